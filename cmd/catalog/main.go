@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/config"
+	"e-commerce/controllers"
+	"e-commerce/db"
+	"e-commerce/handlers"
+	"e-commerce/models"
+)
+
+const usage = `usage:
+  catalog import [-dry-run] [-format csv|jsonl] <file>
+  catalog export [-format csv|jsonl] [-o file]`
+
+func main() {
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr}).With().Caller().Logger()
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command, args := os.Args[1], os.Args[2:]
+
+	switch command {
+	case "import":
+		importProducts(args)
+	case "export":
+		exportProducts(args)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// importProducts imports a file of products and prints the finished import job
+func importProducts(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "only check the rows, nothing is saved")
+	format := flags.String("format", "", "csv or jsonl, taken from the file extension when empty")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	data := &models.ProductImportDto{Format: models.ImportFormat(*format), DryRun: *dryRun}
+	if data.Format == "" {
+		data.Format = models.ImportFormatOf(flags.Arg(0))
+	}
+	if data.Format != "" && !data.Format.IsValid() {
+		log.Fatal().Msgf("import: unknown format %s", data.Format)
+	}
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		log.Fatal().Err(err).Msgf("import: %v", err)
+	}
+	defer file.Close()
+
+	job, err := controller().RunProductImport(context.Background(), file, data, nil)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("import: %v", err)
+	}
+	fmt.Printf("import %s %s: %d rows, %d created, %d updated, %d failed\n",
+		job.Id, job.Status, job.TotalRows, job.CreatedRows, job.UpdatedRows, job.FailedRows)
+	for _, rowError := range job.Errors {
+		fmt.Printf("row %d (%s): %v\n", rowError.Row, rowError.Key, rowError.Errors)
+	}
+	if job.FailedRows > 0 {
+		os.Exit(1)
+	}
+}
+
+// exportProducts writes every product to a file, or to stdout
+func exportProducts(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	format := flags.String("format", string(models.IMPORT_CSV), "csv or jsonl")
+	output := flags.String("o", "", "file to write to, stdout when empty")
+	_ = flags.Parse(args)
+
+	if !models.ImportFormat(*format).IsValid() {
+		log.Fatal().Msgf("export: unknown format %s", *format)
+	}
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("export: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := controller().ExportProducts(context.Background(), models.ImportFormat(*format), out); err != nil {
+		log.Fatal().Err(err).Msgf("export: %v", err)
+	}
+}
+
+// controller connects to the database the app is configured with, run from the root folder so .env is found
+func controller() controllers.Operations {
+	configVariables := config.GetConfig()
+	database := db.ConnectDB(*configVariables)
+	return handlers.NewHandler(configVariables, &database).Controller()
+}
@@ -0,0 +1,96 @@
+package carriers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"e-commerce/config"
+)
+
+type TrackingStatus string
+
+const (
+	TRACKING_LABEL_CREATED    TrackingStatus = "label-created"
+	TRACKING_IN_TRANSIT       TrackingStatus = "in-transit"
+	TRACKING_OUT_FOR_DELIVERY TrackingStatus = "out-for-delivery"
+	TRACKING_DELIVERED        TrackingStatus = "delivered"
+	TRACKING_EXCEPTION        TrackingStatus = "exception"
+
+	CARRIER_FAKE = "fake"
+	CARRIER_NONE = "none"
+)
+
+var (
+	ErrUnknownCarrier    = errors.New("unknown carrier")
+	ErrParcelNotFound    = errors.New("parcel not found")
+	ErrFakeCarrierEnv    = errors.New("fake carrier can only be used in local and dev environments")
+	ErrNoCarrier         = errors.New("no carrier is configured")
+	ErrInvalidTracking   = errors.New("tracking status is not valid")
+	ErrParcelDelivered   = errors.New("parcel is already delivered")
+	ErrInvalidLabelInput = errors.New("label needs a destination and a weight")
+)
+
+// Carrier is implemented by every shipping carrier the application can send parcels with
+type Carrier interface {
+	Name() string
+	CreateLabel(ctx context.Context, req *LabelRequest) (*LabelResponse, error)
+	Track(ctx context.Context, trackingNumber string) ([]*TrackingEvent, error)
+}
+
+// LabelRequest is the data needed to book a parcel with a carrier
+type LabelRequest struct {
+	Reference string
+	// weight in grams
+	Weight int64
+	To     Address
+}
+
+// Address is where a parcel is sent to
+type Address struct {
+	Name       string
+	Phone      string
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
+}
+
+// LabelResponse is returned once a carrier has booked a parcel
+type LabelResponse struct {
+	TrackingNumber string
+	LabelUrl       string
+}
+
+// TrackingEvent is a scan of a parcel by the carrier
+type TrackingEvent struct {
+	Status      TrackingStatus
+	Description string
+	Location    string
+	OccurredAt  time.Time
+}
+
+// NewCarrier returns the carrier selected in the configuration
+func NewCarrier(config *config.ConfigType) (Carrier, error) {
+	switch config.Carrier {
+	case CARRIER_FAKE:
+		if !config.IsDevelopment() {
+			return nil, ErrFakeCarrierEnv
+		}
+		return NewFakeCarrier(), nil
+	case CARRIER_NONE:
+		return NoCarrier{}, nil
+	}
+	return nil, ErrUnknownCarrier
+}
+
+// IsValid checks if status is valid
+func (t TrackingStatus) IsValid() bool {
+	switch t {
+	case TRACKING_LABEL_CREATED, TRACKING_IN_TRANSIT, TRACKING_OUT_FOR_DELIVERY, TRACKING_DELIVERED, TRACKING_EXCEPTION:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package carriers
+
+import (
+	"testing"
+
+	"e-commerce/config"
+)
+
+func TestNewCarrier(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		carrier string
+		want    string
+		err     error
+	}{
+		{name: "fake locally", env: "local", carrier: CARRIER_FAKE, want: CARRIER_FAKE},
+		{name: "fake in dev", env: "dev", carrier: CARRIER_FAKE, want: CARRIER_FAKE},
+		{name: "fake in staging", env: "stg", carrier: CARRIER_FAKE, err: ErrFakeCarrierEnv},
+		{name: "fake in production", env: "prod", carrier: CARRIER_FAKE, err: ErrFakeCarrierEnv},
+		{name: "none in production", env: "prod", carrier: CARRIER_NONE, want: CARRIER_NONE},
+		{name: "unknown", env: "prod", carrier: "dhl", err: ErrUnknownCarrier},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carrier, err := NewCarrier(&config.ConfigType{AppEnv: tt.env, Carrier: tt.carrier})
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err == nil && carrier.Name() != tt.want {
+				t.Errorf("carrier = %s, want %s", carrier.Name(), tt.want)
+			}
+		})
+	}
+}
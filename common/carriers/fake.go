@@ -0,0 +1,97 @@
+package carriers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"e-commerce/helpers"
+)
+
+// FakeCarrier is an in-memory carrier for local development.
+// Parcels only move when an admin scans them.
+type FakeCarrier struct {
+	mu      sync.Mutex
+	parcels map[string]*fakeParcel
+}
+
+type fakeParcel struct {
+	reference string
+	events    []*TrackingEvent
+}
+
+// NewFakeCarrier instantiates the fake carrier
+func NewFakeCarrier() *FakeCarrier {
+	return &FakeCarrier{
+		parcels: map[string]*fakeParcel{},
+	}
+}
+
+func (f *FakeCarrier) Name() string {
+	return CARRIER_FAKE
+}
+
+// CreateLabel books a parcel and returns its tracking number
+func (f *FakeCarrier) CreateLabel(ctx context.Context, req *LabelRequest) (*LabelResponse, error) {
+	if req.To.Country == "" || req.Weight <= 0 {
+		return nil, ErrInvalidLabelInput
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	trackingNumber := "FAKE-TRK-" + helpers.GenerateUniqueReferenceId(12)
+	f.parcels[trackingNumber] = &fakeParcel{
+		reference: req.Reference,
+		events: []*TrackingEvent{{
+			Status:      TRACKING_LABEL_CREATED,
+			Description: "shipping label created",
+			OccurredAt:  time.Now().UTC(),
+		}},
+	}
+
+	return &LabelResponse{
+		TrackingNumber: trackingNumber,
+		LabelUrl:       fmt.Sprintf("https://carrier.fake/labels/%s.pdf", trackingNumber),
+	}, nil
+}
+
+// Track returns every scan of a parcel, oldest first
+func (f *FakeCarrier) Track(ctx context.Context, trackingNumber string) ([]*TrackingEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parcel, ok := f.parcels[trackingNumber]
+	if !ok {
+		return nil, ErrParcelNotFound
+	}
+	events := make([]*TrackingEvent, len(parcel.events))
+	copy(events, parcel.events)
+	return events, nil
+}
+
+// Scan records a scan of a parcel, standing in for the carrier moving it
+func (f *FakeCarrier) Scan(trackingNumber string, status TrackingStatus, location string) error {
+	if !status.IsValid() || status == TRACKING_LABEL_CREATED {
+		return ErrInvalidTracking
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parcel, ok := f.parcels[trackingNumber]
+	if !ok {
+		return ErrParcelNotFound
+	}
+	if parcel.events[len(parcel.events)-1].Status == TRACKING_DELIVERED {
+		return ErrParcelDelivered
+	}
+	parcel.events = append(parcel.events, &TrackingEvent{
+		Status:      status,
+		Description: fmt.Sprintf("parcel %s", status),
+		Location:    location,
+		OccurredAt:  time.Now().UTC(),
+	})
+	return nil
+}
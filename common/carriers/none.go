@@ -0,0 +1,18 @@
+package carriers
+
+import "context"
+
+// NoCarrier stands in for a carrier where none is configured, no parcel can be booked or tracked with it
+type NoCarrier struct{}
+
+func (NoCarrier) Name() string {
+	return CARRIER_NONE
+}
+
+func (NoCarrier) CreateLabel(ctx context.Context, req *LabelRequest) (*LabelResponse, error) {
+	return nil, ErrNoCarrier
+}
+
+func (NoCarrier) Track(ctx context.Context, trackingNumber string) ([]*TrackingEvent, error) {
+	return nil, ErrNoCarrier
+}
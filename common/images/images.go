@@ -0,0 +1,158 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+const (
+	CONTENT_TYPE_JPEG = "image/jpeg"
+	CONTENT_TYPE_PNG  = "image/png"
+	CONTENT_TYPE_GIF  = "image/gif"
+
+	// JPEG_QUALITY is the quality thumbnails are encoded with
+	JPEG_QUALITY = 85
+	// MAX_PIXELS is the most pixels an image can have, small files can still decode to huge images
+	MAX_PIXELS = 40_000_000
+)
+
+var (
+	ErrUnsupportedType = errors.New("image type is not supported")
+	ErrInvalidImage    = errors.New("image could not be decoded")
+	ErrTooManyPixels   = errors.New("image has too many pixels")
+)
+
+// THUMBNAIL_SIZES are the longest sides, in pixels, of the thumbnails made for every image
+var THUMBNAIL_SIZES = map[string]int{
+	"small":  150,
+	"medium": 400,
+	"large":  800,
+}
+
+// Extensions are the file extensions of the supported content types
+var Extensions = map[string]string{
+	CONTENT_TYPE_JPEG: "jpg",
+	CONTENT_TYPE_PNG:  "png",
+	CONTENT_TYPE_GIF:  "gif",
+}
+
+// DetectContentType gets the content type of an image from its first bytes, never from what the client claims
+func DetectContentType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := Extensions[contentType]; !ok {
+		return "", ErrUnsupportedType
+	}
+	return contentType, nil
+}
+
+// Decode reads an image of a supported content type into RGBA, so every thumbnail is made from the same
+// pixels. Its dimensions are read first and images of more than MAX_PIXELS are not decoded.
+func Decode(data []byte, contentType string) (*image.RGBA, error) {
+	var decode func(io.Reader) (image.Image, error)
+	var decodeConfig func(io.Reader) (image.Config, error)
+	switch contentType {
+	case CONTENT_TYPE_JPEG:
+		decode, decodeConfig = jpeg.Decode, jpeg.DecodeConfig
+	case CONTENT_TYPE_PNG:
+		decode, decodeConfig = png.Decode, png.DecodeConfig
+	case CONTENT_TYPE_GIF:
+		decode, decodeConfig = gif.Decode, gif.DecodeConfig
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	config, err := decodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if int64(config.Width)*int64(config.Height) > MAX_PIXELS {
+		return nil, ErrTooManyPixels
+	}
+	img, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba, nil
+}
+
+// Encode writes a thumbnail of an image of contentType. JPEGs stay JPEGs, PNGs and the first frame
+// of GIFs become PNGs so transparency is kept. It returns the content type of the thumbnail.
+func Encode(img image.Image, contentType string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if contentType == CONTENT_TYPE_JPEG {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: JPEG_QUALITY}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), CONTENT_TYPE_JPEG, nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), CONTENT_TYPE_PNG, nil
+}
+
+// Thumbnail scales a decoded image down so its longest side is at most size, keeping its aspect ratio.
+// Each pixel of the thumbnail is the average of the pixels it covers, images are never scaled up.
+func Thumbnail(src *image.RGBA, size int) image.Image {
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	thumbWidth, thumbHeight := fit(width, height, size)
+	if thumbWidth == width && thumbHeight == height {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		y0, y1 := span(y, height, thumbHeight)
+		for x := 0; x < thumbWidth; x++ {
+			x0, x1 := span(x, width, thumbWidth)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(src.Pix[offset])
+					g += uint64(src.Pix[offset+1])
+					b += uint64(src.Pix[offset+2])
+					a += uint64(src.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = uint8(a / count)
+		}
+	}
+	return dst
+}
+
+// fit gets the dimensions of an image scaled down to fit in a size by size square
+func fit(width, height, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+	if width >= height {
+		return size, max(1, height*size/width)
+	}
+	return max(1, width*size/height), size
+}
+
+// span gets the source pixels, from and up to, covered by pixel i of a side scaled from length to scaled
+func span(i, length, scaled int) (int, int) {
+	from := i * length / scaled
+	to := (i + 1) * length / scaled
+	return from, max(to, from+1)
+}
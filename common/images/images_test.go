@@ -0,0 +1,104 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name                  string
+		width, height, size   int
+		wantWidth, wantHeight int
+	}{
+		{name: "smaller is kept", width: 100, height: 50, size: 150, wantWidth: 100, wantHeight: 50},
+		{name: "exact size is kept", width: 150, height: 150, size: 150, wantWidth: 150, wantHeight: 150},
+		{name: "landscape", width: 1600, height: 900, size: 800, wantWidth: 800, wantHeight: 450},
+		{name: "portrait", width: 900, height: 1600, size: 800, wantWidth: 450, wantHeight: 800},
+		{name: "square", width: 1000, height: 1000, size: 400, wantWidth: 400, wantHeight: 400},
+		{name: "thin side keeps a pixel", width: 10000, height: 2, size: 150, wantWidth: 150, wantHeight: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := fit(tt.width, tt.height, tt.size)
+			if width != tt.wantWidth || height != tt.wantHeight {
+				t.Errorf("fit(%d, %d, %d) = %d, %d, want %d, %d", tt.width, tt.height, tt.size, width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	// left half red and right half blue, with the right half transparent
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				src.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				src.Set(x, y, color.RGBA{})
+			}
+		}
+	}
+	tests := []struct {
+		name   string
+		size   int
+		bounds image.Rectangle
+		pixels []color.RGBA
+	}{
+		{name: "not scaled up", size: 10, bounds: image.Rect(0, 0, 4, 2)},
+		{name: "halves average their pixels", size: 2, bounds: image.Rect(0, 0, 2, 1), pixels: []color.RGBA{{R: 255, A: 255}, {}}},
+		{name: "single pixel averages all", size: 1, bounds: image.Rect(0, 0, 1, 1), pixels: []color.RGBA{{R: 127, A: 127}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnail := Thumbnail(src, tt.size)
+			if thumbnail.Bounds() != tt.bounds {
+				t.Fatalf("bounds = %v, want %v", thumbnail.Bounds(), tt.bounds)
+			}
+			for i, want := range tt.pixels {
+				x, y := i%tt.bounds.Dx(), i/tt.bounds.Dx()
+				if got := thumbnail.(*image.RGBA).RGBAAt(x, y); got != want {
+					t.Errorf("pixel (%d, %d) = %v, want %v", x, y, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 3, 2))); err != nil {
+		t.Fatal(err)
+	}
+	// a GIF header of 10000 by 10000 pixels, rejected before any pixel is decoded
+	largeGif := []byte{'G', 'I', 'F', '8', '9', 'a', 0x10, 0x27, 0x10, 0x27, 0, 0, 0, 0x3b}
+
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		bounds      image.Rectangle
+		err         error
+	}{
+		{name: "png", data: buf.Bytes(), contentType: CONTENT_TYPE_PNG, bounds: image.Rect(0, 0, 3, 2)},
+		{name: "unsupported type", data: buf.Bytes(), contentType: "image/webp", err: ErrUnsupportedType},
+		{name: "wrong type", data: buf.Bytes(), contentType: CONTENT_TYPE_JPEG, err: ErrInvalidImage},
+		{name: "truncated", data: buf.Bytes()[:20], contentType: CONTENT_TYPE_PNG, err: ErrInvalidImage},
+		{name: "too many pixels", data: largeGif, contentType: CONTENT_TYPE_GIF, err: ErrTooManyPixels},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := Decode(tt.data, tt.contentType)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err == nil && img.Bounds() != tt.bounds {
+				t.Errorf("bounds = %v, want %v", img.Bounds(), tt.bounds)
+			}
+		})
+	}
+}
@@ -3,19 +3,130 @@ package messages
 import "errors"
 
 var (
-	ErrNoDataFound                   = errors.New("no data found")
-	ErrUserNotFound                  = errors.New("user not found")
-	ErrOrderNotFound                 = errors.New("order not found")
-	ErrOrderCannotBeCancelled        = errors.New("order cannot be cancelled")
-	ErrCancelledOrderCannotBeUpdated = errors.New("cancelled order cannot be updated")
-	ErrProductNotFound               = errors.New("product not found")
-	ErrUserWithEmailAlreadyExists    = errors.New("user with email already exists")
-	ErrProductWithNameAlreadyExists  = errors.New("product with name already exists")
-	ErrInvalidToken                  = errors.New("invalid token")
-	ErrAccessDenied                  = errors.New("access denied")
-	ErrWrongPassword                 = errors.New("wrong password")
-	ErrCouldNotGenerateToken         = errors.New("could not generate user token")
-	ErrTaskWithSlugAlreadyExists     = errors.New("task with slug already exists")
-	ErrInvalidInput                  = errors.New("invalid input")
-	ErrServerError                   = errors.New("server error")
+	ErrNoDataFound                    = errors.New("no data found")
+	ErrUserNotFound                   = errors.New("user not found")
+	ErrOrderNotFound                  = errors.New("order not found")
+	ErrOrderCannotBeCancelled         = errors.New("order cannot be cancelled")
+	ErrCancelledOrderCannotBeUpdated  = errors.New("cancelled order cannot be updated")
+	ErrOrderCannotBePaid              = errors.New("order cannot be paid for")
+	ErrOrderAlreadyPaid               = errors.New("order has already been paid for")
+	ErrOrderNotPaid                   = errors.New("order has not been paid for")
+	ErrPaymentNotFound                = errors.New("payment not found")
+	ErrPaymentAmountMismatch          = errors.New("payment amount does not match order")
+	ErrPaymentProviderNotSupported    = errors.New("payment provider not supported")
+	ErrOrderNotRefundable             = errors.New("order has no payment to refund")
+	ErrOrderRecordNotFound            = errors.New("order record not found")
+	ErrRefundNotFound                 = errors.New("refund not found")
+	ErrRefundAmountWithItems          = errors.New("refund takes either an amount or items, not both")
+	ErrRefundDueByAmount              = errors.New("payments due a refund are refunded by amount, not items")
+	ErrRefundExceedsPayment           = errors.New("refund exceeds the refundable amount")
+	ErrRefundFailed                   = errors.New("refund was declined by the payment provider")
+	ErrRefundQuantityExceeded         = errors.New("refund quantity exceeds the refundable quantity")
+	ErrInvalidOrderStatus             = errors.New("order status cannot be set directly")
+	ErrCouponNotFound                 = errors.New("coupon not found")
+	ErrCouponWithCodeAlreadyExists    = errors.New("coupon with code already exists")
+	ErrCouponNotActive                = errors.New("coupon is not active")
+	ErrCouponUsageLimitReached        = errors.New("coupon usage limit reached")
+	ErrCouponUserLimitReached         = errors.New("coupon already used the maximum number of times")
+	ErrCouponMinOrderAmount           = errors.New("order amount is below the coupon minimum")
+	ErrCouponNotApplicable            = errors.New("coupon does not apply to any item in the order")
+	ErrInvalidCouponValue             = errors.New("percentage coupons cannot exceed 100")
+	ErrCouponCurrencyRequired         = errors.New("fixed coupons and coupons with a maximum discount or minimum order amount need a currency")
+	ErrPromotionNotFound              = errors.New("promotion not found")
+	ErrInvalidPromotionRules          = errors.New("promotion rules do not match the promotion type")
+	ErrPromotionCurrencyRequired      = errors.New("promotions with amounts need a currency")
+	ErrCurrencyMismatch               = errors.New("money currencies do not match")
+	ErrMoneyOverflow                  = errors.New("money amount out of range")
+	ErrNegativeMoney                  = errors.New("money amount cannot be negative")
+	ErrDiscountExceedsPrice           = errors.New("discount cannot exceed the price")
+	ErrExchangeRateNotFound           = errors.New("no exchange rate between the currencies")
+	ErrInvalidCurrencyPair            = errors.New("exchange rates need two different supported currencies")
+	ErrInvalidExchangeRate            = errors.New("exchange rate must be a positive decimal")
+	ErrDuplicateProductPrice          = errors.New("product has more than one price for a currency")
+	ErrTaxRateNotFound                = errors.New("tax rate not found")
+	ErrTaxRateAlreadyExists           = errors.New("tax rate for the country, region and tax class already exists")
+	ErrInvalidTaxRate                 = errors.New("tax rate must be a percent from 0 to 100")
+	ErrAddressNotFound                = errors.New("address not found")
+	ErrInvalidAddress                 = errors.New("address needs a full name, first line and city")
+	ErrAddressRegionRequired          = errors.New("address needs a region in its country")
+	ErrAddressPostalCodeRequired      = errors.New("address needs a postal code in its country")
+	ErrInvalidPostalCode              = errors.New("postal code is not valid for the address country")
+	ErrShippingAddressRequired        = errors.New("order needs a shipping address")
+	ErrShippingZoneNotFound           = errors.New("shipping zone not found")
+	ErrShippingMethodNotFound         = errors.New("shipping method not found")
+	ErrInvalidShippingRates           = errors.New("shipping rates do not match the shipping method type")
+	ErrNoShippingMethod               = errors.New("no shipping method delivers to the address")
+	ErrShippingMethodNotAvailable     = errors.New("shipping method does not deliver this order to the address")
+	ErrShipmentNotFound               = errors.New("shipment not found")
+	ErrOrderNotShippable              = errors.New("only orders being processed can be shipped")
+	ErrNothingToShip                  = errors.New("order has nothing left to ship")
+	ErrShipmentQuantityExceeded       = errors.New("shipment quantity exceeds the quantity left to ship")
+	ErrCarrierNotSupported            = errors.New("shipment carrier is not the configured carrier")
+	ErrReturnNotFound                 = errors.New("return not found")
+	ErrOrderNotReturnable             = errors.New("only delivered orders can be returned")
+	ErrProductNotReturnable           = errors.New("product cannot be returned")
+	ErrReturnWindowClosed             = errors.New("return window for the product has closed")
+	ErrReturnQuantityExceeded         = errors.New("return quantity exceeds the quantity left to return")
+	ErrRestockQuantityExceeded        = errors.New("restock quantity exceeds the unshipped quantity left to restock")
+	ErrInvalidReturnStatus            = errors.New("return cannot move to that status")
+	ErrCategoryNotFound               = errors.New("category not found")
+	ErrCategoryWithNameAlreadyExists  = errors.New("category with name already exists")
+	ErrCategoryCycle                  = errors.New("category cannot be moved under itself or one of its subcategories")
+	ErrCategoryNotEmpty               = errors.New("category with subcategories or products cannot be deleted")
+	ErrProductNotFound                = errors.New("product not found")
+	ErrProductVariantNotFound         = errors.New("product variant not found")
+	ErrProductInUse                   = errors.New("product with orders cannot be deleted")
+	ErrProductVariantInUse            = errors.New("product variant with orders cannot be deleted")
+	ErrPriceFilterCurrencyRequired    = errors.New("a currency is needed to filter by price")
+	ErrInvalidPriceRange              = errors.New("minimum price cannot be more than maximum price")
+	ErrInvalidOptionFilter            = errors.New("option filters must be given as name:value")
+	ErrCategoryAttributeNotFound      = errors.New("category attribute not found")
+	ErrAttributeWithKeyAlreadyExists  = errors.New("category already has an attribute with this key")
+	ErrInvalidAttributeKey            = errors.New("attribute keys are lowercase letters, digits and underscores, starting with a letter")
+	ErrAttributeValuesRequired        = errors.New("enum attributes need values and only they can have them")
+	ErrUnknownAttribute               = errors.New("product attribute is not defined by any of its categories")
+	ErrInvalidAttributeValue          = errors.New("product attribute value does not fit its type")
+	ErrAttributeRequired              = errors.New("a required product attribute is missing")
+	ErrImportJobNotFound              = errors.New("import job not found")
+	ErrImportFormatRequired           = errors.New("import format could not be told from the file, set it to csv or jsonl")
+	ErrImportTooLarge                 = errors.New("import file is larger than the maximum import size")
+	ErrImportKeyRequired              = errors.New("import row needs a slug, a name or a sku")
+	ErrImportUnknownColumn            = errors.New("import file has a column that is not a product column")
+	ErrImportCurrencyChange           = errors.New("currency of an existing product cannot change")
+	ErrImportVariantProductMismatch   = errors.New("variant with this sku belongs to another product")
+	ErrImportInvalidNumber            = errors.New("number columns must be whole numbers")
+	ErrMediaNotFound                  = errors.New("product media not found")
+	ErrMediaFileRequired              = errors.New("a file is needed")
+	ErrMediaTooLarge                  = errors.New("file is larger than the maximum media size")
+	ErrUnsupportedMediaType           = errors.New("only jpeg, png and gif images can be uploaded")
+	ErrInvalidImage                   = errors.New("image could not be read")
+	ErrImageTooLarge                  = errors.New("image has more than the maximum number of pixels")
+	ErrVariantWithSkuAlreadyExists    = errors.New("variant with sku already exists")
+	ErrVariantRequired                = errors.New("product is sold through variants, choose one")
+	ErrInvalidVariantOptions          = errors.New("variant needs one of the allowed values for every option of the product")
+	ErrDuplicateVariant               = errors.New("product already has a variant with those options")
+	ErrDuplicateProductOption         = errors.New("product has more than one option with a name")
+	ErrProductNotAvailable            = errors.New("product is not available")
+	ErrWarehouseNotFound              = errors.New("warehouse not found")
+	ErrWarehouseWithCodeAlreadyExists = errors.New("warehouse with this code already exists")
+	ErrDefaultWarehouseRequired       = errors.New("default warehouse must stay active, make another warehouse the default first")
+	ErrInsufficientStock              = errors.New("insufficient stock for product")
+	ErrReservationNotActive           = errors.New("stock reservation is no longer active")
+	ErrProductInStock                 = errors.New("product is in stock, it can be ordered now")
+	ErrAlreadySubscribedToProduct     = errors.New("you will already be notified when this product is back in stock")
+	ErrStockSubscriptionNotFound      = errors.New("stock subscription not found")
+	ErrCartNotFound                   = errors.New("cart not found")
+	ErrCartItemNotFound               = errors.New("cart item not found")
+	ErrCartIsEmpty                    = errors.New("cart is empty")
+	ErrUserWithEmailAlreadyExists     = errors.New("user with email already exists")
+	ErrProductWithNameAlreadyExists   = errors.New("product with name already exists")
+	ErrInvalidToken                   = errors.New("invalid token")
+	ErrAccessDenied                   = errors.New("access denied")
+	ErrWrongPassword                  = errors.New("wrong password")
+	ErrCouldNotGenerateToken          = errors.New("could not generate user token")
+	ErrTaskWithSlugAlreadyExists      = errors.New("task with slug already exists")
+	ErrTooManyRequests                = errors.New("too many requests, try again later")
+	ErrInvalidInput                   = errors.New("invalid input")
+	ErrInvalidFilter                  = errors.New("filter must be field|condition|value entries on a filterable field with eq, ne, gt, lt, like or in")
+	ErrServerError                    = errors.New("server error")
 )
@@ -10,6 +10,7 @@ import (
 	"e-commerce/repo"
 
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
@@ -19,6 +20,10 @@ import (
 const (
 	authorizationHeader = "Authorization"
 	authorizationBearer = "Bearer"
+
+	// lookups of an order by tracking code allowed per client and per code in a window
+	TRACKING_RATE_LIMIT  = 10
+	TRACKING_RATE_WINDOW = time.Minute
 )
 
 type TokenMaker interface {
@@ -27,10 +32,11 @@ type TokenMaker interface {
 }
 
 type Middleware struct {
-	Jwt      TokenMaker
-	logger   *zerolog.Logger
-	userRepo repo.UserRepo
-	config   *config.ConfigType
+	Jwt             TokenMaker
+	TrackingLimiter *RateLimiter
+	logger          *zerolog.Logger
+	userRepo        repo.UserRepo
+	config          *config.ConfigType
 }
 
 func NewMiddleware(db *db.Database, config *config.ConfigType) (*Middleware, error) {
@@ -42,10 +48,11 @@ func NewMiddleware(db *db.Database, config *config.ConfigType) (*Middleware, err
 	}
 
 	m := &Middleware{
-		Jwt:      jwt,
-		logger:   &l,
-		config:   config,
-		userRepo: repo.NewUserRepo(db),
+		Jwt:             jwt,
+		TrackingLimiter: NewRateLimiter(TRACKING_RATE_LIMIT, TRACKING_RATE_WINDOW),
+		logger:          &l,
+		config:          config,
+		userRepo:        repo.NewUserRepo(db),
 	}
 
 	return m, nil
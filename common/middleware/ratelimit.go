@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter allows a number of hits per key in a fixed window of time, in memory
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+	mu     sync.Mutex
+	hits   map[string]*rateWindow
+	// when keys whose window has ended were last forgotten
+	sweptAt time.Time
+}
+
+type rateWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+// NewRateLimiter instantiates a rate limiter of limit hits per window
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:  limit,
+		window: window,
+		hits:   map[string]*rateWindow{},
+	}
+}
+
+// Allow counts a hit for a key, returning false and how long until the key may try again
+// when it is over the limit
+func (r *RateLimiter) Allow(key string) (bool, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	hits, ok := r.hits[key]
+	if !ok || !now.Before(hits.resetAt) {
+		r.sweep(now)
+		hits = &rateWindow{resetAt: now.Add(r.window)}
+		r.hits[key] = hits
+	}
+	if hits.count >= r.limit {
+		return false, hits.resetAt.Sub(now)
+	}
+	hits.count++
+	return true, 0
+}
+
+// sweep forgets the keys whose window has ended, at most once a window, so the limiter
+// does not grow without bound
+func (r *RateLimiter) sweep(now time.Time) {
+	if now.Sub(r.sweptAt) < r.window {
+		return
+	}
+	r.sweptAt = now
+	for key, hits := range r.hits {
+		if !now.Before(hits.resetAt) {
+			delete(r.hits, key)
+		}
+	}
+}
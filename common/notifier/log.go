@@ -0,0 +1,28 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LogNotifier writes notifications to the application log instead of sending them, for local development
+type LogNotifier struct{}
+
+// NewLogNotifier instantiates the log notifier
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (l *LogNotifier) Name() string {
+	return NOTIFIER_LOG
+}
+
+// Notify logs a notification
+func (l *LogNotifier) Notify(ctx context.Context, notification *Notification) error {
+	if notification.To == "" {
+		return ErrInvalidRecipient
+	}
+	log.Info().Str("to", notification.To).Str("subject", notification.Subject).Msg(notification.Body)
+	return nil
+}
@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+
+	"e-commerce/config"
+)
+
+const (
+	NOTIFIER_LOG = "log"
+)
+
+var (
+	ErrUnknownNotifier  = errors.New("unknown notifier")
+	ErrInvalidRecipient = errors.New("notification needs a recipient")
+)
+
+// Notifier is implemented by every channel notifications can be sent to customers and admins through
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, notification *Notification) error
+}
+
+// Notification is a message for a single recipient
+type Notification struct {
+	// email address of the recipient
+	To      string
+	Subject string
+	Body    string
+}
+
+// NewNotifier returns the notifier selected in the configuration
+func NewNotifier(config *config.ConfigType) (Notifier, error) {
+	switch config.Notifier {
+	case NOTIFIER_LOG:
+		return NewLogNotifier(), nil
+	}
+	return nil, ErrUnknownNotifier
+}
@@ -0,0 +1,153 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"e-commerce/helpers"
+)
+
+// FakeProvider is an in-memory payment provider for local development.
+// Charges stay pending until an admin completes them on the authorization url.
+type FakeProvider struct {
+	baseUrl       string
+	webhookSecret string
+	mu            sync.Mutex
+	charges       map[string]*fakeCharge
+}
+
+type fakeCharge struct {
+	reference string
+	amount    int64
+	refunded  int64
+	currency  string
+	status    ChargeStatus
+}
+
+// fakeWebhook is the payload the fake provider sends to the webhook endpoint
+type fakeWebhook struct {
+	Id    string `json:"id"`
+	Event string `json:"event"`
+	Data  struct {
+		Reference string `json:"reference"`
+		Amount    int64  `json:"amount"`
+		Currency  string `json:"currency"`
+	} `json:"data"`
+}
+
+// NewFakeProvider instantiates the fake payment provider
+func NewFakeProvider(baseUrl, webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		baseUrl:       baseUrl,
+		webhookSecret: webhookSecret,
+		charges:       map[string]*fakeCharge{},
+	}
+}
+
+func (f *FakeProvider) Name() string {
+	return PROVIDER_FAKE
+}
+
+// Initialize registers a pending charge
+func (f *FakeProvider) Initialize(ctx context.Context, req *InitializeRequest) (*InitializeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	providerReference := "FAKE-" + helpers.GenerateUniqueReferenceId(16)
+	f.charges[providerReference] = &fakeCharge{
+		reference: req.Reference,
+		amount:    req.Amount,
+		currency:  req.Currency,
+		status:    CHARGE_PENDING,
+	}
+
+	return &InitializeResponse{
+		ProviderReference: providerReference,
+		AuthorizationUrl:  fmt.Sprintf("%s/payments/fake/%s", f.baseUrl, providerReference),
+	}, nil
+}
+
+// Verify returns the current state of a charge
+func (f *FakeProvider) Verify(ctx context.Context, providerReference string) (*VerifyResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	charge, ok := f.charges[providerReference]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+	return &VerifyResponse{
+		ProviderReference: providerReference,
+		Status:            charge.status,
+		Amount:            charge.amount,
+		Currency:          charge.currency,
+	}, nil
+}
+
+// Refund refunds part or all of a successful charge
+func (f *FakeProvider) Refund(ctx context.Context, req *RefundRequest) (*RefundResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	charge, ok := f.charges[req.ProviderReference]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+	if charge.status != CHARGE_SUCCESS || req.Amount <= 0 || charge.refunded+req.Amount > charge.amount {
+		return nil, ErrInvalidRefund
+	}
+	charge.refunded += req.Amount
+
+	return &RefundResponse{
+		ProviderReference: "FAKE-RF-" + helpers.GenerateUniqueReferenceId(16),
+		Status:            CHARGE_SUCCESS,
+	}, nil
+}
+
+// Complete settles a pending charge, standing in for the customer paying on the provider's page
+func (f *FakeProvider) Complete(providerReference string, status ChargeStatus) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	charge, ok := f.charges[providerReference]
+	if !ok {
+		return ErrChargeNotFound
+	}
+	if charge.status == CHARGE_PENDING {
+		charge.status = status
+	}
+	return nil
+}
+
+// ParseWebhook verifies and decodes a webhook signed with the configured webhook secret
+func (f *FakeProvider) ParseWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	if !VerifySignature(payload, signature, f.webhookSecret) {
+		return nil, ErrInvalidSignature
+	}
+
+	var webhook fakeWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil || webhook.Id == "" {
+		return nil, ErrInvalidWebhook
+	}
+
+	event := &WebhookEvent{
+		Id:                webhook.Id,
+		Type:              webhook.Event,
+		ProviderReference: webhook.Data.Reference,
+		Amount:            webhook.Data.Amount,
+		Currency:          webhook.Data.Currency,
+	}
+	switch webhook.Event {
+	case "charge.success":
+		event.Status = CHARGE_SUCCESS
+	case "charge.failed":
+		event.Status = CHARGE_FAILED
+	case "refund.processed":
+		event.Refund, event.Status = true, CHARGE_SUCCESS
+	case "refund.failed":
+		event.Refund, event.Status = true, CHARGE_FAILED
+	}
+	return event, nil
+}
@@ -0,0 +1,27 @@
+package payments
+
+import "context"
+
+// NoProvider stands in for a payment provider where none is configured, nothing can be charged or refunded
+// through it
+type NoProvider struct{}
+
+func (NoProvider) Name() string {
+	return PROVIDER_NONE
+}
+
+func (NoProvider) Initialize(ctx context.Context, req *InitializeRequest) (*InitializeResponse, error) {
+	return nil, ErrNoProvider
+}
+
+func (NoProvider) Verify(ctx context.Context, providerReference string) (*VerifyResponse, error) {
+	return nil, ErrNoProvider
+}
+
+func (NoProvider) Refund(ctx context.Context, req *RefundRequest) (*RefundResponse, error) {
+	return nil, ErrNoProvider
+}
+
+func (NoProvider) ParseWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	return nil, ErrNoProvider
+}
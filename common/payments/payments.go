@@ -0,0 +1,124 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"e-commerce/config"
+)
+
+type ChargeStatus string
+
+const (
+	CHARGE_PENDING ChargeStatus = "pending"
+	CHARGE_SUCCESS ChargeStatus = "success"
+	CHARGE_FAILED  ChargeStatus = "failed"
+
+	PROVIDER_FAKE = "fake"
+	PROVIDER_NONE = "none"
+)
+
+var (
+	ErrUnknownProvider  = errors.New("unknown payment provider")
+	ErrChargeNotFound   = errors.New("charge not found")
+	ErrInvalidRefund    = errors.New("refund amount is not valid for charge")
+	ErrFakeProviderEnv  = errors.New("fake payment provider can only be used in local and dev environments")
+	ErrNoProvider       = errors.New("no payment provider is configured")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidWebhook   = errors.New("invalid webhook payload")
+)
+
+// Provider is implemented by every payment gateway the application can charge through
+type Provider interface {
+	RefundProvider
+
+	Name() string
+	Initialize(ctx context.Context, req *InitializeRequest) (*InitializeResponse, error)
+	Verify(ctx context.Context, providerReference string) (*VerifyResponse, error)
+	ParseWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
+
+// RefundProvider is implemented by payment gateways that can return money on a successful charge
+type RefundProvider interface {
+	Refund(ctx context.Context, req *RefundRequest) (*RefundResponse, error)
+}
+
+// InitializeRequest is the data needed to start a charge
+type InitializeRequest struct {
+	Reference string
+	Amount    int64
+	Currency  string
+	Email     string
+}
+
+// InitializeResponse is returned once a charge has been started
+type InitializeResponse struct {
+	ProviderReference string
+	AuthorizationUrl  string
+}
+
+// VerifyResponse is the state of a charge as known by the provider
+type VerifyResponse struct {
+	ProviderReference string
+	Status            ChargeStatus
+	Amount            int64
+	Currency          string
+}
+
+// RefundRequest is the data needed to refund a charge, fully or partially
+type RefundRequest struct {
+	ProviderReference string
+	Reference         string
+	Amount            int64
+	Currency          string
+}
+
+// RefundResponse is returned once a refund has been accepted by the provider
+type RefundResponse struct {
+	ProviderReference string
+	Status            ChargeStatus
+}
+
+// WebhookEvent is an asynchronous notification about a charge, or a refund on it, sent by a provider.
+// Status is empty for event types the application does not act on.
+type WebhookEvent struct {
+	Id   string
+	Type string
+	// the event settles a refund, ProviderReference is the refund's
+	Refund            bool
+	ProviderReference string
+	Status            ChargeStatus
+	Amount            int64
+	Currency          string
+}
+
+// NewProvider returns the payment provider selected in the configuration
+func NewProvider(config *config.ConfigType) (Provider, error) {
+	switch config.PaymentProvider {
+	case PROVIDER_FAKE:
+		if !config.IsDevelopment() {
+			return nil, ErrFakeProviderEnv
+		}
+		return NewFakeProvider(config.AppUrl, config.PaymentWebhookSecret), nil
+	case PROVIDER_NONE:
+		return NoProvider{}, nil
+	}
+	return nil, ErrUnknownProvider
+}
+
+// VerifySignature checks a hex encoded HMAC-SHA256 signature in constant time
+func VerifySignature(payload []byte, signature, secret string) bool {
+	if secret == "" || signature == "" {
+		return false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,122 @@
+package payments
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"e-commerce/config"
+)
+
+func TestVerifySignature(t *testing.T) {
+	// test case 2 of RFC 4231
+	payload, secret := []byte("what do ya want for nothing?"), "Jefe"
+	signature := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		signature string
+		secret    string
+		want      bool
+	}{
+		{name: "valid", payload: payload, signature: signature, secret: secret, want: true},
+		{name: "upper case hex", payload: payload, signature: strings.ToUpper(signature), secret: secret, want: true},
+		{name: "other payload", payload: []byte("what do ya want for nothing!"), signature: signature, secret: secret},
+		{name: "other secret", payload: payload, signature: signature, secret: "jefe"},
+		{name: "truncated signature", payload: payload, signature: signature[:32], secret: secret},
+		{name: "not hex", payload: payload, signature: "z" + signature[1:], secret: secret},
+		{name: "no signature", payload: payload, signature: "", secret: secret},
+		{name: "no secret", payload: payload, signature: signature, secret: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifySignature(tt.payload, tt.signature, tt.secret); got != tt.want {
+				t.Errorf("VerifySignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFakeProviderParseWebhook(t *testing.T) {
+	const secret = "webhook-secret"
+	sign := func(payload string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(payload))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	charge := `{"id":"evt_1","event":"charge.success","data":{"reference":"ch_1","amount":5000,"currency":"NGN"}}`
+
+	tests := []struct {
+		name      string
+		payload   string
+		signature string
+		want      *WebhookEvent
+		err       error
+	}{
+		{
+			name:      "charge succeeded",
+			payload:   charge,
+			signature: sign(charge),
+			want:      &WebhookEvent{Id: "evt_1", Type: "charge.success", ProviderReference: "ch_1", Status: CHARGE_SUCCESS, Amount: 5000, Currency: "NGN"},
+		},
+		{
+			name:      "refund failed",
+			payload:   `{"id":"evt_2","event":"refund.failed","data":{"reference":"rf_1"}}`,
+			signature: sign(`{"id":"evt_2","event":"refund.failed","data":{"reference":"rf_1"}}`),
+			want:      &WebhookEvent{Id: "evt_2", Type: "refund.failed", Refund: true, ProviderReference: "rf_1", Status: CHARGE_FAILED},
+		},
+		{
+			name:      "unknown events have no status",
+			payload:   `{"id":"evt_3","event":"transfer.success","data":{}}`,
+			signature: sign(`{"id":"evt_3","event":"transfer.success","data":{}}`),
+			want:      &WebhookEvent{Id: "evt_3", Type: "transfer.success"},
+		},
+		{name: "signed with another secret", payload: charge, signature: sign(charge + " "), err: ErrInvalidSignature},
+		{name: "unsigned", payload: charge, err: ErrInvalidSignature},
+		{name: "no event id", payload: `{"event":"charge.success"}`, signature: sign(`{"event":"charge.success"}`), err: ErrInvalidWebhook},
+		{name: "not json", payload: "charge", signature: sign("charge"), err: ErrInvalidWebhook},
+	}
+	provider := NewFakeProvider("http://localhost:7000", secret)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := provider.ParseWebhook([]byte(tt.payload), tt.signature)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tt.want != nil && *event != *tt.want {
+				t.Errorf("event = %+v, want %+v", *event, *tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		provider string
+		want     string
+		err      error
+	}{
+		{name: "fake locally", env: "local", provider: PROVIDER_FAKE, want: PROVIDER_FAKE},
+		{name: "fake in dev", env: "dev", provider: PROVIDER_FAKE, want: PROVIDER_FAKE},
+		{name: "fake in staging", env: "stg", provider: PROVIDER_FAKE, err: ErrFakeProviderEnv},
+		{name: "fake in production", env: "prod", provider: PROVIDER_FAKE, err: ErrFakeProviderEnv},
+		{name: "none in production", env: "prod", provider: PROVIDER_NONE, want: PROVIDER_NONE},
+		{name: "unknown", env: "prod", provider: "paystack", err: ErrUnknownProvider},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewProvider(&config.ConfigType{AppEnv: tt.env, PaymentProvider: tt.provider})
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err == nil && provider.Name() != tt.want {
+				t.Errorf("provider = %s, want %s", provider.Name(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage keeps files in a directory of the server, served by the application under its base url
+type LocalStorage struct {
+	root    string
+	baseUrl string
+}
+
+// NewLocalStorage returns a storage writing under root and linking to files under baseUrl
+func NewLocalStorage(root string, baseUrl string) *LocalStorage {
+	return &LocalStorage{
+		root:    root,
+		baseUrl: strings.TrimRight(baseUrl, "/"),
+	}
+}
+
+func (l *LocalStorage) Name() string {
+	return STORAGE_LOCAL
+}
+
+// Put writes a file, replacing any file already stored under key
+func (l *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	path := filepath.Join(l.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Delete removes a file, files that are already gone are not an error
+func (l *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	err := os.Remove(filepath.Join(l.root, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (l *LocalStorage) URL(key string) string {
+	return l.baseUrl + "/" + key
+}
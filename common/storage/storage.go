@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"e-commerce/config"
+)
+
+const (
+	STORAGE_LOCAL = "local"
+	STORAGE_S3    = "s3"
+)
+
+var (
+	ErrUnknownStorage = errors.New("unknown storage")
+	ErrInvalidKey     = errors.New("storage key is not valid")
+	ErrS3Config       = errors.New("s3 storage needs an endpoint, a bucket and credentials")
+)
+
+// Storage is implemented by every place uploaded files can be kept in
+type Storage interface {
+	Name() string
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL is where the file stored under key can be downloaded from
+	URL(key string) string
+}
+
+// NewStorage returns the storage selected in the configuration
+func NewStorage(config *config.ConfigType) (Storage, error) {
+	switch config.Storage {
+	case STORAGE_LOCAL:
+		mediaUrl := config.MediaUrl
+		if mediaUrl == "" {
+			mediaUrl = strings.TrimRight(config.AppUrl, "/") + "/media"
+		}
+		return NewLocalStorage(config.StoragePath, mediaUrl), nil
+	case STORAGE_S3:
+		if config.S3Endpoint == "" || config.S3Bucket == "" || config.S3AccessKey == "" || config.S3SecretKey == "" {
+			return nil, ErrS3Config
+		}
+		return NewS3Storage(config.S3Endpoint, config.S3Region, config.S3Bucket, config.S3AccessKey, config.S3SecretKey, config.MediaUrl), nil
+	}
+	return nil, ErrUnknownStorage
+}
+
+// checkKey rejects keys that could escape the storage root
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "..") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	return nil
+}
@@ -22,6 +22,31 @@ type ConfigType struct {
 	PGUser          string `validate:"required"`
 	PGPassword      string `validate:"required"`
 	PGDatabase      string `validate:"required"`
+	AppUrl          string
+	// comma separated addresses or CIDRs of the proxies allowed to set the client address
+	TrustedProxies  string
+	PaymentProvider string
+	Carrier         string
+
+	AllocationStrategy       string
+	ReservationTtl           string
+	ReservationSweepInterval string
+
+	Notifier           string
+	StockAlertInterval string
+
+	PaymentWebhookSecret string
+	TrackingPollInterval string
+
+	Storage      string
+	StoragePath  string
+	MediaUrl     string
+	MediaMaxSize string
+	S3Endpoint   string
+	S3Region     string
+	S3Bucket     string
+	S3AccessKey  string
+	S3SecretKey  string
 }
 
 func GetConfig() *ConfigType {
@@ -43,6 +68,45 @@ func GetConfig() *ConfigType {
 		PGUser:          os.Getenv("PG_USER"),
 		PGPassword:      os.Getenv("PG_PASSWORD"),
 		PGDatabase:      os.Getenv("PG_DATABASE"),
+		AppUrl:          helpers.Getenv("APP_URL", "http://localhost:7000"),
+		TrustedProxies:  os.Getenv("TRUSTED_PROXIES"),
+		PaymentProvider: os.Getenv("PAYMENT_PROVIDER"),
+		Carrier:         os.Getenv("CARRIER"),
+
+		AllocationStrategy:       helpers.Getenv("ALLOCATION_STRATEGY", "priority"),
+		ReservationTtl:           helpers.Getenv("RESERVATION_TTL", "30m"),
+		ReservationSweepInterval: helpers.Getenv("RESERVATION_SWEEP_INTERVAL", "1m"),
+
+		Notifier:           helpers.Getenv("NOTIFIER", "log"),
+		StockAlertInterval: helpers.Getenv("STOCK_ALERT_INTERVAL", "15m"),
+
+		PaymentWebhookSecret: os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		TrackingPollInterval: helpers.Getenv("TRACKING_POLL_INTERVAL", "15m"),
+
+		Storage:      helpers.Getenv("STORAGE", "local"),
+		StoragePath:  helpers.Getenv("STORAGE_PATH", "./uploads"),
+		MediaUrl:     os.Getenv("MEDIA_URL"),
+		MediaMaxSize: helpers.Getenv("MEDIA_MAX_SIZE", "5242880"),
+		S3Endpoint:   os.Getenv("S3_ENDPOINT"),
+		S3Region:     helpers.Getenv("S3_REGION", "us-east-1"),
+		S3Bucket:     os.Getenv("S3_BUCKET"),
+		S3AccessKey:  os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:  os.Getenv("S3_SECRET_KEY"),
+	}
+
+	// the fake payment provider and carrier are only the defaults in development, elsewhere payments and
+	// shipping are off until a provider and carrier are set
+	if ConfigVariables.PaymentProvider == "" {
+		ConfigVariables.PaymentProvider = "none"
+		if ConfigVariables.IsDevelopment() {
+			ConfigVariables.PaymentProvider = "fake"
+		}
+	}
+	if ConfigVariables.Carrier == "" {
+		ConfigVariables.Carrier = "none"
+		if ConfigVariables.IsDevelopment() {
+			ConfigVariables.Carrier = "fake"
+		}
 	}
 
 	errs := helpers.ValidateInput(ConfigVariables)
@@ -55,3 +119,9 @@ func GetConfig() *ConfigType {
 	}
 	return &ConfigVariables
 }
+
+// IsDevelopment checks if the app runs locally or in the dev environment, the only ones fakes of outside
+// services can be used in
+func (c *ConfigType) IsDevelopment() bool {
+	return c.AppEnv == "local" || c.AppEnv == "dev"
+}
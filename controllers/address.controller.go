@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// CreateAddress adds an address to the user's address book, the first address becomes
+// the default shipping and billing address
+func (c *Controller) CreateAddress(ctx context.Context, data *models.CreateAddressDto, user *models.User) *models.ResponseObject {
+	address := &models.Address{
+		Id:                uuid.New(),
+		UserId:            user.Id,
+		FullName:          data.FullName,
+		Phone:             data.Phone,
+		Line1:             data.Line1,
+		Line2:             data.Line2,
+		City:              data.City,
+		Region:            data.Region,
+		PostalCode:        strings.ToUpper(data.PostalCode),
+		Country:           strings.ToUpper(data.Country),
+		IsDefaultShipping: data.IsDefaultShipping,
+		IsDefaultBilling:  data.IsDefaultBilling,
+	}
+	if err := address.Validate(); err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+
+	addresses, err := c.addressRepo.GetUserAddresses(ctx, user.Id)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if len(addresses) == 0 {
+		address.IsDefaultShipping, address.IsDefaultBilling = true, true
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		addressRepo := repo.NewAddressRepo(tx)
+		if err := unsetDefaultAddresses(ctx, addressRepo, user.Id, address.IsDefaultShipping, address.IsDefaultBilling); err != nil {
+			return err
+		}
+		_, err := addressRepo.CreateAddress(ctx, address)
+		return err
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(address, "success", "address created successfully", http.StatusCreated)
+}
+
+// GetAddresses gets the user's address book
+func (c *Controller) GetAddresses(ctx context.Context, user *models.User) *models.ResponseObject {
+	addresses, err := c.addressRepo.GetUserAddresses(ctx, user.Id)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(addresses, "success", "addresses fetched successfully", http.StatusOK)
+}
+
+// GetSingleAddress gets an address from the user's address book
+func (c *Controller) GetSingleAddress(ctx context.Context, addressId uuid.UUID, user *models.User) *models.ResponseObject {
+	address, err := c.addressRepo.GetAddressByFields(ctx, helpers.Map{"id": addressId, "user_id": user.Id})
+	if err == messages.ErrAddressNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(address, "success", "address fetched successfully", http.StatusOK)
+}
+
+// UpdateAddress updates an address in the user's address book, orders already placed keep
+// the address they were placed with
+func (c *Controller) UpdateAddress(ctx context.Context, data *models.UpdateAddressDto, addressId uuid.UUID, user *models.User) *models.ResponseObject {
+	address, err := c.addressRepo.GetAddressByFields(ctx, helpers.Map{"id": addressId, "user_id": user.Id})
+	if err == messages.ErrAddressNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	update := helpers.Map{}
+	if data.FullName != nil {
+		address.FullName = *data.FullName
+		update["full_name"] = address.FullName
+	}
+	if data.Phone != nil {
+		address.Phone = *data.Phone
+		update["phone"] = address.Phone
+	}
+	if data.Line1 != nil {
+		address.Line1 = *data.Line1
+		update["line1"] = address.Line1
+	}
+	if data.Line2 != nil {
+		address.Line2 = *data.Line2
+		update["line2"] = address.Line2
+	}
+	if data.City != nil {
+		address.City = *data.City
+		update["city"] = address.City
+	}
+	if data.Region != nil {
+		address.Region = *data.Region
+		update["region"] = address.Region
+	}
+	if data.PostalCode != nil {
+		address.PostalCode = strings.ToUpper(*data.PostalCode)
+		update["postal_code"] = address.PostalCode
+	}
+	if data.Country != nil {
+		address.Country = strings.ToUpper(*data.Country)
+		update["country"] = address.Country
+	}
+	if data.IsDefaultShipping != nil {
+		address.IsDefaultShipping = *data.IsDefaultShipping
+		update["is_default_shipping"] = address.IsDefaultShipping
+	}
+	if data.IsDefaultBilling != nil {
+		address.IsDefaultBilling = *data.IsDefaultBilling
+		update["is_default_billing"] = address.IsDefaultBilling
+	}
+	if err := address.Validate(); err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		addressRepo := repo.NewAddressRepo(tx)
+		shipping := data.IsDefaultShipping != nil && *data.IsDefaultShipping
+		billing := data.IsDefaultBilling != nil && *data.IsDefaultBilling
+		if err := unsetDefaultAddresses(ctx, addressRepo, user.Id, shipping, billing); err != nil {
+			return err
+		}
+		return addressRepo.UpdateAddressById(ctx, address.Id, update)
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(address, "success", "address updated successfully", http.StatusOK)
+}
+
+// DeleteAddress removes an address from the user's address book
+func (c *Controller) DeleteAddress(ctx context.Context, addressId uuid.UUID, user *models.User) *models.ResponseObject {
+	address, err := c.addressRepo.GetAddressByFields(ctx, helpers.Map{"id": addressId, "user_id": user.Id})
+	if err == messages.ErrAddressNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	if err := c.addressRepo.DeleteAddress(ctx, address.Id); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "address deleted successfully", http.StatusOK)
+}
+
+// unsetDefaultAddresses clears the user's defaults an address is about to take over
+func unsetDefaultAddresses(ctx context.Context, addressRepo repo.AddressRepo, userId uuid.UUID, shipping, billing bool) error {
+	if shipping {
+		if err := addressRepo.UnsetDefaultAddress(ctx, userId, "is_default_shipping"); err != nil {
+			return err
+		}
+	}
+	if billing {
+		return addressRepo.UnsetDefaultAddress(ctx, userId, "is_default_billing")
+	}
+	return nil
+}
+
+// orderAddress gets an address of the user by id, or their default address when there is no id
+func (c *Controller) orderAddress(ctx context.Context, addressId string, defaultField string, user *models.User) (*models.Address, error) {
+	if addressId != "" {
+		id, _ := uuid.Parse(addressId)
+		return c.addressRepo.GetAddressByFields(ctx, helpers.Map{"id": id, "user_id": user.Id})
+	}
+	address, err := c.addressRepo.GetAddressByFields(ctx, helpers.Map{"user_id": user.Id, defaultField: true})
+	if err == messages.ErrAddressNotFound {
+		return nil, nil
+	}
+	return address, err
+}
@@ -0,0 +1,131 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/common/notifier"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// SubscribeToProduct asks for the user to be notified when a sold out product can be ordered again
+func (c *Controller) SubscribeToProduct(ctx context.Context, productId uuid.UUID, user *models.User) *models.ResponseObject {
+	product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": productId})
+	if err == messages.ErrProductNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if product.Status != string(models.SOLD_OUT) && product.SellableQuantity > 0 {
+		return handleError(messages.ErrProductInStock, "bad-request", http.StatusBadRequest)
+	}
+
+	subscription, err := c.stockSubscriptionRepo.CreateStockSubscription(ctx, &models.StockSubscription{
+		Id:        uuid.New(),
+		ProductId: product.Id,
+		UserId:    user.Id,
+		Email:     user.Email,
+		Status:    string(models.SUBSCRIPTION_PENDING),
+	})
+	if err == messages.ErrAlreadySubscribedToProduct {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(subscription, "success", "you will be notified when the product is back in stock", http.StatusCreated)
+}
+
+// UnsubscribeFromProduct stops the user being notified when a product is back in stock
+func (c *Controller) UnsubscribeFromProduct(ctx context.Context, productId uuid.UUID, user *models.User) *models.ResponseObject {
+	subscription, err := c.stockSubscriptionRepo.GetStockSubscriptionByFields(ctx, helpers.Map{"product_id": productId, "user_id": user.Id, "status": string(models.SUBSCRIPTION_PENDING)})
+	if err == messages.ErrStockSubscriptionNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if err := c.stockSubscriptionRepo.DeleteStockSubscription(ctx, subscription.Id); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "stock subscription removed successfully", http.StatusOK)
+}
+
+// AlertLowStock notifies admins of the products and variants that fell to their reorder threshold since
+// the last run. Each is alerted on once until it is restocked above the threshold.
+func (c *Controller) AlertLowStock(ctx context.Context) error {
+	if err := c.stockAlertRepo.ResetLowStockAlerts(ctx); err != nil {
+		return err
+	}
+	items, err := c.stockAlertRepo.GetLowStockItems(ctx)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	admins, err := c.userRepo.GetUsersByFields(ctx, helpers.Map{"role": string(models.USER_ROLE_ADMIN)})
+	if err != nil {
+		return err
+	}
+
+	lines := []string{}
+	for _, item := range items {
+		name := item.Name
+		if item.Sku != "" {
+			name = fmt.Sprintf("%s (%s)", item.Name, item.Sku)
+		}
+		lines = append(lines, fmt.Sprintf("%s: %d left, reorder at %d", name, item.AvailableQuantity, item.ReorderThreshold))
+	}
+	subject := fmt.Sprintf("%d products are running low on stock", len(items))
+	if len(items) == 1 {
+		subject = fmt.Sprintf("%s is running low on stock", items[0].Name)
+	}
+	// the alert is kept for the next run unless an admin got it
+	var notifyErr error
+	sent := len(admins) == 0
+	for _, admin := range admins {
+		err := c.notifier.Notify(ctx, &notifier.Notification{To: admin.Email, Subject: subject, Body: strings.Join(lines, "\n")})
+		if err != nil {
+			log.Err(err).Msgf("AlertLowStock: could not notify %s through %s: %v", admin.Email, c.notifier.Name(), err)
+			notifyErr = err
+			continue
+		}
+		sent = true
+	}
+	if !sent {
+		return notifyErr
+	}
+	return c.stockAlertRepo.MarkLowStockAlerted(ctx, items)
+}
+
+// notifyBackInStock tells the customers waiting for a product that it can be ordered again. Customers
+// that could not be notified stay subscribed.
+func (c *Controller) notifyBackInStock(ctx context.Context, product *models.Product) {
+	subscriptions, err := c.stockSubscriptionRepo.GetStockSubscriptionsByFields(ctx, helpers.Map{"product_id": product.Id, "status": string(models.SUBSCRIPTION_PENDING)})
+	if err != nil {
+		log.Err(err).Msgf("notifyBackInStock: could not get subscriptions of product %s: %v", product.Id, err)
+		return
+	}
+	for _, subscription := range subscriptions {
+		notification := &notifier.Notification{
+			To:      subscription.Email,
+			Subject: fmt.Sprintf("%s is back in stock", product.Name),
+			Body:    fmt.Sprintf("%s you asked about is back in stock and can be ordered again.", product.Name),
+		}
+		if err := c.notifier.Notify(ctx, notification); err != nil {
+			log.Err(err).Msgf("notifyBackInStock: could not notify %s through %s: %v", subscription.Email, c.notifier.Name(), err)
+			continue
+		}
+		update := helpers.Map{"status": string(models.SUBSCRIPTION_NOTIFIED), "notified_at": time.Now().UTC()}
+		if err := c.stockSubscriptionRepo.UpdateStockSubscriptionById(ctx, subscription.Id, update); err != nil {
+			log.Err(err).Msgf("notifyBackInStock: could not update subscription %s: %v", subscription.Id, err)
+		}
+	}
+}
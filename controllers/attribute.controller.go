@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// attributeKeyPattern keeps attribute keys usable as json keys and in listing filters
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// CreateCategoryAttribute defines an attribute products of a category, and of its subcategories, can be given
+func (c *Controller) CreateCategoryAttribute(ctx context.Context, categoryId uuid.UUID, data *models.CreateAttributeDto) *models.ResponseObject {
+	category, err := c.categoryRepo.GetCategoryByFields(ctx, helpers.Map{"id": categoryId})
+	if err == messages.ErrCategoryNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if !attributeKeyPattern.MatchString(data.Key) {
+		return handleError(messages.ErrInvalidAttributeKey, "bad-request", http.StatusBadRequest)
+	}
+	if (data.Type == models.ATTRIBUTE_ENUM) != (len(data.Values) > 0) {
+		return handleError(messages.ErrAttributeValuesRequired, "bad-request", http.StatusBadRequest)
+	}
+
+	attribute, err := c.categoryAttributeRepo.CreateCategoryAttribute(ctx, &models.CategoryAttribute{
+		Id:         uuid.New(),
+		CategoryId: category.Id,
+		Key:        data.Key,
+		Name:       data.Name,
+		Type:       string(data.Type),
+		Values:     models.StringList(data.Values),
+		Required:   data.Required,
+		Position:   data.Position,
+	})
+	if err == messages.ErrAttributeWithKeyAlreadyExists {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(attribute, "success", "category attribute created successfully", http.StatusCreated)
+}
+
+// GetCategoryAttributes gets the attributes products of a category can have, those of the categories above it included
+func (c *Controller) GetCategoryAttributes(ctx context.Context, idOrSlug string) *models.ResponseObject {
+	category, err := c.getCategory(ctx, idOrSlug)
+	if err == messages.ErrCategoryNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	attributes, err := c.categoryAttributeRepo.GetCategoryAttributes(ctx, []uuid.UUID{category.Id})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(attributes, "success", "category attributes fetched successfully", http.StatusOK)
+}
+
+// UpdateCategoryAttribute updates an attribute of a category
+func (c *Controller) UpdateCategoryAttribute(ctx context.Context, categoryId uuid.UUID, attributeId uuid.UUID, data *models.UpdateAttributeDto) *models.ResponseObject {
+	attribute, err := c.categoryAttributeRepo.GetCategoryAttributeByFields(ctx, helpers.Map{"id": attributeId, "category_id": categoryId})
+	if err == messages.ErrCategoryAttributeNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	update := helpers.Map{}
+	if data.Name != nil {
+		update["name"] = *data.Name
+	}
+	if data.Values != nil {
+		if attribute.Type != string(models.ATTRIBUTE_ENUM) || len(*data.Values) == 0 {
+			return handleError(messages.ErrAttributeValuesRequired, "bad-request", http.StatusBadRequest)
+		}
+		update["values"] = models.StringList(*data.Values)
+	}
+	if data.Required != nil {
+		update["required"] = *data.Required
+	}
+	if data.Position != nil {
+		update["position"] = *data.Position
+	}
+
+	if err := c.categoryAttributeRepo.UpdateCategoryAttributeById(ctx, attribute.Id, update); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "category attribute updated successfully", http.StatusOK)
+}
+
+// DeleteCategoryAttribute deletes an attribute of a category and drops its values from the products of the category
+func (c *Controller) DeleteCategoryAttribute(ctx context.Context, categoryId uuid.UUID, attributeId uuid.UUID) *models.ResponseObject {
+	attribute, err := c.categoryAttributeRepo.GetCategoryAttributeByFields(ctx, helpers.Map{"id": attributeId, "category_id": categoryId})
+	if err == messages.ErrCategoryAttributeNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	categoryIds, err := c.categoryRepo.GetCategoryDescendantIds(ctx, categoryId)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		if err := repo.NewCategoryAttributeRepo(tx).DeleteCategoryAttribute(ctx, attribute.Id); err != nil {
+			return err
+		}
+		return repo.NewProductRepo(tx).RemoveProductAttribute(ctx, categoryIds, attribute.Key)
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "category attribute deleted successfully", http.StatusOK)
+}
+
+// toProductAttributes checks the attributes of a product against those of its categories, dropping empty ones
+func (c *Controller) toProductAttributes(ctx context.Context, categoryIds []uuid.UUID, data map[string]interface{}) (models.ProductAttributes, error) {
+	schema, err := c.categoryAttributeRepo.GetCategoryAttributes(ctx, categoryIds)
+	if err != nil {
+		return nil, err
+	}
+	attributes := models.ProductAttributes(data).Compact()
+	if err := attributes.Validate(schema); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+func handleAttributeError(err error) *models.ResponseObject {
+	switch err {
+	case messages.ErrUnknownAttribute,
+		messages.ErrInvalidAttributeValue,
+		messages.ErrAttributeRequired:
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	return handleError(err, "server-error", http.StatusInternalServerError)
+}
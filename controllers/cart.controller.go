@@ -0,0 +1,352 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+const CART_TOKEN_LENGTH = 32
+
+// GetCart gets the cart of the user or guest cart token
+func (c *Controller) GetCart(ctx context.Context, cartToken string, user *models.User) *models.ResponseObject {
+	cart, err := c.getActiveCart(ctx, cartToken, user)
+	if err == messages.ErrCartNotFound && user != nil {
+		cart, err = c.createCart(ctx, user)
+	}
+	if err == messages.ErrCartNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	if err := c.priceCart(ctx, cart); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(cart, "success", "cart fetched successfully", http.StatusOK)
+}
+
+// AddCartItem adds a product to the cart, creating the cart if none exists
+func (c *Controller) AddCartItem(ctx context.Context, data *models.AddCartItemDto, cartToken string, user *models.User) *models.ResponseObject {
+	productId, _ := uuid.Parse(data.ProductId)
+	var variantId *uuid.UUID
+	if data.VariantId != "" {
+		id, _ := uuid.Parse(data.VariantId)
+		variantId = &id
+	}
+
+	cart, err := c.getActiveCart(ctx, cartToken, user)
+	if err == messages.ErrCartNotFound {
+		cart, err = c.createCart(ctx, user)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	quantity := data.Quantity
+	var existingItem *models.CartItem
+	for _, item := range cart.CartItems {
+		if item.IsFor(productId, variantId) {
+			existingItem = item
+			quantity += item.Quantity
+		}
+	}
+
+	if _, err := c.validateCartProduct(ctx, productId, variantId, quantity); err != nil {
+		return handleCartError(err)
+	}
+
+	if existingItem != nil {
+		err = c.cartItemRepo.UpdateCartItemById(ctx, existingItem.Id, &models.CartItem{Quantity: quantity})
+	} else {
+		_, err = c.cartItemRepo.CreateCartItem(ctx, &models.CartItem{
+			Id:        uuid.New(),
+			CartId:    cart.Id,
+			ProductId: productId,
+			VariantId: variantId,
+			Quantity:  quantity,
+		})
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	return c.refreshCart(ctx, cart.Id, "item added to cart successfully")
+}
+
+// UpdateCartItem changes the quantity of a cart item
+func (c *Controller) UpdateCartItem(ctx context.Context, itemId uuid.UUID, data *models.UpdateCartItemDto, cartToken string, user *models.User) *models.ResponseObject {
+	cart, item, res := c.getCartItem(ctx, itemId, cartToken, user)
+	if res != nil {
+		return res
+	}
+
+	if _, err := c.validateCartProduct(ctx, item.ProductId, item.VariantId, data.Quantity); err != nil {
+		return handleCartError(err)
+	}
+
+	err := c.cartItemRepo.UpdateCartItemById(ctx, item.Id, &models.CartItem{Quantity: data.Quantity})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	return c.refreshCart(ctx, cart.Id, "cart item updated successfully")
+}
+
+// RemoveCartItem removes an item from the cart
+func (c *Controller) RemoveCartItem(ctx context.Context, itemId uuid.UUID, cartToken string, user *models.User) *models.ResponseObject {
+	cart, item, res := c.getCartItem(ctx, itemId, cartToken, user)
+	if res != nil {
+		return res
+	}
+
+	if err := c.cartItemRepo.DeleteCartItem(ctx, item); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	return c.refreshCart(ctx, cart.Id, "cart item removed successfully")
+}
+
+// CheckoutCart converts the user's cart into an order
+func (c *Controller) CheckoutCart(ctx context.Context, data *models.CheckoutCartDto, user *models.User) *models.ResponseObject {
+	cart, err := c.getActiveCart(ctx, "", user)
+	if err == messages.ErrCartNotFound {
+		return handleError(messages.ErrCartIsEmpty, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if len(cart.CartItems) == 0 {
+		return handleError(messages.ErrCartIsEmpty, "bad-request", http.StatusBadRequest)
+	}
+
+	orderData := &models.PlaceOrderDto{
+		Currency:          data.Currency,
+		CouponCode:        data.CouponCode,
+		ShippingAddressId: data.ShippingAddressId,
+		BillingAddressId:  data.BillingAddressId,
+		ShippingMethodId:  data.ShippingMethodId,
+	}
+	for _, item := range cart.CartItems {
+		if _, err := c.validateCartProduct(ctx, item.ProductId, item.VariantId, item.Quantity); err != nil {
+			return handleCartError(err)
+		}
+		orderLine := models.PlaceOrder{
+			ProductId: item.ProductId.String(),
+			Quantity:  item.Quantity,
+		}
+		if item.VariantId != nil {
+			orderLine.VariantId = item.VariantId.String()
+		}
+		orderData.Data = append(orderData.Data, orderLine)
+	}
+
+	return c.placeOrder(ctx, orderData, user, &cart.Id)
+}
+
+// mergeGuestCart moves the items of a guest cart into the user's active cart
+func (c *Controller) mergeGuestCart(ctx context.Context, cartToken string, user *models.User) error {
+	guestCart, err := c.getActiveCart(ctx, cartToken, nil)
+	if err == messages.ErrCartNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	userCart, err := c.getActiveCart(ctx, "", user)
+	if err == messages.ErrCartNotFound {
+		userCart = nil
+	} else if err != nil {
+		return err
+	}
+
+	return c.db.Transaction(ctx, func(tx *db.Database) error {
+		return mergeCarts(ctx, repo.NewCartRepo(tx), repo.NewCartItemRepo(tx), repo.NewProductRepo(tx), guestCart, userCart, user)
+	})
+}
+
+// mergeCarts moves the items of a guest cart into the user's cart, or gives the guest cart to the user when they have none.
+// Items in both carts are summed and capped by the stock left.
+func mergeCarts(ctx context.Context, cartRepo repo.CartRepo, cartItemRepo repo.CartItemRepo, productRepo repo.ProductRepo, guestCart *models.Cart, userCart *models.Cart, user *models.User) error {
+	if userCart == nil {
+		// the guest cart simply becomes the user's cart
+		return cartRepo.UpdateCartById(ctx, guestCart.Id, &models.Cart{UserId: &user.Id})
+	}
+
+	// a guest cart is only merged once, even when the user logs in twice at the same time
+	err := cartRepo.MoveCart(ctx, guestCart.Id, string(models.CART_ACTIVE), string(models.CART_MERGED))
+	if err == messages.ErrCartNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, guestItem := range guestCart.CartItems {
+		var existingItem *models.CartItem
+		for _, item := range userCart.CartItems {
+			if item.IsFor(guestItem.ProductId, guestItem.VariantId) {
+				existingItem = item
+			}
+		}
+
+		if existingItem == nil {
+			err = cartItemRepo.UpdateCartItemById(ctx, guestItem.Id, &models.CartItem{CartId: userCart.Id})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		quantity := existingItem.Quantity + guestItem.Quantity
+		product, err := productRepo.GetProductByFields(ctx, helpers.Map{"id": guestItem.ProductId})
+		if err != nil && err != messages.ErrProductNotFound {
+			return err
+		}
+		if product != nil {
+			available := product.SellableQuantity
+			if variant, err := productVariant(product, guestItem.VariantId); err == nil && variant != nil {
+				available = variant.SellableQuantity
+			}
+			quantity = min(quantity, max(available, 0))
+		}
+		// items that can no longer be bought are dropped
+		if quantity == 0 {
+			err = cartItemRepo.DeleteCartItem(ctx, existingItem)
+		} else {
+			err = cartItemRepo.UpdateCartItemById(ctx, existingItem.Id, &models.CartItem{Quantity: quantity})
+		}
+		if err != nil {
+			return err
+		}
+		if err := cartItemRepo.DeleteCartItem(ctx, guestItem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getActiveCart gets the active cart of a user, or of a guest when no user is given
+func (c *Controller) getActiveCart(ctx context.Context, cartToken string, user *models.User) (*models.Cart, error) {
+	if user != nil {
+		return c.cartRepo.GetCartByFields(ctx, helpers.Map{"user_id": user.Id, "status": string(models.CART_ACTIVE)})
+	}
+	if cartToken == "" {
+		return nil, messages.ErrCartNotFound
+	}
+	return c.cartRepo.GetCartByFields(ctx, helpers.Map{"token": cartToken, "user_id": nil, "status": string(models.CART_ACTIVE)})
+}
+
+func (c *Controller) createCart(ctx context.Context, user *models.User) (*models.Cart, error) {
+	cart := &models.Cart{
+		Id:     uuid.New(),
+		Token:  helpers.GenerateUniqueReferenceId(CART_TOKEN_LENGTH),
+		Status: string(models.CART_ACTIVE),
+	}
+	if user != nil {
+		cart.UserId = &user.Id
+	}
+	return c.cartRepo.CreateCart(ctx, cart)
+}
+
+// getCartItem gets a cart item making sure it belongs to the caller's cart
+func (c *Controller) getCartItem(ctx context.Context, itemId uuid.UUID, cartToken string, user *models.User) (*models.Cart, *models.CartItem, *models.ResponseObject) {
+	cart, err := c.getActiveCart(ctx, cartToken, user)
+	if err == messages.ErrCartNotFound {
+		return nil, nil, handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return nil, nil, handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	for _, item := range cart.CartItems {
+		if item.Id == itemId {
+			return cart, item, nil
+		}
+	}
+	return nil, nil, handleError(messages.ErrCartItemNotFound, "bad-request", http.StatusBadRequest)
+}
+
+// validateCartProduct checks that a product, or its variant, can be bought in the given quantity
+// and gets the product priced as the variant
+func (c *Controller) validateCartProduct(ctx context.Context, productId uuid.UUID, variantId *uuid.UUID, quantity int64) (*models.Product, error) {
+	product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": productId})
+	if err != nil {
+		return nil, err
+	}
+	if product.Status != string(models.IN_STOCK) || product.DeletedAt != nil {
+		return nil, messages.ErrProductNotAvailable
+	}
+	variant, err := productVariant(product, variantId)
+	if err != nil {
+		return nil, err
+	}
+	if variant == nil {
+		if quantity > product.SellableQuantity {
+			return nil, messages.ErrInsufficientStock
+		}
+		return product, nil
+	}
+
+	if variant.Status != string(models.IN_STOCK) {
+		return nil, messages.ErrProductNotAvailable
+	}
+	if quantity > variant.SellableQuantity {
+		return nil, messages.ErrInsufficientStock
+	}
+	return product.ForVariant(variant), nil
+}
+
+// priceCart loads the live price and availability of every item in the cart
+func (c *Controller) priceCart(ctx context.Context, cart *models.Cart) error {
+	for _, item := range cart.CartItems {
+		product, err := c.validateCartProduct(ctx, item.ProductId, item.VariantId, item.Quantity)
+		switch err {
+		case nil:
+			if item.Amount, err = product.Price.Sub(product.Discount); err != nil {
+				return err
+			}
+			item.IsAvailable = true
+		case messages.ErrProductNotFound, messages.ErrProductNotAvailable, messages.ErrInsufficientStock,
+			messages.ErrProductVariantNotFound, messages.ErrVariantRequired:
+			item.Note = err.Error()
+		default:
+			return err
+		}
+	}
+	totalAmount, err := cart.GetTotalAmount()
+	if err != nil {
+		return err
+	}
+	cart.TotalAmount = totalAmount
+	return nil
+}
+
+func (c *Controller) refreshCart(ctx context.Context, cartId uuid.UUID, message string) *models.ResponseObject {
+	cart, err := c.cartRepo.GetCartByFields(ctx, helpers.Map{"id": cartId})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if err := c.priceCart(ctx, cart); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(cart, "success", message, http.StatusOK)
+}
+
+func handleCartError(err error) *models.ResponseObject {
+	switch err {
+	case messages.ErrProductNotFound, messages.ErrProductNotAvailable, messages.ErrInsufficientStock,
+		messages.ErrProductVariantNotFound, messages.ErrVariantRequired:
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	return handleError(err, "server-error", http.StatusInternalServerError)
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"e-commerce/models"
+)
+
+func TestMergeCarts(t *testing.T) {
+	// a product with 5 left, one sold out and one with a variant with 3 left
+	stocked := &models.Product{Id: uuid.New(), SellableQuantity: 5}
+	soldOut := &models.Product{Id: uuid.New()}
+	variantId := uuid.New()
+	varied := &models.Product{Id: uuid.New(), SellableQuantity: 10, Variants: []*models.ProductVariant{{Id: variantId, SellableQuantity: 3}}}
+	otherVariantId := uuid.New()
+	varied.Variants = append(varied.Variants, &models.ProductVariant{Id: otherVariantId, SellableQuantity: 10})
+
+	type line struct {
+		product   *models.Product
+		variantId *uuid.UUID
+		quantity  int64
+	}
+	tests := []struct {
+		name      string
+		guest     []line
+		user      []line
+		noUser    bool
+		merged    bool
+		want      []string
+		wantOwner bool
+	}{
+		{name: "summed", guest: []line{{stocked, nil, 2}}, user: []line{{stocked, nil, 1}}, want: []string{"user:stocked:3"}},
+		{name: "summed up to the stock", guest: []line{{stocked, nil, 4}}, user: []line{{stocked, nil, 3}}, want: []string{"user:stocked:5"}},
+		{name: "summed up to the variant's stock", guest: []line{{varied, &variantId, 2}}, user: []line{{varied, &variantId, 2}}, want: []string{"user:varied:3"}},
+		{name: "other variants are kept apart", guest: []line{{varied, &variantId, 1}}, user: []line{{varied, &otherVariantId, 1}}, want: []string{"user:varied:1", "user:varied:1"}},
+		{name: "sold out items are dropped", guest: []line{{soldOut, nil, 1}}, user: []line{{soldOut, nil, 1}}},
+		{name: "items only in the guest cart are moved", guest: []line{{stocked, nil, 9}}, user: []line{{soldOut, nil, 1}}, want: []string{"user:soldOut:1", "user:stocked:9"}},
+		{name: "no user cart", guest: []line{{stocked, nil, 2}}, noUser: true, want: []string{"guest:stocked:2"}, wantOwner: true},
+		{name: "already merged", guest: []line{{stocked, nil, 2}}, user: []line{{stocked, nil, 1}}, merged: true, want: []string{"guest:stocked:2", "user:stocked:1"}},
+	}
+	names := map[uuid.UUID]string{stocked.Id: "stocked", soldOut.Id: "soldOut", varied.Id: "varied"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &models.User{Id: uuid.New()}
+			guestCart := &models.Cart{Id: uuid.New(), Status: string(models.CART_ACTIVE)}
+			if tt.merged {
+				guestCart.Status = string(models.CART_MERGED)
+			}
+			userCart := &models.Cart{Id: uuid.New(), UserId: &user.Id, Status: string(models.CART_ACTIVE)}
+			cartItemRepo := &fakeCartItemRepo{}
+			for _, l := range tt.guest {
+				item := &models.CartItem{Id: uuid.New(), CartId: guestCart.Id, ProductId: l.product.Id, VariantId: l.variantId, Quantity: l.quantity}
+				guestCart.CartItems = append(guestCart.CartItems, item)
+				cartItemRepo.items = append(cartItemRepo.items, &models.CartItem{Id: item.Id, CartId: item.CartId, ProductId: item.ProductId, Quantity: item.Quantity})
+			}
+			for _, l := range tt.user {
+				item := &models.CartItem{Id: uuid.New(), CartId: userCart.Id, ProductId: l.product.Id, VariantId: l.variantId, Quantity: l.quantity}
+				userCart.CartItems = append(userCart.CartItems, item)
+				cartItemRepo.items = append(cartItemRepo.items, &models.CartItem{Id: item.Id, CartId: item.CartId, ProductId: item.ProductId, Quantity: item.Quantity})
+			}
+			storedGuestCart := *guestCart
+			cartRepo := &fakeCartRepo{carts: []*models.Cart{&storedGuestCart}}
+			if tt.noUser {
+				userCart = nil
+			}
+
+			productRepo := &fakeProductRepo{products: []*models.Product{stocked, soldOut, varied}}
+			if err := mergeCarts(context.Background(), cartRepo, cartItemRepo, productRepo, guestCart, userCart, user); err != nil {
+				t.Fatalf("err = %v", err)
+			}
+
+			got := []string{}
+			for _, item := range cartItemRepo.items {
+				cart := "guest"
+				if userCart != nil && item.CartId == userCart.Id {
+					cart = "user"
+				}
+				got = append(got, fmt.Sprintf("%s:%s:%d", cart, names[item.ProductId], item.Quantity))
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+			if owned := storedGuestCart.UserId != nil; owned != tt.wantOwner {
+				t.Errorf("guest cart given to the user = %v, want %v", owned, tt.wantOwner)
+			}
+			if !tt.noUser && storedGuestCart.Status != string(models.CART_MERGED) {
+				t.Errorf("guest cart status = %s, want %s", storedGuestCart.Status, models.CART_MERGED)
+			}
+		})
+	}
+}
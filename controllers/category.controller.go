@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// CreateCategory creates a new category, at the top level or under a parent
+func (c *Controller) CreateCategory(ctx context.Context, data *models.CreateCategoryDto) *models.ResponseObject {
+	slug := helpers.ToSlug(data.Name)
+	_, err := c.categoryRepo.GetCategoryByFields(ctx, helpers.Map{"slug": slug})
+	if err == nil {
+		return handleError(messages.ErrCategoryWithNameAlreadyExists, "bad-request", http.StatusBadRequest)
+	}
+	if err != messages.ErrCategoryNotFound {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	category := &models.Category{
+		Id:          uuid.New(),
+		Name:        data.Name,
+		Slug:        slug,
+		Description: data.Description,
+		Position:    data.Position,
+	}
+	if data.ParentId != "" {
+		parent, err := c.categoryRepo.GetCategoryByFields(ctx, helpers.Map{"id": data.ParentId})
+		if err == messages.ErrCategoryNotFound {
+			return handleError(err, "bad-request", http.StatusBadRequest)
+		}
+		if err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+		category.ParentId = &parent.Id
+	}
+
+	newCategory, err := c.categoryRepo.CreateCategory(ctx, category)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(newCategory, "success", "category created successfully", http.StatusCreated)
+}
+
+// GetCategoryTree gets every category nested under its parent
+func (c *Controller) GetCategoryTree(ctx context.Context) *models.ResponseObject {
+	categories, err := c.categoryRepo.GetAllCategories(ctx)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(models.BuildCategoryTree(categories), "success", "categories fetched successfully", http.StatusOK)
+}
+
+// GetSingleCategory gets a category by id or slug
+func (c *Controller) GetSingleCategory(ctx context.Context, idOrSlug string) *models.ResponseObject {
+	category, err := c.getCategory(ctx, idOrSlug)
+	if err == messages.ErrCategoryNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(category, "success", "category fetched successfully", http.StatusOK)
+}
+
+// UpdateCategory updates the name, description or position of a category
+func (c *Controller) UpdateCategory(ctx context.Context, data *models.UpdateCategoryDto, categoryId uuid.UUID) *models.ResponseObject {
+	category, err := c.categoryRepo.GetCategoryByFields(ctx, helpers.Map{"id": categoryId})
+	if err == messages.ErrCategoryNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	update := helpers.Map{}
+	if data.Name != nil {
+		slug := helpers.ToSlug(*data.Name)
+		existing, err := c.categoryRepo.GetCategoryByFields(ctx, helpers.Map{"slug": slug})
+		if err != nil && err != messages.ErrCategoryNotFound {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+		if existing != nil && existing.Id != category.Id {
+			return handleError(messages.ErrCategoryWithNameAlreadyExists, "bad-request", http.StatusBadRequest)
+		}
+		update["name"] = *data.Name
+		update["slug"] = slug
+	}
+	if data.Description != nil {
+		update["description"] = *data.Description
+	}
+	if data.Position != nil {
+		update["position"] = *data.Position
+	}
+
+	if err := c.categoryRepo.UpdateCategoryById(ctx, categoryId, update); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "category updated successfully", http.StatusOK)
+}
+
+// MoveCategory moves a category and its subcategories under another category or to the top level.
+// A category cannot be moved under itself or anything below it.
+func (c *Controller) MoveCategory(ctx context.Context, data *models.MoveCategoryDto, categoryId uuid.UUID) *models.ResponseObject {
+	var parentId *uuid.UUID
+	if data.ParentId != "" {
+		id, _ := uuid.Parse(data.ParentId)
+		parentId = &id
+	}
+
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		categoryRepo := repo.NewCategoryRepo(tx)
+		// moves take turns on the whole tree, locking only the categories moved would let two moves
+		// further apart, say a under b's child and b under a's child, both pass the cycle check
+		if err := categoryRepo.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+		ids := []uuid.UUID{categoryId}
+		if parentId != nil {
+			ids = append(ids, *parentId)
+		}
+		locked, err := categoryRepo.LockCategories(ctx, ids)
+		if err != nil {
+			return err
+		}
+		if len(locked) != len(uniqueIds(ids)) {
+			return messages.ErrCategoryNotFound
+		}
+
+		update := helpers.Map{"parent_id": parentId}
+		if data.Position != nil {
+			update["position"] = *data.Position
+		}
+		if parentId == nil {
+			return categoryRepo.UpdateCategoryById(ctx, categoryId, update)
+		}
+
+		descendantIds, err := categoryRepo.GetCategoryDescendantIds(ctx, categoryId)
+		if err != nil {
+			return err
+		}
+		for _, id := range descendantIds {
+			if id == *parentId {
+				return messages.ErrCategoryCycle
+			}
+		}
+		return categoryRepo.UpdateCategoryById(ctx, categoryId, update)
+	})
+	if err == messages.ErrCategoryNotFound || err == messages.ErrCategoryCycle {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "category moved successfully", http.StatusOK)
+}
+
+// DeleteCategory deletes a category that has no subcategories and no products
+func (c *Controller) DeleteCategory(ctx context.Context, categoryId uuid.UUID) *models.ResponseObject {
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		categoryRepo := repo.NewCategoryRepo(tx)
+		locked, err := categoryRepo.LockCategories(ctx, []uuid.UUID{categoryId})
+		if err != nil {
+			return err
+		}
+		if len(locked) == 0 {
+			return messages.ErrCategoryNotFound
+		}
+
+		subcategories, err := categoryRepo.CountSubcategories(ctx, categoryId)
+		if err != nil {
+			return err
+		}
+		products, err := repo.NewProductCategoryRepo(tx).CountCategoryProducts(ctx, categoryId)
+		if err != nil {
+			return err
+		}
+		if subcategories > 0 || products > 0 {
+			return messages.ErrCategoryNotEmpty
+		}
+		return categoryRepo.DeleteCategory(ctx, categoryId)
+	})
+	if err == messages.ErrCategoryNotFound || err == messages.ErrCategoryNotEmpty {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "category deleted successfully", http.StatusOK)
+}
+
+// getCategory gets a category by its id, or by its slug when idOrSlug is not an id
+func (c *Controller) getCategory(ctx context.Context, idOrSlug string) (*models.Category, error) {
+	if id, err := uuid.Parse(idOrSlug); err == nil {
+		return c.categoryRepo.GetCategoryByFields(ctx, helpers.Map{"id": id})
+	}
+	return c.categoryRepo.GetCategoryByFields(ctx, helpers.Map{"slug": idOrSlug})
+}
+
+// productFilter narrows products down to a category, by id or slug, and its subcategories
+func (c *Controller) productFilter(ctx context.Context, category string) (*models.ProductFilter, error) {
+	filter := &models.ProductFilter{}
+	if category == "" {
+		return filter, nil
+	}
+	found, err := c.getCategory(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	if filter.CategoryIds, err = c.categoryRepo.GetCategoryDescendantIds(ctx, found.Id); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// toCategoryIds parses category ids and checks that they exist, dropping repeats
+func (c *Controller) toCategoryIds(ctx context.Context, data []string) ([]uuid.UUID, error) {
+	ids := []uuid.UUID{}
+	for _, value := range data {
+		id, _ := uuid.Parse(value)
+		ids = append(ids, id)
+	}
+	ids = uniqueIds(ids)
+	if _, err := c.categoryRepo.GetCategoriesByIds(ctx, ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// uniqueIds drops repeated ids, keeping the first of each
+func uniqueIds(ids []uuid.UUID) []uuid.UUID {
+	unique := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -2,10 +2,16 @@ package controllers
 
 import (
 	"context"
+	"io"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 
+	"e-commerce/common/carriers"
 	"e-commerce/common/middleware"
+	"e-commerce/common/notifier"
+	"e-commerce/common/payments"
+	"e-commerce/common/storage"
 	"e-commerce/config"
 	"e-commerce/db"
 	"e-commerce/models"
@@ -16,11 +22,49 @@ import (
 type Controller struct {
 	middleware *middleware.Middleware
 	Config     *config.ConfigType
+	db         *db.Database
+
+	paymentProvider payments.Provider
+	refundProvider  payments.RefundProvider
+	carrier         carriers.Carrier
+	storage         storage.Storage
+	notifier        notifier.Notifier
+
+	allocationStrategy models.AllocationStrategy
 
 	userRepo        repo.UserRepo
 	productRepo     repo.ProductRepo
 	orderRepo       repo.OrderRepo
 	orderRecordRepo repo.OrderRecordRepo
+	cartRepo        repo.CartRepo
+	cartItemRepo    repo.CartItemRepo
+	paymentRepo     repo.PaymentRepo
+	refundRepo      repo.RefundRepo
+
+	couponRepo            repo.CouponRepo
+	couponRedemptionRepo  repo.CouponRedemptionRepo
+	promotionRepo         repo.PromotionRepo
+	exchangeRateRepo      repo.ExchangeRateRepo
+	productPriceRepo      repo.ProductPriceRepo
+	taxRateRepo           repo.TaxRateRepo
+	addressRepo           repo.AddressRepo
+	orderTaxRepo          repo.OrderTaxRepo
+	shippingZoneRepo      repo.ShippingZoneRepo
+	shippingMethodRepo    repo.ShippingMethodRepo
+	shipmentRepo          repo.ShipmentRepo
+	returnRequestRepo     repo.ReturnRequestRepo
+	categoryRepo          repo.CategoryRepo
+	productVariantRepo    repo.ProductVariantRepo
+	productMediaRepo      repo.ProductMediaRepo
+	categoryAttributeRepo repo.CategoryAttributeRepo
+	importJobRepo         repo.ImportJobRepo
+	inventoryMovementRepo repo.InventoryMovementRepo
+	warehouseRepo         repo.WarehouseRepo
+	warehouseStockRepo    repo.WarehouseStockRepo
+	orderAllocationRepo   repo.OrderAllocationRepo
+	stockReservationRepo  repo.StockReservationRepo
+	stockAlertRepo        repo.StockAlertRepo
+	stockSubscriptionRepo repo.StockSubscriptionRepo
 }
 
 // Operations registers all controllers method
@@ -31,30 +75,211 @@ type Operations interface {
 	RegisterUser(ctx context.Context, data *models.SignUpDto) *models.ResponseObject
 	Login(ctx context.Context, data *models.SignInDto) *models.ResponseObject
 
+	// address
+	CreateAddress(ctx context.Context, data *models.CreateAddressDto, user *models.User) *models.ResponseObject
+	GetAddresses(ctx context.Context, user *models.User) *models.ResponseObject
+	GetSingleAddress(ctx context.Context, addressId uuid.UUID, user *models.User) *models.ResponseObject
+	UpdateAddress(ctx context.Context, data *models.UpdateAddressDto, addressId uuid.UUID, user *models.User) *models.ResponseObject
+	DeleteAddress(ctx context.Context, addressId uuid.UUID, user *models.User) *models.ResponseObject
+
 	// order
 	PlaceOrder(ctx context.Context, data *models.PlaceOrderDto, user *models.User) *models.ResponseObject
+	PriceOrder(ctx context.Context, data *models.PlaceOrderDto, user *models.User) *models.ResponseObject
 	GetAllOrders(ctx context.Context, user *models.User, query *models.APIPagingDto) *models.ResponseObject
-	GetSingleOrder(ctx context.Context, orderId uuid.UUID) *models.ResponseObject
+	GetSingleOrder(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject
 	CancelOrder(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject
 	UpdateOrderStatus(ctx context.Context, orderId uuid.UUID, data *models.UpdateOrderStatusDto, user *models.User) *models.ResponseObject
+	TrackOrder(ctx context.Context, trackingCode string, data *models.TrackOrderDto) *models.ResponseObject
+	ReleaseExpiredReservations(ctx context.Context) error
 
 	// product
 	CreateProduct(ctx context.Context, data *models.CreateProductDto, user *models.User) *models.ResponseObject
 	GetSingleProduct(ctx context.Context, productId uuid.UUID) *models.ResponseObject
-	UpdateProduct(ctx context.Context, data *models.UpdateProductDto, productId uuid.UUID) *models.ResponseObject
-	GetAllProducts(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject
+	UpdateProduct(ctx context.Context, data *models.UpdateProductDto, productId uuid.UUID, user *models.User) *models.ResponseObject
+	GetAllProducts(ctx context.Context, query *models.APIPagingDto, data *models.ProductQueryDto) *models.ResponseObject
 	DeleteProduct(ctx context.Context, productId uuid.UUID) *models.ResponseObject
+	CreateProductVariant(ctx context.Context, productId uuid.UUID, data *models.CreateVariantDto, user *models.User) *models.ResponseObject
+	UpdateProductVariant(ctx context.Context, productId uuid.UUID, variantId uuid.UUID, data *models.UpdateVariantDto, user *models.User) *models.ResponseObject
+	DeleteProductVariant(ctx context.Context, productId uuid.UUID, variantId uuid.UUID) *models.ResponseObject
+	SearchProducts(ctx context.Context, query *models.APIPagingDto, data *models.ProductSearchDto) *models.ResponseObject
+	AutocompleteProducts(ctx context.Context, data *models.ProductAutocompleteDto) *models.ResponseObject
+	UploadProductMedia(ctx context.Context, productId uuid.UUID, file io.Reader) *models.ResponseObject
+	UpdateProductMedia(ctx context.Context, productId uuid.UUID, mediaId uuid.UUID, data *models.UpdateMediaDto) *models.ResponseObject
+	DeleteProductMedia(ctx context.Context, productId uuid.UUID, mediaId uuid.UUID) *models.ResponseObject
+	StartProductImport(ctx context.Context, file io.Reader, data *models.ProductImportDto, user *models.User) *models.ResponseObject
+	RunProductImport(ctx context.Context, file io.Reader, data *models.ProductImportDto, user *models.User) (*models.ImportJob, error)
+	GetProductImport(ctx context.Context, jobId uuid.UUID) *models.ResponseObject
+	ExportProducts(ctx context.Context, format models.ImportFormat, w io.Writer) error
+	GetProductInventory(ctx context.Context, productId uuid.UUID, query *models.APIPagingDto) *models.ResponseObject
+	AdjustProductInventory(ctx context.Context, productId uuid.UUID, data *models.AdjustInventoryDto, user *models.User) *models.ResponseObject
+	SubscribeToProduct(ctx context.Context, productId uuid.UUID, user *models.User) *models.ResponseObject
+	UnsubscribeFromProduct(ctx context.Context, productId uuid.UUID, user *models.User) *models.ResponseObject
+	AlertLowStock(ctx context.Context) error
+
+	// warehouse
+	CreateWarehouse(ctx context.Context, data *models.CreateWarehouseDto) *models.ResponseObject
+	GetAllWarehouses(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject
+	GetSingleWarehouse(ctx context.Context, warehouseId uuid.UUID) *models.ResponseObject
+	UpdateWarehouse(ctx context.Context, data *models.UpdateWarehouseDto, warehouseId uuid.UUID) *models.ResponseObject
+
+	// category
+	CreateCategory(ctx context.Context, data *models.CreateCategoryDto) *models.ResponseObject
+	GetCategoryTree(ctx context.Context) *models.ResponseObject
+	GetSingleCategory(ctx context.Context, idOrSlug string) *models.ResponseObject
+	UpdateCategory(ctx context.Context, data *models.UpdateCategoryDto, categoryId uuid.UUID) *models.ResponseObject
+	MoveCategory(ctx context.Context, data *models.MoveCategoryDto, categoryId uuid.UUID) *models.ResponseObject
+	DeleteCategory(ctx context.Context, categoryId uuid.UUID) *models.ResponseObject
+	CreateCategoryAttribute(ctx context.Context, categoryId uuid.UUID, data *models.CreateAttributeDto) *models.ResponseObject
+	GetCategoryAttributes(ctx context.Context, idOrSlug string) *models.ResponseObject
+	UpdateCategoryAttribute(ctx context.Context, categoryId uuid.UUID, attributeId uuid.UUID, data *models.UpdateAttributeDto) *models.ResponseObject
+	DeleteCategoryAttribute(ctx context.Context, categoryId uuid.UUID, attributeId uuid.UUID) *models.ResponseObject
+
+	// cart
+	GetCart(ctx context.Context, cartToken string, user *models.User) *models.ResponseObject
+	AddCartItem(ctx context.Context, data *models.AddCartItemDto, cartToken string, user *models.User) *models.ResponseObject
+	UpdateCartItem(ctx context.Context, itemId uuid.UUID, data *models.UpdateCartItemDto, cartToken string, user *models.User) *models.ResponseObject
+	RemoveCartItem(ctx context.Context, itemId uuid.UUID, cartToken string, user *models.User) *models.ResponseObject
+	CheckoutCart(ctx context.Context, data *models.CheckoutCartDto, user *models.User) *models.ResponseObject
+
+	// payment
+	InitializePayment(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject
+	VerifyPayment(ctx context.Context, reference string, user *models.User) *models.ResponseObject
+	CompleteFakePayment(ctx context.Context, providerReference string, status payments.ChargeStatus) *models.ResponseObject
+	HandlePaymentWebhook(ctx context.Context, provider string, payload []byte, signature string) *models.ResponseObject
+
+	// return
+	CreateReturn(ctx context.Context, orderId uuid.UUID, data *models.CreateReturnDto, user *models.User) *models.ResponseObject
+	GetOrderReturns(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject
+	GetAllReturns(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject
+	GetSingleReturn(ctx context.Context, returnId uuid.UUID, user *models.User) *models.ResponseObject
+	ApproveReturn(ctx context.Context, returnId uuid.UUID, data *models.ReviewReturnDto, user *models.User) *models.ResponseObject
+	RejectReturn(ctx context.Context, returnId uuid.UUID, data *models.ReviewReturnDto, user *models.User) *models.ResponseObject
+	ReceiveReturn(ctx context.Context, returnId uuid.UUID, data *models.ReceiveReturnDto, user *models.User) *models.ResponseObject
+
+	// shipment
+	CreateShipment(ctx context.Context, orderId uuid.UUID, data *models.CreateShipmentDto, user *models.User) *models.ResponseObject
+	GetOrderShipments(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject
+	RefreshShipmentTracking(ctx context.Context, shipmentId uuid.UUID) *models.ResponseObject
+	ScanFakeShipment(ctx context.Context, trackingNumber string, status carriers.TrackingStatus, location string) *models.ResponseObject
+	PollShipmentTracking(ctx context.Context) error
+
+	// refund
+	CreateRefund(ctx context.Context, orderId uuid.UUID, data *models.CreateRefundDto, user *models.User) *models.ResponseObject
+	GetOrderRefunds(ctx context.Context, orderId uuid.UUID) *models.ResponseObject
+
+	// coupon
+	CreateCoupon(ctx context.Context, data *models.CreateCouponDto) *models.ResponseObject
+	GetAllCoupons(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject
+	GetSingleCoupon(ctx context.Context, couponId uuid.UUID) *models.ResponseObject
+	UpdateCoupon(ctx context.Context, data *models.UpdateCouponDto, couponId uuid.UUID) *models.ResponseObject
+
+	// promotion
+	CreatePromotion(ctx context.Context, data *models.CreatePromotionDto) *models.ResponseObject
+	GetAllPromotions(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject
+	GetSinglePromotion(ctx context.Context, promotionId uuid.UUID) *models.ResponseObject
+	UpdatePromotion(ctx context.Context, data *models.UpdatePromotionDto, promotionId uuid.UUID) *models.ResponseObject
+	DeletePromotion(ctx context.Context, promotionId uuid.UUID) *models.ResponseObject
+
+	// exchange rate
+	SetExchangeRate(ctx context.Context, data *models.SetExchangeRateDto) *models.ResponseObject
+	GetAllExchangeRates(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject
+	ImportExchangeRates(ctx context.Context, file io.Reader) *models.ResponseObject
+
+	// tax
+	CreateTaxRate(ctx context.Context, data *models.CreateTaxRateDto) *models.ResponseObject
+	GetAllTaxRates(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject
+	GetSingleTaxRate(ctx context.Context, taxRateId uuid.UUID) *models.ResponseObject
+	UpdateTaxRate(ctx context.Context, data *models.UpdateTaxRateDto, taxRateId uuid.UUID) *models.ResponseObject
+	SetTaxExemption(ctx context.Context, userId uuid.UUID, data *models.SetTaxExemptionDto) *models.ResponseObject
+	GetTaxReport(ctx context.Context, query *models.TaxReportDto) *models.ResponseObject
+
+	// shipping
+	CreateShippingZone(ctx context.Context, data *models.CreateShippingZoneDto) *models.ResponseObject
+	GetAllShippingZones(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject
+	GetSingleShippingZone(ctx context.Context, zoneId uuid.UUID) *models.ResponseObject
+	UpdateShippingZone(ctx context.Context, data *models.UpdateShippingZoneDto, zoneId uuid.UUID) *models.ResponseObject
+	DeleteShippingZone(ctx context.Context, zoneId uuid.UUID) *models.ResponseObject
+	CreateShippingMethod(ctx context.Context, data *models.CreateShippingMethodDto, zoneId uuid.UUID) *models.ResponseObject
+	UpdateShippingMethod(ctx context.Context, data *models.UpdateShippingMethodDto, methodId uuid.UUID) *models.ResponseObject
+	DeleteShippingMethod(ctx context.Context, methodId uuid.UUID) *models.ResponseObject
+	QuoteShipping(ctx context.Context, data *models.ShippingQuoteDto) *models.ResponseObject
 }
 
 // NewController loads all controllers resources
-func NewController(middleware *middleware.Middleware, db *db.Database) *Operations {
+func NewController(middleware *middleware.Middleware, config *config.ConfigType, db *db.Database) *Operations {
+	paymentProvider, err := payments.NewProvider(config)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("payment provider error: %s", err.Error())
+	}
+	if paymentProvider.Name() == payments.PROVIDER_NONE {
+		log.Warn().Msg("no payment provider is configured, orders cannot be paid for")
+	}
+	carrier, err := carriers.NewCarrier(config)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("carrier error: %s", err.Error())
+	}
+	if carrier.Name() == carriers.CARRIER_NONE {
+		log.Warn().Msg("no carrier is configured, orders cannot be shipped")
+	}
+	mediaStorage, err := storage.NewStorage(config)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("storage error: %s", err.Error())
+	}
+	stockNotifier, err := notifier.NewNotifier(config)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("notifier error: %s", err.Error())
+	}
+	allocationStrategy := models.AllocationStrategy(config.AllocationStrategy)
+	if !allocationStrategy.IsValid() {
+		log.Fatal().Msgf("allocation strategy error: unknown allocation strategy %q", config.AllocationStrategy)
+	}
+
 	c := &Controller{
 		middleware: middleware,
+		Config:     config,
+		db:         db,
+
+		paymentProvider: paymentProvider,
+		refundProvider:  paymentProvider,
+		carrier:         carrier,
+		storage:         mediaStorage,
+		notifier:        stockNotifier,
+
+		allocationStrategy: allocationStrategy,
 
 		userRepo:        repo.NewUserRepo(db),
 		productRepo:     repo.NewProductRepo(db),
 		orderRepo:       repo.NewOrderRepo(db),
 		orderRecordRepo: repo.NewOrderRecordRepo(db),
+		cartRepo:        repo.NewCartRepo(db),
+		cartItemRepo:    repo.NewCartItemRepo(db),
+		paymentRepo:     repo.NewPaymentRepo(db),
+		refundRepo:      repo.NewRefundRepo(db),
+
+		couponRepo:            repo.NewCouponRepo(db),
+		couponRedemptionRepo:  repo.NewCouponRedemptionRepo(db),
+		promotionRepo:         repo.NewPromotionRepo(db),
+		exchangeRateRepo:      repo.NewExchangeRateRepo(db),
+		productPriceRepo:      repo.NewProductPriceRepo(db),
+		taxRateRepo:           repo.NewTaxRateRepo(db),
+		addressRepo:           repo.NewAddressRepo(db),
+		orderTaxRepo:          repo.NewOrderTaxRepo(db),
+		shippingZoneRepo:      repo.NewShippingZoneRepo(db),
+		shippingMethodRepo:    repo.NewShippingMethodRepo(db),
+		shipmentRepo:          repo.NewShipmentRepo(db),
+		returnRequestRepo:     repo.NewReturnRequestRepo(db),
+		categoryRepo:          repo.NewCategoryRepo(db),
+		productVariantRepo:    repo.NewProductVariantRepo(db),
+		productMediaRepo:      repo.NewProductMediaRepo(db),
+		categoryAttributeRepo: repo.NewCategoryAttributeRepo(db),
+		importJobRepo:         repo.NewImportJobRepo(db),
+		inventoryMovementRepo: repo.NewInventoryMovementRepo(db),
+		warehouseRepo:         repo.NewWarehouseRepo(db),
+		warehouseStockRepo:    repo.NewWarehouseStockRepo(db),
+		orderAllocationRepo:   repo.NewOrderAllocationRepo(db),
+		stockReservationRepo:  repo.NewStockReservationRepo(db),
+		stockAlertRepo:        repo.NewStockAlertRepo(db),
+		stockSubscriptionRepo: repo.NewStockSubscriptionRepo(db),
 	}
 	op := Operations(c)
 
@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// CreateCoupon creates a new coupon
+func (c *Controller) CreateCoupon(ctx context.Context, data *models.CreateCouponDto) *models.ResponseObject {
+	if data.Type == models.COUPON_PERCENTAGE && data.Value > 100 {
+		return handleError(messages.ErrInvalidCouponValue, "bad-request", http.StatusBadRequest)
+	}
+	// amounts are in the coupon currency
+	if data.Currency == nil && (data.Type == models.COUPON_FIXED || data.MaxDiscount > 0 || data.MinOrderAmount > 0) {
+		return handleError(messages.ErrCouponCurrencyRequired, "bad-request", http.StatusBadRequest)
+	}
+
+	coupon := &models.Coupon{
+		Id:             uuid.New(),
+		Code:           strings.ToUpper(data.Code),
+		Description:    data.Description,
+		Type:           string(data.Type),
+		Value:          data.Value,
+		MaxDiscount:    data.MaxDiscount,
+		MinOrderAmount: data.MinOrderAmount,
+		ProductIds:     toUUIDList(data.ProductIds),
+		UsageLimit:     data.UsageLimit,
+		PerUserLimit:   data.PerUserLimit,
+		StartsAt:       data.StartsAt,
+		EndsAt:         data.EndsAt,
+		Stackable:      data.Stackable,
+		Status:         string(models.COUPON_ACTIVE),
+	}
+	if data.Currency != nil {
+		coupon.Currency = string(*data.Currency)
+	}
+
+	newCoupon, err := c.couponRepo.CreateCoupon(ctx, coupon)
+	if err == messages.ErrCouponWithCodeAlreadyExists {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(newCoupon, "success", "coupon created successfully", http.StatusCreated)
+}
+
+// GetAllCoupons gets all coupons
+func (c *Controller) GetAllCoupons(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject {
+	result, err := c.couponRepo.GetAllCoupons(ctx, query)
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(result, "success", "coupons fetched successfully", http.StatusOK)
+}
+
+// GetSingleCoupon gets a coupon by id
+func (c *Controller) GetSingleCoupon(ctx context.Context, couponId uuid.UUID) *models.ResponseObject {
+	coupon, err := c.couponRepo.GetCouponByFields(ctx, helpers.Map{"id": couponId})
+	if err == messages.ErrCouponNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(coupon, "success", "coupon fetched successfully", http.StatusOK)
+}
+
+// UpdateCoupon updates a coupon
+func (c *Controller) UpdateCoupon(ctx context.Context, data *models.UpdateCouponDto, couponId uuid.UUID) *models.ResponseObject {
+	coupon, err := c.couponRepo.GetCouponByFields(ctx, helpers.Map{"id": couponId})
+	if err == messages.ErrCouponNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	update := helpers.Map{}
+	if data.Description != nil {
+		update["description"] = *data.Description
+	}
+	if data.Value != nil {
+		if coupon.Type == string(models.COUPON_PERCENTAGE) && *data.Value > 100 {
+			return handleError(messages.ErrInvalidCouponValue, "bad-request", http.StatusBadRequest)
+		}
+		update["value"] = *data.Value
+	}
+	if coupon.Currency == "" && ((data.MaxDiscount != nil && *data.MaxDiscount > 0) || (data.MinOrderAmount != nil && *data.MinOrderAmount > 0)) {
+		return handleError(messages.ErrCouponCurrencyRequired, "bad-request", http.StatusBadRequest)
+	}
+	if data.MaxDiscount != nil {
+		update["max_discount"] = *data.MaxDiscount
+	}
+	if data.MinOrderAmount != nil {
+		update["min_order_amount"] = *data.MinOrderAmount
+	}
+	if data.ProductIds != nil {
+		update["product_ids"] = toUUIDList(*data.ProductIds)
+	}
+	if data.UsageLimit != nil {
+		update["usage_limit"] = *data.UsageLimit
+	}
+	if data.PerUserLimit != nil {
+		update["per_user_limit"] = *data.PerUserLimit
+	}
+	if data.StartsAt != nil {
+		update["starts_at"] = *data.StartsAt
+	}
+	if data.EndsAt != nil {
+		update["ends_at"] = *data.EndsAt
+	}
+	if data.Stackable != nil {
+		update["stackable"] = *data.Stackable
+	}
+	if data.Status != nil {
+		update["status"] = string(*data.Status)
+	}
+
+	if err := c.couponRepo.UpdateCouponById(ctx, couponId, update); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "coupon updated successfully", http.StatusOK)
+}
+
+// applyCoupon validates a coupon code against a priced order and returns the discount it gives
+func (c *Controller) applyCoupon(ctx context.Context, code string, user *models.User, quote *orderQuote) (*models.Coupon, models.Money, error) {
+	coupon, err := c.couponRepo.GetCouponByFields(ctx, helpers.Map{"code": strings.ToUpper(code)})
+	if err != nil {
+		return nil, models.Money{}, err
+	}
+	if err := checkCouponUsage(ctx, c.couponRedemptionRepo, coupon, user.Id); err != nil {
+		return nil, models.Money{}, err
+	}
+
+	order := quote.order
+	if coupon.Type == string(models.COUPON_FIXED) && coupon.Currency != order.Currency {
+		return nil, models.Money{}, messages.ErrCouponNotApplicable
+	}
+	orderAmount, err := order.GetTotalAmount()
+	if err != nil {
+		return nil, models.Money{}, err
+	}
+	if coupon.MinOrderAmount > 0 {
+		minOrderAmount, err := c.couponAmount(ctx, coupon, order, coupon.MinOrderAmount)
+		if err != nil {
+			return nil, models.Money{}, err
+		}
+		if orderAmount.Amount < minOrderAmount.Amount {
+			return nil, models.Money{}, messages.ErrCouponMinOrderAmount
+		}
+	}
+
+	eligibleAmount := models.NewMoney(0, models.Currency(order.Currency))
+	for _, orderRecord := range order.OrderRecords {
+		if !coupon.AppliesTo(orderRecord.ProductId) {
+			continue
+		}
+		if !coupon.Stackable && quote.isDiscounted(orderRecord) {
+			continue
+		}
+		remaining, err := quote.recordRemaining(orderRecord)
+		if err != nil {
+			return nil, models.Money{}, err
+		}
+		if eligibleAmount, err = eligibleAmount.Add(remaining); err != nil {
+			return nil, models.Money{}, err
+		}
+	}
+	if !eligibleAmount.IsPositive() {
+		return nil, models.Money{}, messages.ErrCouponNotApplicable
+	}
+
+	maxDiscount, err := c.couponAmount(ctx, coupon, order, coupon.MaxDiscount)
+	if err != nil {
+		return nil, models.Money{}, err
+	}
+	discount, err := coupon.GetDiscount(eligibleAmount, maxDiscount)
+	if err != nil {
+		return nil, models.Money{}, err
+	}
+	if discount, err = discount.Min(orderAmount); err != nil {
+		return nil, models.Money{}, err
+	}
+	return coupon, discount, nil
+}
+
+// couponAmount gets an amount of a coupon in the order currency. Coupons made before amounts needed a
+// currency have them in the currency of any order.
+func (c *Controller) couponAmount(ctx context.Context, coupon *models.Coupon, order *models.Order, amount int64) (models.Money, error) {
+	if coupon.Currency == "" || amount == 0 {
+		return models.NewMoney(amount, models.Currency(order.Currency)), nil
+	}
+	return c.convert(ctx, order, models.NewMoney(amount, models.Currency(coupon.Currency)))
+}
+
+// redeemCoupon records the use of a coupon on an order with the repos of a transaction. The coupon row
+// stays locked until the transaction ends so concurrent orders cannot go past its limits.
+func redeemCoupon(ctx context.Context, couponRepo repo.CouponRepo, couponRedemptionRepo repo.CouponRedemptionRepo, coupon *models.Coupon, order *models.Order) error {
+	lockedCoupon, err := couponRepo.GetCouponForUpdate(ctx, helpers.Map{"id": coupon.Id})
+	if err != nil {
+		return err
+	}
+	if err := checkCouponUsage(ctx, couponRedemptionRepo, lockedCoupon, order.UserId); err != nil {
+		return err
+	}
+
+	var amount int64
+	for _, discount := range order.Discounts {
+		if discount.CouponId != nil && *discount.CouponId == coupon.Id {
+			amount += discount.Amount.Amount
+		}
+	}
+	_, err = couponRedemptionRepo.CreateCouponRedemption(ctx, &models.CouponRedemption{
+		Id:       uuid.New(),
+		CouponId: coupon.Id,
+		UserId:   order.UserId,
+		OrderId:  order.Id,
+		Amount:   amount,
+	})
+	if err != nil {
+		return err
+	}
+	return couponRepo.AddUsedCount(ctx, coupon.Id, 1)
+}
+
+// releaseCoupon gives back the coupon uses of a cancelled order
+func (c *Controller) releaseCoupon(ctx context.Context, tx *db.Database, orderId uuid.UUID) error {
+	couponRepo := repo.NewCouponRepo(tx)
+	couponRedemptionRepo := repo.NewCouponRedemptionRepo(tx)
+
+	redemptions, err := couponRedemptionRepo.GetCouponRedemptionsByFields(ctx, helpers.Map{"order_id": orderId})
+	if err != nil {
+		return err
+	}
+	for _, redemption := range redemptions {
+		if err := couponRedemptionRepo.DeleteCouponRedemption(ctx, redemption); err != nil {
+			return err
+		}
+		if err := couponRepo.AddUsedCount(ctx, redemption.CouponId, -1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCouponUsage checks that a coupon is live and has uses left for a user
+func checkCouponUsage(ctx context.Context, couponRedemptionRepo repo.CouponRedemptionRepo, coupon *models.Coupon, userId uuid.UUID) error {
+	if !coupon.IsActiveAt(time.Now().UTC()) {
+		return messages.ErrCouponNotActive
+	}
+	if coupon.UsageLimit > 0 && coupon.UsedCount >= coupon.UsageLimit {
+		return messages.ErrCouponUsageLimitReached
+	}
+	if coupon.PerUserLimit > 0 {
+		count, err := couponRedemptionRepo.CountCouponRedemptions(ctx, helpers.Map{"coupon_id": coupon.Id, "user_id": userId})
+		if err != nil {
+			return err
+		}
+		if count >= coupon.PerUserLimit {
+			return messages.ErrCouponUserLimitReached
+		}
+	}
+	return nil
+}
+
+func toUUIDList(ids []string) models.UUIDList {
+	list := models.UUIDList{}
+	for _, id := range ids {
+		parsed, _ := uuid.Parse(id)
+		list = append(list, parsed)
+	}
+	return list
+}
@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/models"
+)
+
+func TestCheckCouponUsage(t *testing.T) {
+	userId, otherUserId := uuid.New(), uuid.New()
+	now := time.Now().UTC()
+	yesterday, tomorrow := now.AddDate(0, 0, -1), now.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name   string
+		coupon models.Coupon
+		// coupons the user already redeemed
+		redeemed int
+		err      error
+	}{
+		{name: "active", coupon: models.Coupon{Status: string(models.COUPON_ACTIVE)}},
+		{name: "inactive", coupon: models.Coupon{Status: string(models.COUPON_INACTIVE)}, err: messages.ErrCouponNotActive},
+		{name: "within its window", coupon: models.Coupon{Status: string(models.COUPON_ACTIVE), StartsAt: &yesterday, EndsAt: &tomorrow}},
+		{name: "not started", coupon: models.Coupon{Status: string(models.COUPON_ACTIVE), StartsAt: &tomorrow}, err: messages.ErrCouponNotActive},
+		{name: "ended", coupon: models.Coupon{Status: string(models.COUPON_ACTIVE), EndsAt: &yesterday}, err: messages.ErrCouponNotActive},
+		{name: "uses left", coupon: models.Coupon{Status: string(models.COUPON_ACTIVE), UsageLimit: 2, UsedCount: 1}},
+		{name: "used up", coupon: models.Coupon{Status: string(models.COUPON_ACTIVE), UsageLimit: 2, UsedCount: 2}, err: messages.ErrCouponUsageLimitReached},
+		{name: "uses left for the user", coupon: models.Coupon{Status: string(models.COUPON_ACTIVE), PerUserLimit: 2}, redeemed: 1},
+		{name: "used up by the user", coupon: models.Coupon{Status: string(models.COUPON_ACTIVE), PerUserLimit: 2}, redeemed: 2, err: messages.ErrCouponUserLimitReached},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon := tt.coupon
+			coupon.Id = uuid.New()
+			redemptions := &fakeCouponRedemptionRepo{redemptions: []*models.CouponRedemption{
+				{CouponId: coupon.Id, UserId: otherUserId},
+				{CouponId: uuid.New(), UserId: userId},
+			}}
+			for i := 0; i < tt.redeemed; i++ {
+				redemptions.redemptions = append(redemptions.redemptions, &models.CouponRedemption{CouponId: coupon.Id, UserId: userId})
+			}
+			if err := checkCouponUsage(context.Background(), redemptions, &coupon, userId); err != tt.err {
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestApplyCoupon(t *testing.T) {
+	ngn := func(amount int64) models.Money { return models.NewMoney(amount, models.CURRENCY_NGN) }
+	shoe, sock := uuid.New(), uuid.New()
+	user := &models.User{Id: uuid.New()}
+	later := time.Now().UTC().Add(time.Hour)
+	// records are 10,000.00 of shoes and 5,000.00 of socks
+	records := func() []*models.OrderRecord {
+		return []*models.OrderRecord{
+			{Id: uuid.New(), ProductId: shoe, Amount: ngn(500000), Quantity: 2, Currency: string(models.CURRENCY_NGN)},
+			{Id: uuid.New(), ProductId: sock, Amount: ngn(250000), Quantity: 2, Currency: string(models.CURRENCY_NGN)},
+		}
+	}
+	percent := func(value int64) models.Coupon {
+		return models.Coupon{Type: string(models.COUPON_PERCENTAGE), Value: value, Status: string(models.COUPON_ACTIVE)}
+	}
+	fixed := func(value int64, currency models.Currency) models.Coupon {
+		return models.Coupon{Type: string(models.COUPON_FIXED), Value: value, Currency: string(currency), Status: string(models.COUPON_ACTIVE)}
+	}
+	with := func(coupon models.Coupon, change func(coupon *models.Coupon)) models.Coupon {
+		change(&coupon)
+		return coupon
+	}
+
+	tests := []struct {
+		name   string
+		code   string
+		coupon models.Coupon
+		// records sold at a discount on the product, and records with promotion discount lines
+		discounted []int
+		promoted   map[int]int64
+		want       int64
+		err        error
+	}{
+		{name: "percentage of the order", coupon: percent(10), want: 150000},
+		{name: "code in any case", code: "save", coupon: percent(10), want: 150000},
+		{name: "unknown code", code: "other", coupon: percent(10), err: messages.ErrCouponNotFound},
+		{name: "percentage capped", coupon: with(percent(10), func(c *models.Coupon) { c.MaxDiscount = 100000 }), want: 100000},
+		{name: "fixed", coupon: fixed(300000, models.CURRENCY_NGN), want: 300000},
+		{name: "fixed over the order", coupon: fixed(2000000, models.CURRENCY_NGN), want: 1500000},
+		{name: "fixed in another currency", coupon: fixed(1000, models.CURRENCY_USD), err: messages.ErrCouponNotApplicable},
+		{name: "only its products", coupon: with(percent(10), func(c *models.Coupon) { c.ProductIds = models.UUIDList{sock} }), want: 50000},
+		{name: "none of its products", coupon: with(percent(10), func(c *models.Coupon) { c.ProductIds = models.UUIDList{uuid.New()} }), err: messages.ErrCouponNotApplicable},
+		{name: "min order reached", coupon: with(percent(10), func(c *models.Coupon) { c.MinOrderAmount = 1500000 }), want: 150000},
+		{name: "min order not reached", coupon: with(percent(10), func(c *models.Coupon) { c.MinOrderAmount = 1500001 }), err: messages.ErrCouponMinOrderAmount},
+		// 1 USD is 1,500 NGN, so the order is worth 10.00 USD
+		{name: "min order reached in the coupon currency", coupon: with(percent(10), func(c *models.Coupon) { c.Currency, c.MinOrderAmount = "USD", 1000 }), want: 150000},
+		{name: "min order not reached in the coupon currency", coupon: with(percent(10), func(c *models.Coupon) { c.Currency, c.MinOrderAmount = "USD", 1001 }), err: messages.ErrCouponMinOrderAmount},
+		{name: "max discount in the coupon currency", coupon: with(percent(10), func(c *models.Coupon) { c.Currency, c.MaxDiscount = "USD", 50 }), want: 75000},
+		{name: "min order after promotions", coupon: with(percent(10), func(c *models.Coupon) { c.MinOrderAmount = 1500000 }), promoted: map[int]int64{1: 1}, err: messages.ErrCouponMinOrderAmount},
+		{name: "not stackable leaves out discounted records", coupon: percent(10), discounted: []int{0}, want: 50000},
+		{name: "not stackable leaves out promoted records", coupon: percent(10), promoted: map[int]int64{1: 100000}, want: 100000},
+		{name: "not stackable on discounted records only", coupon: percent(10), discounted: []int{0, 1}, err: messages.ErrCouponNotApplicable},
+		{name: "stackable takes discounted records", coupon: with(percent(10), func(c *models.Coupon) { c.Stackable = true }), discounted: []int{0, 1}, want: 150000},
+		{name: "stackable takes what promotions left", coupon: with(percent(10), func(c *models.Coupon) { c.Stackable = true }), promoted: map[int]int64{1: 100000}, want: 140000},
+		{name: "fixed capped at what promotions left", coupon: with(fixed(2000000, models.CURRENCY_NGN), func(c *models.Coupon) { c.Stackable = true }), promoted: map[int]int64{1: 100000}, want: 1400000},
+		{name: "used up", coupon: with(percent(10), func(c *models.Coupon) { c.UsageLimit, c.UsedCount = 1, 1 }), err: messages.ErrCouponUsageLimitReached},
+		{name: "not started", coupon: with(percent(10), func(c *models.Coupon) { c.StartsAt = &later }), err: messages.ErrCouponNotActive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon := tt.coupon
+			coupon.Id, coupon.Code = uuid.New(), "SAVE"
+			order := &models.Order{
+				Id:            uuid.New(),
+				Currency:      string(models.CURRENCY_NGN),
+				OrderRecords:  records(),
+				ExchangeRates: models.ExchangeRateSnapshot{"USD": "1500"},
+			}
+			quote := &orderQuote{order: order, discounted: map[uuid.UUID]bool{}, promoted: map[uuid.UUID]bool{}}
+			for _, i := range tt.discounted {
+				quote.discounted[order.OrderRecords[i].Id] = true
+			}
+			for i, amount := range tt.promoted {
+				quote.promoted[order.OrderRecords[i].Id] = true
+				order.Discounts = append(order.Discounts, &models.OrderDiscount{OrderRecordId: &order.OrderRecords[i].Id, Amount: ngn(amount)})
+			}
+			code := tt.code
+			if code == "" {
+				code = coupon.Code
+			}
+			c := &Controller{
+				couponRepo:           &fakeCouponRepo{coupons: []*models.Coupon{&coupon}},
+				couponRedemptionRepo: &fakeCouponRedemptionRepo{},
+			}
+
+			applied, discount, err := c.applyCoupon(context.Background(), code, user, quote)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err == nil && (applied.Id != coupon.Id || discount != ngn(tt.want)) {
+				t.Errorf("discount = %v, want %v", discount, ngn(tt.want))
+			}
+		})
+	}
+}
+
+func TestRedeemCoupon(t *testing.T) {
+	userId, promotionId := uuid.New(), uuid.New()
+	tests := []struct {
+		name string
+		// the coupon read when the order was priced, and the row once locked
+		read   models.Coupon
+		locked models.Coupon
+		// coupons the user redeemed since the order was priced
+		redeemed int
+		calls    []string
+		err      error
+	}{
+		{
+			name:   "uses left",
+			read:   models.Coupon{Status: string(models.COUPON_ACTIVE), UsageLimit: 2},
+			locked: models.Coupon{Status: string(models.COUPON_ACTIVE), UsageLimit: 2, UsedCount: 1},
+			calls:  []string{"lock", "redeem", "use"},
+		},
+		{
+			name:     "uses left for the user",
+			read:     models.Coupon{Status: string(models.COUPON_ACTIVE), PerUserLimit: 2},
+			locked:   models.Coupon{Status: string(models.COUPON_ACTIVE), PerUserLimit: 2},
+			redeemed: 1,
+			calls:    []string{"lock", "count", "redeem", "use"},
+		},
+		{
+			name:   "used up by orders placed since it was read",
+			read:   models.Coupon{Status: string(models.COUPON_ACTIVE), UsageLimit: 2, UsedCount: 1},
+			locked: models.Coupon{Status: string(models.COUPON_ACTIVE), UsageLimit: 2, UsedCount: 2},
+			calls:  []string{"lock"},
+			err:    messages.ErrCouponUsageLimitReached,
+		},
+		{
+			name:     "used up by the user since it was read",
+			read:     models.Coupon{Status: string(models.COUPON_ACTIVE), PerUserLimit: 1},
+			locked:   models.Coupon{Status: string(models.COUPON_ACTIVE), PerUserLimit: 1},
+			redeemed: 1,
+			calls:    []string{"lock", "count"},
+			err:      messages.ErrCouponUserLimitReached,
+		},
+		{
+			name:   "deactivated since it was read",
+			read:   models.Coupon{Status: string(models.COUPON_ACTIVE)},
+			locked: models.Coupon{Status: string(models.COUPON_INACTIVE)},
+			calls:  []string{"lock"},
+			err:    messages.ErrCouponNotActive,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			couponId := uuid.New()
+			read, locked := tt.read, tt.locked
+			read.Id, locked.Id = couponId, couponId
+			order := &models.Order{Id: uuid.New(), UserId: userId, Currency: string(models.CURRENCY_NGN), Discounts: []*models.OrderDiscount{
+				{CouponId: &couponId, Amount: models.NewMoney(1500, models.CURRENCY_NGN)},
+				{PromotionId: &promotionId, Amount: models.NewMoney(700, models.CURRENCY_NGN)},
+			}}
+
+			calls := []string{}
+			couponRepo := &fakeCouponRepo{coupons: []*models.Coupon{&locked}, calls: &calls}
+			couponRedemptionRepo := &fakeCouponRedemptionRepo{calls: &calls}
+			for i := 0; i < tt.redeemed; i++ {
+				couponRedemptionRepo.redemptions = append(couponRedemptionRepo.redemptions, &models.CouponRedemption{CouponId: couponId, UserId: userId})
+			}
+
+			err := redeemCoupon(context.Background(), couponRepo, couponRedemptionRepo, &read, order)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			// the limits are checked on the locked row, before the coupon is used
+			if !slices.Equal(calls, tt.calls) {
+				t.Errorf("calls = %v, want %v", calls, tt.calls)
+			}
+			if err != nil {
+				if locked.UsedCount != tt.locked.UsedCount {
+					t.Errorf("used count = %d, want %d", locked.UsedCount, tt.locked.UsedCount)
+				}
+				return
+			}
+			if locked.UsedCount != tt.locked.UsedCount+1 {
+				t.Errorf("used count = %d, want %d", locked.UsedCount, tt.locked.UsedCount+1)
+			}
+			redemption := couponRedemptionRepo.redemptions[len(couponRedemptionRepo.redemptions)-1]
+			if redemption.OrderId != order.Id || redemption.UserId != userId || redemption.Amount != 1500 {
+				t.Errorf("redemption = %+v, want 1500 on order %s", redemption, order.Id)
+			}
+		})
+	}
+}
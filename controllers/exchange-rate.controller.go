@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// SetExchangeRate sets the rate between two currencies
+func (c *Controller) SetExchangeRate(ctx context.Context, data *models.SetExchangeRateDto) *models.ResponseObject {
+	rate, err := newExchangeRate(string(data.BaseCurrency), string(data.QuoteCurrency), data.Rate)
+	if err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+
+	newRate, err := c.exchangeRateRepo.SetExchangeRate(ctx, rate)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(newRate, "success", "exchange rate set successfully", http.StatusOK)
+}
+
+// GetAllExchangeRates gets all exchange rates
+func (c *Controller) GetAllExchangeRates(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject {
+	result, err := c.exchangeRateRepo.GetAllExchangeRates(ctx, query)
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(result, "success", "exchange rates fetched successfully", http.StatusOK)
+}
+
+// ImportExchangeRates sets exchange rates from a csv of base_currency,quote_currency,rate rows.
+// Invalid rows are skipped and reported, a header row is allowed.
+func (c *Controller) ImportExchangeRates(ctx context.Context, file io.Reader) *models.ResponseObject {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	result := &models.ExchangeRateImportResponse{Errors: []string{}}
+	var rates []*models.ExchangeRate
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			if _, ok := err.(*csv.ParseError); ok {
+				continue
+			}
+			return handleError(messages.ErrInvalidInput, "bad-request", http.StatusBadRequest)
+		}
+		if row == 1 && strings.EqualFold(record[0], "base_currency") {
+			continue
+		}
+
+		rate, err := newExchangeRate(strings.ToUpper(record[0]), strings.ToUpper(record[1]), record[2])
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("row %d: %s", row, err.Error()))
+			continue
+		}
+		rates = append(rates, rate)
+	}
+
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		exchangeRateRepo := repo.NewExchangeRateRepo(tx)
+		for _, rate := range rates {
+			if _, err := exchangeRateRepo.SetExchangeRate(ctx, rate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	result.Imported = len(rates)
+	return handleSuccess(result, "success", "exchange rates imported successfully", http.StatusOK)
+}
+
+// getExchangeRate gets the rate to convert from one currency into another, inverting the
+// opposite rate when only that one is set
+func (c *Controller) getExchangeRate(ctx context.Context, from, to string) (*models.ExchangeRate, error) {
+	rate, err := c.exchangeRateRepo.GetExchangeRateByFields(ctx, helpers.Map{"base_currency": from, "quote_currency": to})
+	if err != messages.ErrExchangeRateNotFound {
+		return rate, err
+	}
+
+	rate, err = c.exchangeRateRepo.GetExchangeRateByFields(ctx, helpers.Map{"base_currency": to, "quote_currency": from})
+	if err != nil {
+		return nil, err
+	}
+	return rate.Inverse()
+}
+
+// convert converts money into the order currency, snapshotting the rate used on the order
+func (c *Controller) convert(ctx context.Context, order *models.Order, money models.Money) (models.Money, error) {
+	currency := string(money.Currency)
+	if currency == order.Currency {
+		return money, nil
+	}
+
+	if order.ExchangeRates == nil {
+		order.ExchangeRates = models.ExchangeRateSnapshot{}
+	}
+	rate := &models.ExchangeRate{BaseCurrency: currency, QuoteCurrency: order.Currency, Rate: order.ExchangeRates[currency]}
+	if rate.Rate == "" {
+		var err error
+		if rate, err = c.getExchangeRate(ctx, currency, order.Currency); err != nil {
+			return models.Money{}, err
+		}
+		order.ExchangeRates[currency] = rate.Rate
+	}
+	return rate.Convert(money)
+}
+
+func newExchangeRate(base, quote, rate string) (*models.ExchangeRate, error) {
+	if !models.Currency(base).IsValid() || !models.Currency(quote).IsValid() || base == quote {
+		return nil, messages.ErrInvalidCurrencyPair
+	}
+	parsed, ok := models.ParseExchangeRate(rate)
+	if !ok {
+		return nil, messages.ErrInvalidExchangeRate
+	}
+	return &models.ExchangeRate{
+		Id:            uuid.New(),
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          parsed.FloatString(models.EXCHANGE_RATE_PRECISION),
+	}, nil
+}
@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// the fakes embed the repo they stand in for, so a test that calls a method they do not fake panics
+
+type fakeProductRepo struct {
+	repo.ProductRepo
+	products  []*models.Product
+	deleteErr error
+	deleted   []uuid.UUID
+}
+
+func (f *fakeProductRepo) GetProductByFields(ctx context.Context, fields map[string]interface{}) (*models.Product, error) {
+	for _, product := range f.products {
+		if product.Id == fields["id"] {
+			return product, nil
+		}
+	}
+	return nil, messages.ErrProductNotFound
+}
+
+func (f *fakeProductRepo) DeleteProduct(ctx context.Context, product *models.Product) error {
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.deleted = append(f.deleted, product.Id)
+	return nil
+}
+
+type fakeProductVariantRepo struct {
+	repo.ProductVariantRepo
+	variants []*models.ProductVariant
+	deleted  []uuid.UUID
+}
+
+func (f *fakeProductVariantRepo) GetProductVariantByFields(ctx context.Context, fields map[string]interface{}) (*models.ProductVariant, error) {
+	for _, variant := range f.variants {
+		if variant.Id == fields["id"] && variant.ProductId == fields["product_id"] {
+			return variant, nil
+		}
+	}
+	return nil, messages.ErrProductVariantNotFound
+}
+
+func (f *fakeProductVariantRepo) DeleteProductVariant(ctx context.Context, id uuid.UUID) error {
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+type fakeProductMediaRepo struct {
+	repo.ProductMediaRepo
+}
+
+func (f *fakeProductMediaRepo) GetMediaOfProduct(ctx context.Context, productId uuid.UUID) ([]*models.ProductMedia, error) {
+	return nil, nil
+}
+
+// fakeOrderRecordRepo keeps the order records of the products and variants that were ordered
+type fakeOrderRecordRepo struct {
+	repo.OrderRecordRepo
+	records []*models.OrderRecord
+}
+
+func (f *fakeOrderRecordRepo) CountOrderRecords(ctx context.Context, fields map[string]interface{}) (int64, error) {
+	var count int64
+	for _, record := range f.records {
+		if productId, ok := fields["product_id"]; ok && record.ProductId != productId {
+			continue
+		}
+		if variantId, ok := fields["variant_id"]; ok && (record.VariantId == nil || *record.VariantId != variantId) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// fakePromotionRepo keeps the running promotions, highest priority first
+type fakePromotionRepo struct {
+	repo.PromotionRepo
+	promotions []*models.Promotion
+}
+
+func (f *fakePromotionRepo) GetActivePromotions(ctx context.Context, at time.Time) ([]*models.Promotion, error) {
+	return f.promotions, nil
+}
+
+// fakeCouponRepo keeps coupons as they are in the database, and adds the calls that lock and use them to calls
+type fakeCouponRepo struct {
+	repo.CouponRepo
+	coupons []*models.Coupon
+	calls   *[]string
+}
+
+func (f *fakeCouponRepo) GetCouponByFields(ctx context.Context, fields map[string]interface{}) (*models.Coupon, error) {
+	for _, coupon := range f.coupons {
+		if coupon.Code == fields["code"] {
+			copied := *coupon
+			return &copied, nil
+		}
+	}
+	return nil, messages.ErrCouponNotFound
+}
+
+func (f *fakeCouponRepo) GetCouponForUpdate(ctx context.Context, fields map[string]interface{}) (*models.Coupon, error) {
+	addCall(f.calls, "lock")
+	for _, coupon := range f.coupons {
+		if coupon.Id == fields["id"] {
+			copied := *coupon
+			return &copied, nil
+		}
+	}
+	return nil, messages.ErrCouponNotFound
+}
+
+func (f *fakeCouponRepo) AddUsedCount(ctx context.Context, id uuid.UUID, count int64) error {
+	addCall(f.calls, "use")
+	for _, coupon := range f.coupons {
+		if coupon.Id == id {
+			coupon.UsedCount += count
+		}
+	}
+	return nil
+}
+
+// fakeCouponRedemptionRepo keeps coupon redemptions, and adds the calls that count and create them to calls
+type fakeCouponRedemptionRepo struct {
+	repo.CouponRedemptionRepo
+	redemptions []*models.CouponRedemption
+	calls       *[]string
+}
+
+func (f *fakeCouponRedemptionRepo) CountCouponRedemptions(ctx context.Context, fields map[string]interface{}) (int64, error) {
+	addCall(f.calls, "count")
+	var count int64
+	for _, redemption := range f.redemptions {
+		if redemption.CouponId == fields["coupon_id"] && redemption.UserId == fields["user_id"] {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeCouponRedemptionRepo) CreateCouponRedemption(ctx context.Context, redemption *models.CouponRedemption) (*models.CouponRedemption, error) {
+	addCall(f.calls, "redeem")
+	f.redemptions = append(f.redemptions, redemption)
+	return redemption, nil
+}
+
+func addCall(calls *[]string, call string) {
+	if calls != nil {
+		*calls = append(*calls, call)
+	}
+}
+
+// fakeShippingZoneRepo keeps the active shipping zones with their active methods
+type fakeShippingZoneRepo struct {
+	repo.ShippingZoneRepo
+	zones []*models.ShippingZone
+}
+
+func (f *fakeShippingZoneRepo) GetActiveShippingZones(ctx context.Context, country string) ([]*models.ShippingZone, error) {
+	zones := []*models.ShippingZone{}
+	for _, zone := range f.zones {
+		if zone.Countries.Contains(country) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+// fakeCartRepo keeps carts as they are in the database
+type fakeCartRepo struct {
+	repo.CartRepo
+	carts []*models.Cart
+}
+
+func (f *fakeCartRepo) UpdateCartById(ctx context.Context, id uuid.UUID, cart *models.Cart) error {
+	for _, stored := range f.carts {
+		if stored.Id == id && cart.UserId != nil {
+			stored.UserId = cart.UserId
+		}
+	}
+	return nil
+}
+
+func (f *fakeCartRepo) MoveCart(ctx context.Context, id uuid.UUID, from string, to string) error {
+	for _, stored := range f.carts {
+		if stored.Id == id && stored.Status == from {
+			stored.Status = to
+			return nil
+		}
+	}
+	return messages.ErrCartNotFound
+}
+
+// fakeCartItemRepo keeps cart items as they are in the database
+type fakeCartItemRepo struct {
+	repo.CartItemRepo
+	items []*models.CartItem
+}
+
+func (f *fakeCartItemRepo) UpdateCartItemById(ctx context.Context, id uuid.UUID, cartItem *models.CartItem) error {
+	for _, item := range f.items {
+		if item.Id != id {
+			continue
+		}
+		if cartItem.CartId != uuid.Nil {
+			item.CartId = cartItem.CartId
+		}
+		if cartItem.Quantity != 0 {
+			item.Quantity = cartItem.Quantity
+		}
+	}
+	return nil
+}
+
+func (f *fakeCartItemRepo) DeleteCartItem(ctx context.Context, cartItem *models.CartItem) error {
+	f.items = slices.DeleteFunc(f.items, func(item *models.CartItem) bool { return item.Id == cartItem.Id })
+	return nil
+}
@@ -0,0 +1,607 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+const (
+	// IMPORT_MAX_SIZE is the largest import file, in bytes
+	IMPORT_MAX_SIZE = 20 << 20
+	// IMPORT_PROGRESS_EVERY is how many rows are processed between saves of an import's progress
+	IMPORT_PROGRESS_EVERY = 50
+	// IMPORT_MAX_ERRORS is how many row errors an import keeps, failed rows past it are only counted
+	IMPORT_MAX_ERRORS = 1000
+	// EXPORT_BATCH_SIZE is how many products are read at a time for an export
+	EXPORT_BATCH_SIZE = 200
+)
+
+// importRow is a row of an import file, or why it could not be read
+type importRow struct {
+	number int64
+	row    *models.ProductImportRow
+	err    error
+}
+
+// productImport is the state of an import shared by its rows
+type productImport struct {
+	job  *models.ImportJob
+	user *models.User
+	// ids of the categories rows refer to, by id or slug
+	categoryIds map[string]uuid.UUID
+	// slugs of the products a dry run would have created, so rows of their variants can be checked
+	dryRunProducts map[string]bool
+}
+
+// StartProductImport checks an import file can be read and imports its rows in the background. Progress and
+// the errors of the rows are on the import job.
+func (c *Controller) StartProductImport(ctx context.Context, file io.Reader, data *models.ProductImportDto, user *models.User) *models.ResponseObject {
+	rows, err := readImportRows(file, data.Format)
+	if err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	job, err := c.createImportJob(ctx, data, int64(len(rows)), user)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	// the import outlives the request, and goes on updating its own copy of the job
+	started := *job
+	go c.runProductImport(context.Background(), &productImport{job: job, user: user}, rows)
+	return handleSuccess(&started, "success", "product import started successfully", http.StatusAccepted)
+}
+
+// RunProductImport imports the rows of an import file and returns the finished import job
+func (c *Controller) RunProductImport(ctx context.Context, file io.Reader, data *models.ProductImportDto, user *models.User) (*models.ImportJob, error) {
+	rows, err := readImportRows(file, data.Format)
+	if err != nil {
+		return nil, err
+	}
+	job, err := c.createImportJob(ctx, data, int64(len(rows)), user)
+	if err != nil {
+		return nil, err
+	}
+	c.runProductImport(ctx, &productImport{job: job, user: user}, rows)
+	return job, nil
+}
+
+// GetProductImport gets an import job with its progress and the errors of its rows
+func (c *Controller) GetProductImport(ctx context.Context, jobId uuid.UUID) *models.ResponseObject {
+	job, err := c.importJobRepo.GetImportJobByFields(ctx, helpers.Map{"id": jobId})
+	if err == messages.ErrImportJobNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(job, "success", "product import fetched successfully", http.StatusOK)
+}
+
+// ExportProducts writes every product, followed by its variants, as rows a product import reads back
+func (c *Controller) ExportProducts(ctx context.Context, format models.ImportFormat, w io.Writer) error {
+	var write func(row *models.ProductImportRow) error
+	var flush func() error
+	switch format {
+	case models.IMPORT_JSONL:
+		encoder := json.NewEncoder(w)
+		write = func(row *models.ProductImportRow) error { return encoder.Encode(row) }
+		flush = func() error { return nil }
+	default:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(models.PRODUCT_IMPORT_COLUMNS); err != nil {
+			return err
+		}
+		write = func(row *models.ProductImportRow) error {
+			record, err := row.CSVRecord()
+			if err != nil {
+				return err
+			}
+			return writer.Write(record)
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	}
+
+	after := uuid.Nil
+	for {
+		products, err := c.productRepo.GetProductsAfter(ctx, after, EXPORT_BATCH_SIZE)
+		if err != nil {
+			return err
+		}
+		for _, product := range products {
+			for _, row := range toProductImportRows(product) {
+				if err := write(row); err != nil {
+					return err
+				}
+			}
+			after = product.Id
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if len(products) < EXPORT_BATCH_SIZE {
+			return nil
+		}
+	}
+}
+
+func (c *Controller) createImportJob(ctx context.Context, data *models.ProductImportDto, totalRows int64, user *models.User) (*models.ImportJob, error) {
+	job := &models.ImportJob{
+		Id:        uuid.New(),
+		Format:    string(data.Format),
+		DryRun:    data.DryRun,
+		Status:    string(models.IMPORT_PENDING),
+		TotalRows: totalRows,
+		Errors:    models.ImportErrors{},
+	}
+	if user != nil {
+		job.CreatedBy = &user.Id
+	}
+	return c.importJobRepo.CreateImportJob(ctx, job)
+}
+
+// runProductImport imports rows one by one, saving the progress of the job as it goes. A row that fails
+// is reported on the job and does not stop the others.
+func (c *Controller) runProductImport(ctx context.Context, state *productImport, rows []importRow) {
+	job := state.job
+	state.categoryIds = map[string]uuid.UUID{}
+	state.dryRunProducts = map[string]bool{}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Msgf("runProductImport: import %s failed: %v", job.Id, r)
+			job.Error = fmt.Sprint(r)
+			c.saveImportProgress(ctx, job, models.IMPORT_FAILED)
+		}
+	}()
+	c.saveImportProgress(ctx, job, models.IMPORT_RUNNING)
+
+	for i, row := range rows {
+		created, errs := c.importRow(ctx, state, row)
+		switch {
+		case len(errs) > 0:
+			job.FailedRows++
+			if len(job.Errors) < IMPORT_MAX_ERRORS {
+				key := ""
+				if row.row != nil {
+					key = row.row.Key()
+				}
+				job.Errors = append(job.Errors, models.ImportError{Row: row.number, Key: key, Errors: errs})
+			}
+		case created:
+			job.CreatedRows++
+		default:
+			job.UpdatedRows++
+		}
+		job.ProcessedRows++
+		if (i+1)%IMPORT_PROGRESS_EVERY == 0 {
+			c.saveImportProgress(ctx, job, models.IMPORT_RUNNING)
+		}
+	}
+	c.saveImportProgress(ctx, job, models.IMPORT_COMPLETED)
+}
+
+// saveImportProgress saves the counts, errors and status of an import, failures are only logged so the import goes on
+func (c *Controller) saveImportProgress(ctx context.Context, job *models.ImportJob, status models.ImportStatus) {
+	job.Status = string(status)
+	update := helpers.Map{
+		"status":         job.Status,
+		"processed_rows": job.ProcessedRows,
+		"created_rows":   job.CreatedRows,
+		"updated_rows":   job.UpdatedRows,
+		"failed_rows":    job.FailedRows,
+		"errors":         job.Errors,
+		"error":          job.Error,
+	}
+	if status == models.IMPORT_COMPLETED || status == models.IMPORT_FAILED {
+		now := time.Now().UTC()
+		job.FinishedAt = &now
+		update["finished_at"] = now
+	}
+	if err := c.importJobRepo.UpdateImportJobById(ctx, job.Id, update); err != nil {
+		log.Err(err).Msgf("saveImportProgress: could not save import %s: %v", job.Id, err)
+	}
+}
+
+// stock is the stock movement rows of the import are recorded as
+func (p *productImport) stock() *models.InventoryMovement {
+	stock := &models.InventoryMovement{Type: string(models.INVENTORY_IMPORT), ReferenceId: &p.job.Id}
+	if p.user != nil {
+		stock.ActorId = &p.user.Id
+	}
+	return stock
+}
+
+// importRow imports a row, returning whether it created a product or variant, or why it failed
+func (c *Controller) importRow(ctx context.Context, state *productImport, row importRow) (bool, []string) {
+	if row.err != nil {
+		return false, []string{row.err.Error()}
+	}
+	if row.row.Sku != "" {
+		return c.importVariant(ctx, state, row.row)
+	}
+	return c.importProduct(ctx, state, row.row)
+}
+
+// importProduct creates the product of a row or updates the product with its slug
+func (c *Controller) importProduct(ctx context.Context, state *productImport, row *models.ProductImportRow) (bool, []string) {
+	if row.Slug == "" && row.Name != nil {
+		row.Slug = helpers.ToSlug(*row.Name)
+	}
+	if row.Slug == "" {
+		return false, []string{messages.ErrImportKeyRequired.Error()}
+	}
+	var categoryIds *[]string
+	if row.Categories != nil {
+		ids, err := c.importCategoryIds(ctx, state, *row.Categories)
+		if err != nil {
+			return false, []string{err.Error()}
+		}
+		categoryIds = &ids
+	}
+	existing, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"slug": row.Slug})
+	if err != nil && err != messages.ErrProductNotFound {
+		return false, []string{err.Error()}
+	}
+
+	// a product created by an earlier row of a dry run is updated by this one in the real run
+	if existing == nil && state.job.DryRun && state.dryRunProducts[row.Slug] {
+		return false, nil
+	}
+	if existing == nil {
+		data := models.CreateProductDto{
+			Name:             deref(row.Name),
+			Description:      deref(row.Description),
+			Brand:            deref(row.Brand),
+			Quantity:         deref(row.Quantity),
+			Price:            deref(row.Price),
+			Discount:         deref(row.Discount),
+			Currency:         models.Currency(deref(row.Currency)),
+			TaxClass:         models.TaxClass(deref(row.TaxClass)),
+			Weight:           deref(row.Weight),
+			Length:           deref(row.Length),
+			Width:            deref(row.Width),
+			Height:           deref(row.Height),
+			ReturnWindowDays: row.ReturnWindowDays,
+		}
+		if categoryIds != nil {
+			data.CategoryIds = *categoryIds
+		}
+		if row.Attributes != nil {
+			data.Attributes = *row.Attributes
+		}
+		status := &models.UpdateProductDto{Status: (*models.ProductStatus)(row.Status)}
+		if inputErrors := append(helpers.ValidateInput(data), helpers.ValidateInput(status)...); len(inputErrors) > 0 {
+			return false, inputErrors
+		}
+
+		if state.job.DryRun {
+			if data.Discount > data.Price {
+				return false, []string{messages.ErrDiscountExceedsPrice.Error()}
+			}
+			if _, err := c.toProductAttributes(ctx, toUuids(data.CategoryIds), data.Attributes); err != nil {
+				return false, []string{err.Error()}
+			}
+			state.dryRunProducts[row.Slug] = true
+			return true, nil
+		}
+		result := c.createProduct(ctx, &data, row.Slug, state.stock())
+		if err := resultError(result); err != nil {
+			return false, []string{err.Error()}
+		}
+		if row.Status != nil {
+			product := result.Data.(*models.Product)
+			if err := resultError(c.updateProduct(ctx, status, product.Id, true, state.stock())); err != nil {
+				return false, []string{err.Error()}
+			}
+		}
+		return true, nil
+	}
+
+	if row.Currency != nil && *row.Currency != existing.Currency {
+		return false, []string{messages.ErrImportCurrencyChange.Error()}
+	}
+	data := models.UpdateProductDto{
+		Name:             row.Name,
+		Description:      row.Description,
+		Brand:            row.Brand,
+		Quantity:         row.Quantity,
+		Status:           (*models.ProductStatus)(row.Status),
+		Price:            row.Price,
+		Discount:         row.Discount,
+		TaxClass:         (*models.TaxClass)(row.TaxClass),
+		Weight:           row.Weight,
+		Length:           row.Length,
+		Width:            row.Width,
+		Height:           row.Height,
+		ReturnWindowDays: row.ReturnWindowDays,
+		CategoryIds:      categoryIds,
+		Attributes:       row.Attributes,
+	}
+	if inputErrors := helpers.ValidateInput(data); len(inputErrors) > 0 {
+		return false, inputErrors
+	}
+
+	if state.job.DryRun {
+		if data.Attributes != nil || data.CategoryIds != nil {
+			attributeCategoryIds, attributes := []uuid.UUID{}, map[string]interface{}(existing.Attributes)
+			for _, category := range existing.Categories {
+				attributeCategoryIds = append(attributeCategoryIds, category.Id)
+			}
+			if data.CategoryIds != nil {
+				attributeCategoryIds = toUuids(*data.CategoryIds)
+			}
+			if data.Attributes != nil {
+				attributes = *data.Attributes
+			}
+			if _, err := c.toProductAttributes(ctx, attributeCategoryIds, attributes); err != nil {
+				return false, []string{err.Error()}
+			}
+		}
+		return false, nil
+	}
+	// products are matched on their slug, so a new name does not change it
+	if err := resultError(c.updateProduct(ctx, &data, existing.Id, true, state.stock())); err != nil {
+		return false, []string{err.Error()}
+	}
+	return false, nil
+}
+
+// importVariant creates the variant of a row under the product with its slug, or updates the variant with its sku
+func (c *Controller) importVariant(ctx context.Context, state *productImport, row *models.ProductImportRow) (bool, []string) {
+	existing, err := c.productVariantRepo.GetProductVariantByFields(ctx, helpers.Map{"sku": row.Sku})
+	if err != nil && err != messages.ErrProductVariantNotFound {
+		return false, []string{err.Error()}
+	}
+
+	if existing != nil {
+		product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": existing.ProductId})
+		if err != nil {
+			return false, []string{err.Error()}
+		}
+		if row.Slug != "" && row.Slug != product.Slug {
+			return false, []string{messages.ErrImportVariantProductMismatch.Error()}
+		}
+		data := models.UpdateVariantDto{
+			Options:  row.Options,
+			Price:    row.Price,
+			Discount: row.Discount,
+			Quantity: row.Quantity,
+			Status:   (*models.ProductStatus)(row.Status),
+			Position: row.Position,
+		}
+		if inputErrors := helpers.ValidateInput(data); len(inputErrors) > 0 {
+			return false, inputErrors
+		}
+		if state.job.DryRun {
+			return false, nil
+		}
+		if err := resultError(c.updateProductVariant(ctx, product.Id, existing.Id, &data, state.stock())); err != nil {
+			return false, []string{err.Error()}
+		}
+		return false, nil
+	}
+
+	if row.Slug == "" {
+		return false, []string{messages.ErrImportKeyRequired.Error()}
+	}
+	data := models.CreateVariantDto{
+		Sku:      row.Sku,
+		Price:    row.Price,
+		Discount: row.Discount,
+		Quantity: deref(row.Quantity),
+		Position: deref(row.Position),
+	}
+	if row.Options != nil {
+		data.Options = *row.Options
+	}
+	status := &models.UpdateVariantDto{Status: (*models.ProductStatus)(row.Status)}
+	if inputErrors := append(helpers.ValidateInput(data), helpers.ValidateInput(status)...); len(inputErrors) > 0 {
+		return false, inputErrors
+	}
+	if state.job.DryRun && state.dryRunProducts[row.Slug] {
+		return true, nil
+	}
+	product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"slug": row.Slug})
+	if err != nil {
+		return false, []string{err.Error()}
+	}
+
+	if state.job.DryRun {
+		if err := fitVariant(product, product.Variants, toProductVariant(&data, product.Currency)); err != nil {
+			return false, []string{err.Error()}
+		}
+		return true, nil
+	}
+	result := c.createProductVariant(ctx, product.Id, &data, state.stock())
+	if err := resultError(result); err != nil {
+		return false, []string{err.Error()}
+	}
+	if row.Status != nil {
+		variant := result.Data.(*models.ProductVariant)
+		if err := resultError(c.updateProductVariant(ctx, product.Id, variant.Id, status, state.stock())); err != nil {
+			return false, []string{err.Error()}
+		}
+	}
+	return true, nil
+}
+
+// importCategoryIds gets the ids of the categories of a row, given by id or slug
+func (c *Controller) importCategoryIds(ctx context.Context, state *productImport, categories []string) ([]string, error) {
+	ids := []string{}
+	for _, idOrSlug := range categories {
+		id, ok := state.categoryIds[idOrSlug]
+		if !ok {
+			category, err := c.getCategory(ctx, idOrSlug)
+			if err != nil {
+				return nil, err
+			}
+			id = category.Id
+			state.categoryIds[idOrSlug] = id
+		}
+		ids = append(ids, id.String())
+	}
+	return ids, nil
+}
+
+// readImportRows reads the rows of an import file. A file that cannot be read at all is an error,
+// a row that cannot be read is reported with the row.
+func readImportRows(file io.Reader, format models.ImportFormat) ([]importRow, error) {
+	if format == "" {
+		return nil, messages.ErrImportFormatRequired
+	}
+	data, err := io.ReadAll(io.LimitReader(file, IMPORT_MAX_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > IMPORT_MAX_SIZE {
+		return nil, messages.ErrImportTooLarge
+	}
+
+	rows := []importRow{}
+	if format == models.IMPORT_JSONL {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), IMPORT_MAX_SIZE)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			row := importRow{number: int64(len(rows) + 1), row: &models.ProductImportRow{}}
+			decoder := json.NewDecoder(bytes.NewReader(line))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(row.row); err != nil {
+				row.row, row.err = nil, err
+			}
+			rows = append(rows, row)
+		}
+		return rows, scanner.Err()
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(models.PRODUCT_IMPORT_COLUMNS, header[i]) {
+			return nil, messages.ErrImportUnknownColumn
+		}
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row := importRow{number: int64(len(rows) + 1)}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			row.err = err
+		} else {
+			row.row, row.err = models.ParseProductImportRecord(header, record)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// toProductImportRows gets the export rows of a product, the product first and then its variants
+func toProductImportRows(product *models.Product) []*models.ProductImportRow {
+	categories := []string{}
+	for _, category := range product.Categories {
+		categories = append(categories, category.Slug)
+	}
+	row := &models.ProductImportRow{
+		Slug:             product.Slug,
+		Name:             &product.Name,
+		Description:      &product.Description,
+		Brand:            &product.Brand,
+		Currency:         &product.Currency,
+		Price:            &product.Price.Amount,
+		Discount:         &product.Discount.Amount,
+		Quantity:         &product.AvailableQuantity,
+		Status:           &product.Status,
+		TaxClass:         &product.TaxClass,
+		Weight:           &product.Weight,
+		Length:           &product.Length,
+		Width:            &product.Width,
+		Height:           &product.Height,
+		ReturnWindowDays: &product.ReturnWindowDays,
+		Categories:       &categories,
+	}
+	if len(product.Attributes) > 0 {
+		attributes := map[string]interface{}(product.Attributes)
+		row.Attributes = &attributes
+	}
+
+	rows := []*models.ProductImportRow{row}
+	for _, variant := range product.Variants {
+		options := map[string]string(variant.Options)
+		variantRow := &models.ProductImportRow{
+			Slug:     product.Slug,
+			Sku:      variant.Sku,
+			Quantity: &variant.AvailableQuantity,
+			Status:   &variant.Status,
+			Options:  &options,
+			Position: &variant.Position,
+		}
+		if variant.Price != nil {
+			variantRow.Price = &variant.Price.Amount
+		}
+		if variant.Discount != nil {
+			variantRow.Discount = &variant.Discount.Amount
+		}
+		rows = append(rows, variantRow)
+	}
+	return rows
+}
+
+// resultError gets the error of a failed controller result
+func resultError(result *models.ResponseObject) error {
+	if result.Code < http.StatusBadRequest {
+		return nil
+	}
+	return errors.New(result.Message)
+}
+
+// toUuids parses ids already checked to be uuids
+func toUuids(values []string) []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, value := range values {
+		id, _ := uuid.Parse(value)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// deref gets the value of an optional field, its zero value when it is not set
+func deref[T any](value *T) T {
+	var zero T
+	if value == nil {
+		return zero
+	}
+	return *value
+}
@@ -0,0 +1,279 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// GetProductInventory gets the stock of a product and its variants, reconciled with the inventory ledger,
+// and a page of the ledger
+func (c *Controller) GetProductInventory(ctx context.Context, productId uuid.UUID, query *models.APIPagingDto) *models.ResponseObject {
+	product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": productId})
+	if err == messages.ErrProductNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	ledger, err := c.inventoryMovementRepo.GetLedgerQuantities(ctx, productId)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	movements, err := c.inventoryMovementRepo.GetInventoryMovements(ctx, query, helpers.Map{"product_id": productId})
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	stock := []*models.StockLevel{{
+		AvailableQuantity: product.AvailableQuantity,
+		LedgerQuantity:    ledger[uuid.Nil],
+		Reconciled:        product.AvailableQuantity == ledger[uuid.Nil],
+		Warehouses:        []*models.WarehouseStock{},
+	}}
+	levels := map[uuid.UUID]*models.StockLevel{uuid.Nil: stock[0]}
+	for _, variant := range product.Variants {
+		level := &models.StockLevel{
+			VariantId:         &variant.Id,
+			Sku:               variant.Sku,
+			AvailableQuantity: variant.AvailableQuantity,
+			LedgerQuantity:    ledger[variant.Id],
+			Reconciled:        variant.AvailableQuantity == ledger[variant.Id],
+			Warehouses:        []*models.WarehouseStock{},
+		}
+		levels[variant.Id] = level
+		stock = append(stock, level)
+	}
+	for _, warehouseStock := range product.Stock {
+		key := uuid.Nil
+		if warehouseStock.VariantId != nil {
+			key = *warehouseStock.VariantId
+		}
+		if level, ok := levels[key]; ok {
+			level.Warehouses = append(level.Warehouses, warehouseStock)
+		}
+	}
+	response := &models.ProductInventoryResponse{
+		ProductId:  product.Id,
+		Stock:      stock,
+		Movements:  movements.Movements,
+		PagingInfo: movements.PagingInfo,
+	}
+	return handleSuccess(response, "success", "product inventory fetched successfully", http.StatusOK)
+}
+
+// AdjustProductInventory adds stock to, or takes stock from, a product or one of its variants for a reason
+func (c *Controller) AdjustProductInventory(ctx context.Context, productId uuid.UUID, data *models.AdjustInventoryDto, user *models.User) *models.ResponseObject {
+	product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": productId})
+	if err == messages.ErrProductNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	var variantId *uuid.UUID
+	if data.VariantId != "" {
+		id, _ := uuid.Parse(data.VariantId)
+		if product.GetVariant(id) == nil {
+			return handleError(messages.ErrProductVariantNotFound, "bad-request", http.StatusBadRequest)
+		}
+		variantId = &id
+	}
+	var warehouseId *uuid.UUID
+	if data.WarehouseId != "" {
+		id, _ := uuid.Parse(data.WarehouseId)
+		_, err := c.warehouseRepo.GetWarehouseByFields(ctx, helpers.Map{"id": id})
+		if err == messages.ErrWarehouseNotFound {
+			return handleError(err, "bad-request", http.StatusBadRequest)
+		}
+		if err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+		warehouseId = &id
+	}
+
+	stock := &models.InventoryMovement{Type: string(models.INVENTORY_ADJUSTMENT), Reason: data.Reason, WarehouseId: warehouseId, ActorId: &user.Id}
+	movement := stock.For(product.Id, variantId, data.Quantity)
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		return moveStock(ctx, tx, movement)
+	})
+	if err == messages.ErrInsufficientStock {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(movement, "success", "product inventory adjusted successfully", http.StatusCreated)
+}
+
+// moveStock changes the stock of a product, or of its variant, in a warehouse and adds the movement to the
+// inventory ledger. Stock moves in the default warehouse unless the movement names one. Adjustments cannot
+// take out more than the warehouse holds. It must run inside a transaction.
+func moveStock(ctx context.Context, tx *db.Database, movement *models.InventoryMovement) error {
+	if movement.Quantity == 0 {
+		return nil
+	}
+	var err error
+	if movement.VariantId != nil {
+		movement.Balance, err = repo.NewProductVariantRepo(tx).AddVariantQuantity(ctx, *movement.VariantId, movement.Quantity)
+	} else {
+		movement.Balance, err = repo.NewProductRepo(tx).AddProductQuantity(ctx, movement.ProductId, movement.Quantity)
+	}
+	// stock of products and variants deleted since is no longer tracked
+	if err == messages.ErrProductNotFound || err == messages.ErrProductVariantNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	warehouseBalance, err := addWarehouseStock(ctx, tx, movement)
+	if err != nil {
+		return err
+	}
+	if warehouseBalance < 0 && movement.Type == string(models.INVENTORY_ADJUSTMENT) {
+		return messages.ErrInsufficientStock
+	}
+	_, err = repo.NewInventoryMovementRepo(tx).CreateInventoryMovement(ctx, movement)
+	return err
+}
+
+// addWarehouseStock moves the stock of a movement in its warehouse, the default warehouse when it has none,
+// and returns what the warehouse holds after
+func addWarehouseStock(ctx context.Context, tx *db.Database, movement *models.InventoryMovement) (int64, error) {
+	if movement.WarehouseId == nil {
+		warehouse, err := repo.NewWarehouseRepo(tx).GetWarehouseByFields(ctx, helpers.Map{"is_default": true})
+		if err != nil {
+			return 0, err
+		}
+		movement.WarehouseId = &warehouse.Id
+	}
+	return repo.NewWarehouseStockRepo(tx).AddWarehouseStock(ctx, *movement.WarehouseId, movement.ProductId, movement.VariantId, movement.Quantity)
+}
+
+// setStock moves the available quantity of a product, or of its variant, to quantity and records the
+// difference in the inventory ledger. It must run inside a transaction.
+func setStock(ctx context.Context, tx *db.Database, stock *models.InventoryMovement, productId uuid.UUID, variantId *uuid.UUID, quantity int64) error {
+	var current int64
+	if variantId != nil {
+		variant, err := repo.NewProductVariantRepo(tx).GetProductVariantForUpdate(ctx, *variantId)
+		if err != nil {
+			return err
+		}
+		current = variant.AvailableQuantity
+	} else {
+		product, err := repo.NewProductRepo(tx).GetProductForUpdate(ctx, productId)
+		if err != nil {
+			return err
+		}
+		current = product.AvailableQuantity
+	}
+	return moveStock(ctx, tx, stock.For(productId, variantId, quantity-current))
+}
+
+// recordStock adds the stock a product or variant was created with to the inventory ledger.
+// It must run inside a transaction.
+func recordStock(ctx context.Context, tx *db.Database, stock *models.InventoryMovement, productId uuid.UUID, variantId *uuid.UUID, quantity int64) error {
+	if quantity == 0 {
+		return nil
+	}
+	movement := stock.For(productId, variantId, quantity)
+	movement.Balance = quantity
+	if _, err := addWarehouseStock(ctx, tx, movement); err != nil {
+		return err
+	}
+	_, err := repo.NewInventoryMovementRepo(tx).CreateInventoryMovement(ctx, movement)
+	return err
+}
+
+// sellOrder takes the items of a paid order out of the warehouses they were allocated to, converting the
+// stock reserved for it. Stock whose reservation expired is held again first, so an order is not sold
+// from stock that is gone. It must run inside a transaction.
+func sellOrder(ctx context.Context, tx *db.Database, order *models.Order, actorId *uuid.UUID) error {
+	if err := reholdOrder(ctx, tx, order); err != nil {
+		return err
+	}
+	if err := releaseReservations(ctx, tx, order.Id, models.RESERVATION_CONVERTED); err != nil {
+		return err
+	}
+	stock := &models.InventoryMovement{Type: string(models.INVENTORY_SALE), ReferenceId: &order.Id, ActorId: actorId}
+	for _, orderRecord := range order.OrderRecords {
+		for _, movement := range allocatedMovements(stock, orderRecord, orderRecord.Quantity) {
+			movement.Quantity = -movement.Quantity
+			if err := moveStock(ctx, tx, movement); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// restockOrder puts the items of a cancelled order that were neither shipped nor restocked by a refund back
+// in the warehouses they were allocated to. It must run inside a transaction on the locked order.
+func restockOrder(ctx context.Context, tx *db.Database, order *models.Order, actorId *uuid.UUID) error {
+	stock := &models.InventoryMovement{Type: string(models.INVENTORY_CANCELLATION), ReferenceId: &order.Id, ActorId: actorId}
+	for _, orderRecord := range order.OrderRecords {
+		if err := restockUnshipped(ctx, tx, stock, orderRecord, orderRecord.Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restockUnshipped puts up to quantity units of an order record that were not shipped back in the warehouses
+// they were allocated to, leaving out those put back before so no unit is restocked twice. It must run inside
+// a transaction on the locked order.
+func restockUnshipped(ctx context.Context, tx *db.Database, stock *models.InventoryMovement, orderRecord *models.OrderRecord, quantity int64) error {
+	quantity = min(quantity, orderRecord.GetUnshippedQuantity())
+	if quantity <= 0 {
+		return nil
+	}
+	for _, movement := range allocatedMovements(stock, orderRecord, quantity) {
+		if err := moveStock(ctx, tx, movement); err != nil {
+			return err
+		}
+	}
+	if err := repo.NewOrderRecordRepo(tx).AddRestockedQuantity(ctx, orderRecord.Id, quantity); err != nil {
+		return err
+	}
+	orderRecord.RestockedQuantity += quantity
+	return nil
+}
+
+// allocatedMovements splits quantity of an order record over the warehouses it was allocated to, in the order
+// they were allocated. Records placed before warehouses were allocated move stock in the default warehouse.
+func allocatedMovements(stock *models.InventoryMovement, orderRecord *models.OrderRecord, quantity int64) []*models.InventoryMovement {
+	if len(orderRecord.Allocations) == 0 {
+		return []*models.InventoryMovement{stock.For(orderRecord.ProductId, orderRecord.VariantId, quantity)}
+	}
+	movements := []*models.InventoryMovement{}
+	for _, allocation := range orderRecord.Allocations {
+		if quantity <= 0 {
+			break
+		}
+		movement := stock.For(orderRecord.ProductId, orderRecord.VariantId, min(quantity, allocation.Quantity))
+		movement.WarehouseId = &allocation.WarehouseId
+		movements = append(movements, movement)
+		quantity -= movement.Quantity
+	}
+	return movements
+}
+
+// fulfilmentWarehouse gets the warehouse an order record was fulfilled from, items coming back go back to it.
+// It is empty for records placed before warehouses were allocated.
+func fulfilmentWarehouse(ctx context.Context, tx *db.Database, orderRecordId uuid.UUID) (*uuid.UUID, error) {
+	allocations, err := repo.NewOrderAllocationRepo(tx).GetOrderAllocationsByFields(ctx, helpers.Map{"order_record_id": orderRecordId})
+	if err != nil || len(allocations) == 0 {
+		return nil, err
+	}
+	return &allocations[0].WarehouseId, nil
+}
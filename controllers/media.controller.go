@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/images"
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+const DEFAULT_MEDIA_MAX_SIZE = 5 << 20
+
+// UploadProductMedia stores an image of a product with its thumbnails. The first image of a product becomes its primary one.
+func (c *Controller) UploadProductMedia(ctx context.Context, productId uuid.UUID, file io.Reader) *models.ResponseObject {
+	_, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": productId})
+	if err == messages.ErrProductNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	// read one byte more than allowed to tell a file of exactly the maximum size from a larger one
+	maxSize := c.mediaMaxSize()
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if len(data) == 0 {
+		return handleError(messages.ErrMediaFileRequired, "bad-request", http.StatusBadRequest)
+	}
+	if int64(len(data)) > maxSize {
+		return handleError(messages.ErrMediaTooLarge, "bad-request", http.StatusBadRequest)
+	}
+	contentType, err := images.DetectContentType(data)
+	if err != nil {
+		return handleError(messages.ErrUnsupportedMediaType, "bad-request", http.StatusBadRequest)
+	}
+	img, err := images.Decode(data, contentType)
+	if err == images.ErrTooManyPixels {
+		return handleError(messages.ErrImageTooLarge, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(messages.ErrInvalidImage, "bad-request", http.StatusBadRequest)
+	}
+
+	media := &models.ProductMedia{
+		Id:          uuid.New(),
+		ProductId:   productId,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       int64(img.Bounds().Dx()),
+		Height:      int64(img.Bounds().Dy()),
+		Thumbnails:  models.MediaThumbnails{},
+	}
+	media.Key = mediaKey(media, "original", images.Extensions[contentType])
+	if err := c.storage.Put(ctx, media.Key, data, contentType); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	for name, size := range images.THUMBNAIL_SIZES {
+		thumbnail, thumbnailType, err := images.Encode(images.Thumbnail(img, size), contentType)
+		if err == nil {
+			key := mediaKey(media, name, images.Extensions[thumbnailType])
+			if err = c.storage.Put(ctx, key, thumbnail, thumbnailType); err == nil {
+				media.Thumbnails[name] = key
+				continue
+			}
+		}
+		c.deleteMediaFiles(ctx, media)
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		// lock the product so concurrent uploads get distinct positions and only one becomes primary
+		if _, err := repo.NewProductRepo(tx).GetProductForUpdate(ctx, productId); err != nil {
+			return err
+		}
+		mediaRepo := repo.NewProductMediaRepo(tx)
+		existing, err := mediaRepo.GetMediaOfProduct(ctx, productId)
+		if err != nil {
+			return err
+		}
+		media.Position = int64(len(existing))
+		media.IsPrimary = len(existing) == 0
+		_, err = mediaRepo.CreateProductMedia(ctx, media)
+		return err
+	})
+	if err != nil {
+		c.deleteMediaFiles(ctx, media)
+		if err == messages.ErrProductNotFound {
+			return handleError(err, "bad-request", http.StatusBadRequest)
+		}
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	c.setMediaUrls(media)
+	return handleSuccess(media, "success", "product media uploaded successfully", http.StatusCreated)
+}
+
+// UpdateProductMedia moves an image of a product or makes it the primary one
+func (c *Controller) UpdateProductMedia(ctx context.Context, productId uuid.UUID, mediaId uuid.UUID, data *models.UpdateMediaDto) *models.ResponseObject {
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		if _, err := repo.NewProductRepo(tx).GetProductForUpdate(ctx, productId); err != nil {
+			return err
+		}
+		mediaRepo := repo.NewProductMediaRepo(tx)
+		media, err := mediaRepo.GetProductMediaByFields(ctx, helpers.Map{"id": mediaId, "product_id": productId})
+		if err != nil {
+			return err
+		}
+
+		update := helpers.Map{}
+		if data.Position != nil {
+			update["position"] = *data.Position
+		}
+		if data.IsPrimary != nil && *data.IsPrimary && !media.IsPrimary {
+			if err := mediaRepo.UnsetPrimaryMedia(ctx, productId); err != nil {
+				return err
+			}
+			update["is_primary"] = true
+		}
+		if len(update) == 0 {
+			return nil
+		}
+		return mediaRepo.UpdateProductMediaById(ctx, media.Id, update)
+	})
+	if err == messages.ErrProductNotFound || err == messages.ErrMediaNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "product media updated successfully", http.StatusOK)
+}
+
+// DeleteProductMedia deletes an image of a product and its files, the next image becomes primary when it was the primary one
+func (c *Controller) DeleteProductMedia(ctx context.Context, productId uuid.UUID, mediaId uuid.UUID) *models.ResponseObject {
+	var media *models.ProductMedia
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		if _, err := repo.NewProductRepo(tx).GetProductForUpdate(ctx, productId); err != nil {
+			return err
+		}
+		mediaRepo := repo.NewProductMediaRepo(tx)
+		var err error
+		media, err = mediaRepo.GetProductMediaByFields(ctx, helpers.Map{"id": mediaId, "product_id": productId})
+		if err != nil {
+			return err
+		}
+		if err := mediaRepo.DeleteProductMedia(ctx, media.Id); err != nil {
+			return err
+		}
+		if !media.IsPrimary {
+			return nil
+		}
+
+		remaining, err := mediaRepo.GetMediaOfProduct(ctx, productId)
+		if err != nil || len(remaining) == 0 {
+			return err
+		}
+		return mediaRepo.UpdateProductMediaById(ctx, remaining[0].Id, helpers.Map{"is_primary": true})
+	})
+	if err == messages.ErrProductNotFound || err == messages.ErrMediaNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	// files are only removed once the image is gone from the database, a failure leaves orphan files behind, not broken links
+	c.deleteMediaFiles(ctx, media)
+	return handleSuccess(nil, "success", "product media deleted successfully", http.StatusOK)
+}
+
+// setMediaUrls sets the links to an image and its thumbnails from the storage
+func (c *Controller) setMediaUrls(media ...*models.ProductMedia) {
+	for _, m := range media {
+		m.Url = c.storage.URL(m.Key)
+		m.ThumbnailUrls = map[string]string{}
+		for name, key := range m.Thumbnails {
+			m.ThumbnailUrls[name] = c.storage.URL(key)
+		}
+	}
+}
+
+// setProductMediaUrls sets the media links of products
+func (c *Controller) setProductMediaUrls(products ...*models.Product) {
+	for _, product := range products {
+		c.setMediaUrls(product.Media...)
+	}
+}
+
+// deleteMediaFiles removes an image and its thumbnails from the storage, failures are only logged
+func (c *Controller) deleteMediaFiles(ctx context.Context, media ...*models.ProductMedia) {
+	for _, m := range media {
+		for _, key := range m.GetKeys() {
+			if key == "" {
+				continue
+			}
+			if err := c.storage.Delete(ctx, key); err != nil {
+				log.Err(err).Msgf("deleteMediaFiles: could not delete %s from %s storage: %v", key, c.storage.Name(), err)
+			}
+		}
+	}
+}
+
+// mediaMaxSize is the largest file, in bytes, that can be uploaded
+func (c *Controller) mediaMaxSize() int64 {
+	size, err := strconv.ParseInt(c.Config.MediaMaxSize, 10, 64)
+	if err != nil || size <= 0 {
+		return DEFAULT_MEDIA_MAX_SIZE
+	}
+	return size
+}
+
+// mediaKey is the storage key of a file of an image, all files of an image share a folder
+func mediaKey(media *models.ProductMedia, name string, extension string) string {
+	return fmt.Sprintf("products/%s/%s/%s.%s", media.ProductId, media.Id, name, extension)
+}
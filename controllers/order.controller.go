@@ -4,23 +4,70 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 
 	"e-commerce/common/messages"
+	"e-commerce/db"
 	"e-commerce/helpers"
 	"e-commerce/models"
+	"e-commerce/repo"
 )
 
+// orderQuote is an order priced from a PlaceOrderDto, ready to be stored
+type orderQuote struct {
+	order    *models.Order
+	products map[uuid.UUID]*models.Product
+	coupon   *models.Coupon
+
+	// order records sold at a discount in the order currency
+	discounted map[uuid.UUID]bool
+	// order records with promotion discounts
+	promoted map[uuid.UUID]bool
+}
+
 // place order
 func (c *Controller) PlaceOrder(ctx context.Context, data *models.PlaceOrderDto, user *models.User) *models.ResponseObject {
-	order := models.Order{
+	return c.placeOrder(ctx, data, user, nil)
+}
+
+// placeOrder prices, allocates and stores an order, checking out the cart it was placed from, if any,
+// in the same transaction
+func (c *Controller) placeOrder(ctx context.Context, data *models.PlaceOrderDto, user *models.User, cartId *uuid.UUID) *models.ResponseObject {
+	quote, err := c.priceOrder(ctx, data, user)
+	if err != nil {
+		return handleOrderError(err)
+	}
+	if err := c.allocateOrder(ctx, quote.order); err != nil {
+		return handleOrderError(err)
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		// the cart row stays locked until the order is stored, so it cannot be checked out twice
+		if cartId != nil {
+			err := repo.NewCartRepo(tx).MoveCart(ctx, *cartId, string(models.CART_ACTIVE), string(models.CART_CHECKED_OUT))
+			if err != nil {
+				return err
+			}
+		}
+		return c.storeOrder(ctx, tx, quote)
+	})
+	if err != nil {
+		return handleOrderError(err)
+	}
+
+	return handleSuccess(quote.order, "success", "order created successfully", http.StatusCreated)
+}
+
+// priceOrder builds an order and its records from the order data without storing anything
+func (c *Controller) priceOrder(ctx context.Context, data *models.PlaceOrderDto, user *models.User) (*orderQuote, error) {
+	order := &models.Order{
 		Id:           uuid.New(),
 		UserId:       user.Id,
 		TrackingCode: helpers.GenerateUniqueReferenceId(12),
 		Status:       string(models.PENDING),
-		Fee:          models.ORDER_FEE,
 		Currency:     string(data.Currency),
 		History: models.OrderHistoryData{
 			Data: []models.OrderHistory{
@@ -32,84 +79,330 @@ func (c *Controller) PlaceOrder(ctx context.Context, data *models.PlaceOrderDto,
 			},
 		},
 	}
+	if err := c.setOrderAddresses(ctx, order, data, user); err != nil {
+		return nil, err
+	}
+	quote := &orderQuote{
+		order:      order,
+		products:   map[uuid.UUID]*models.Product{},
+		discounted: map[uuid.UUID]bool{},
+		promoted:   map[uuid.UUID]bool{},
+	}
 
-	var orderRecords []*models.OrderRecord
 	for _, orderData := range data.Data {
-		id, _ := uuid.Parse(orderData.ProductId)
-		product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": id})
-		if err != nil && err != messages.ErrProductNotFound {
-			return handleError(err, "server-error", http.StatusInternalServerError)
+		product, variant, err := c.orderProduct(ctx, orderData)
+		if err != nil {
+			return nil, err
 		}
+		quote.products[product.Id] = product
 
-		if product == nil {
-			return handleError(messages.ErrProductNotFound, "bad-request", http.StatusBadRequest)
+		price, discount, err := c.productPrice(ctx, order, product.ForVariant(variant))
+		if err != nil {
+			return nil, err
 		}
-
-		amount := product.Price - product.Discount
-		orderRecord := models.OrderRecord{
+		amount, err := price.Sub(discount)
+		if err == messages.ErrNegativeMoney {
+			return nil, messages.ErrDiscountExceedsPrice
+		}
+		if err != nil {
+			return nil, err
+		}
+		orderRecord := &models.OrderRecord{
+			Id:        uuid.New(),
 			ProductId: product.Id,
 			Quantity:  orderData.Quantity,
 			Amount:    amount,
+			Currency:  order.Currency,
 			OrderId:   order.Id,
 		}
-		// create order record
-		newOrderRecord, err := c.orderRecordRepo.CreateOrderRecord(ctx, &orderRecord)
+		if variant != nil {
+			orderRecord.VariantId = &variant.Id
+			orderRecord.Sku = variant.Sku
+		}
+		if discount.IsPositive() {
+			quote.discounted[orderRecord.Id] = true
+		}
+		order.OrderRecords = append(order.OrderRecords, orderRecord)
+	}
+
+	if err := c.applyPromotions(ctx, quote); err != nil {
+		return nil, err
+	}
+
+	if data.CouponCode != "" {
+		coupon, discount, err := c.applyCoupon(ctx, data.CouponCode, user, quote)
 		if err != nil {
-			return handleError(err, "server-error", http.StatusInternalServerError)
+			return nil, err
 		}
-		orderRecords = append(orderRecords, newOrderRecord)
+		quote.coupon = coupon
+		order.CouponCode = coupon.Code
+		order.Discounts = append(order.Discounts, &models.OrderDiscount{
+			Id:          uuid.New(),
+			OrderId:     order.Id,
+			CouponId:    &coupon.Id,
+			Description: coupon.Code,
+			Amount:      discount,
+			Currency:    order.Currency,
+		})
 	}
 
+	if err := c.applyTaxes(ctx, quote, user); err != nil {
+		return nil, err
+	}
+
+	if err := c.setOrderShipping(ctx, quote, data.ShippingMethodId); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if order.Discount, err = order.GetDiscountTotal(); err != nil {
+		return nil, err
+	}
+	if order.TotalAmount, err = order.GetTotalAmount(); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// setOrderAddresses keeps a copy of the shipping and billing addresses on the order and
+// taxes it where it is shipped to
+func (c *Controller) setOrderAddresses(ctx context.Context, order *models.Order, data *models.PlaceOrderDto, user *models.User) error {
+	shippingAddress, err := c.orderAddress(ctx, data.ShippingAddressId, "is_default_shipping", user)
+	if err != nil || shippingAddress == nil {
+		return err
+	}
+	billingAddress, err := c.orderAddress(ctx, data.BillingAddressId, "is_default_billing", user)
+	if err != nil {
+		return err
+	}
+	if billingAddress == nil {
+		billingAddress = shippingAddress
+	}
+
+	order.ShippingAddress = shippingAddress.Snapshot()
+	order.BillingAddress = billingAddress.Snapshot()
+	order.Country = shippingAddress.Country
+	order.Region = shippingAddress.Region
+	return nil
+}
+
+// storeOrder creates a priced order with its records, discount and tax lines and warehouse allocations,
+// reserves its stock and redeems its coupon
+func (c *Controller) storeOrder(ctx context.Context, tx *db.Database, quote *orderQuote) error {
+	orderRepo := repo.NewOrderRepo(tx)
+	orderRecordRepo := repo.NewOrderRecordRepo(tx)
+	orderDiscountRepo := repo.NewOrderDiscountRepo(tx)
+	orderTaxRepo := repo.NewOrderTaxRepo(tx)
+	orderAllocationRepo := repo.NewOrderAllocationRepo(tx)
+
+	order := quote.order
+	orderRecords, discounts := order.OrderRecords, order.Discounts
+	order.OrderRecords, order.Discounts = nil, nil
+
 	// create order
-	newOrder, err := c.orderRepo.CreateOrder(ctx, &order)
+	if _, err := orderRepo.CreateOrder(ctx, order); err != nil {
+		return err
+	}
+	// create order records
+	for _, orderRecord := range orderRecords {
+		taxLines, allocations := orderRecord.TaxLines, orderRecord.Allocations
+		orderRecord.TaxLines, orderRecord.Allocations = nil, nil
+		if _, err := orderRecordRepo.CreateOrderRecord(ctx, orderRecord); err != nil {
+			return err
+		}
+		// create tax lines
+		for _, taxLine := range taxLines {
+			if _, err := orderTaxRepo.CreateOrderTax(ctx, taxLine); err != nil {
+				return err
+			}
+		}
+		orderRecord.TaxLines = taxLines
+		// create warehouse allocations
+		if len(allocations) > 0 {
+			if err := orderAllocationRepo.CreateOrderAllocations(ctx, allocations); err != nil {
+				return err
+			}
+		}
+		orderRecord.Allocations = allocations
+	}
+	order.OrderRecords = orderRecords
+	// hold the allocated stock until the order is paid for
+	if err := c.reserveOrder(ctx, tx, order); err != nil {
+		return err
+	}
+	// create discount lines
+	for _, discount := range discounts {
+		if _, err := orderDiscountRepo.CreateOrderDiscount(ctx, discount); err != nil {
+			return err
+		}
+	}
+	order.Discounts = discounts
+
+	if quote.coupon != nil {
+		return redeemCoupon(ctx, repo.NewCouponRepo(tx), repo.NewCouponRedemptionRepo(tx), quote.coupon, order)
+	}
+	return nil
+}
+
+// isDiscounted checks if an order record already carries a product or promotion discount
+func (q *orderQuote) isDiscounted(orderRecord *models.OrderRecord) bool {
+	if q.promoted[orderRecord.Id] {
+		return true
+	}
+	return q.discounted[orderRecord.Id]
+}
+
+// orderProduct gets the product of an order line and the variant bought, for products sold through variants
+func (c *Controller) orderProduct(ctx context.Context, orderData models.PlaceOrder) (*models.Product, *models.ProductVariant, error) {
+	var variantId *uuid.UUID
+	if orderData.VariantId != "" {
+		id, _ := uuid.Parse(orderData.VariantId)
+		variantId = &id
+	}
+
+	productId, _ := uuid.Parse(orderData.ProductId)
+	if orderData.ProductId == "" {
+		variant, err := c.productVariantRepo.GetProductVariantByFields(ctx, helpers.Map{"id": *variantId})
+		if err != nil {
+			return nil, nil, err
+		}
+		productId = variant.ProductId
+	}
+
+	product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": productId})
 	if err != nil {
-		return handleError(err, "server-error", http.StatusInternalServerError)
+		return nil, nil, err
 	}
+	variant, err := productVariant(product, variantId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return product, variant, nil
+}
 
-	newOrder.OrderRecords = orderRecords
-	newOrder.TotalAmount = newOrder.GetTotalAmount()
+// productPrice gets the price and discount of a product in the order currency, from its price
+// in that currency or else converted from its own price
+func (c *Controller) productPrice(ctx context.Context, order *models.Order, product *models.Product) (models.Money, models.Money, error) {
+	if price, discount, ok := product.PriceIn(order.Currency); ok {
+		return price, discount, nil
+	}
 
-	return handleSuccess(newOrder, "success", "order created successfully", http.StatusCreated)
+	price, err := c.convert(ctx, order, product.Price)
+	if err != nil {
+		return models.Money{}, models.Money{}, err
+	}
+	discount, err := c.convert(ctx, order, product.Discount)
+	if err != nil {
+		return models.Money{}, models.Money{}, err
+	}
+	return price, discount, nil
+}
+
+func handleOrderError(err error) *models.ResponseObject {
+	switch err {
+	case messages.ErrProductNotFound,
+		messages.ErrProductVariantNotFound,
+		messages.ErrVariantRequired,
+		messages.ErrAddressNotFound,
+		messages.ErrNoShippingMethod,
+		messages.ErrShippingMethodNotAvailable,
+		messages.ErrExchangeRateNotFound,
+		messages.ErrDiscountExceedsPrice,
+		messages.ErrCouponNotFound,
+		messages.ErrCouponNotActive,
+		messages.ErrCouponUsageLimitReached,
+		messages.ErrCouponUserLimitReached,
+		messages.ErrCouponMinOrderAmount,
+		messages.ErrCouponNotApplicable,
+		messages.ErrInsufficientStock,
+		messages.ErrCartNotFound:
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	return handleError(err, "server-error", http.StatusInternalServerError)
+}
+
+// PriceOrder prices an order with its promotions and coupon without creating anything
+func (c *Controller) PriceOrder(ctx context.Context, data *models.PlaceOrderDto, user *models.User) *models.ResponseObject {
+	quote, err := c.priceOrder(ctx, data, user)
+	if err != nil {
+		return handleOrderError(err)
+	}
+	return handleSuccess(quote.order, "success", "order priced successfully", http.StatusOK)
 }
 
 // get all orders
 func (c *Controller) GetAllOrders(ctx context.Context, user *models.User, query *models.APIPagingDto) *models.ResponseObject {
 	response, err := c.orderRepo.GetAllOrders(ctx, query, helpers.Map{"user_id": user.Id})
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
 	if err != nil {
 		return handleError(err, "server-error", http.StatusInternalServerError)
 	}
 	return handleSuccess(response, "success", "orders successfully fetched", http.StatusOK)
 }
 
-// get single order
-func (c *Controller) GetSingleOrder(ctx context.Context, orderId uuid.UUID) *models.ResponseObject {
-	order, err := c.orderRepo.GetOrderByFields(ctx, helpers.Map{"id": orderId})
+// get single order, users only get their own orders while admins get any order
+func (c *Controller) GetSingleOrder(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject {
+	fields := helpers.Map{"id": orderId}
+	if user.Role != string(models.USER_ROLE_ADMIN) {
+		fields["user_id"] = user.Id
+	}
+	order, err := c.orderRepo.GetOrderByFields(ctx, fields)
+	if err == messages.ErrOrderNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
 	if err != nil {
 		return handleError(err, "server-error", http.StatusInternalServerError)
 	}
-	order.TotalAmount = order.GetTotalAmount()
+	if order.TotalAmount, err = order.GetTotalAmount(); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
 	return handleSuccess(order, "success", "order successfully fetched", http.StatusOK)
 }
 
 // cancel order
 func (c *Controller) CancelOrder(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject {
-	order, err := c.orderRepo.GetOrderByFields(ctx, helpers.Map{"id": orderId, "user_id": user.Id})
-	if err != nil {
-		return handleError(err, "server-error", http.StatusInternalServerError)
-	}
-	if order.Status != string(models.PENDING) {
-		return handleError(messages.ErrOrderCannotBeCancelled, "bad-request", http.StatusBadRequest)
-	}
-	history := models.OrderHistory{
-		Note:      "order cancelled",
-		Status:    string(models.CANCELLED),
-		CreatedAt: time.Now().UTC(),
-	}
-	order.History.Data = append(order.History.Data, history)
-	err = c.orderRepo.UpdateOrderById(ctx, orderId, &models.Order{
-		Status:  string(models.CANCELLED),
-		History: order.History,
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		orderRepo := repo.NewOrderRepo(tx)
+		// the order is locked so a payment being settled cannot sell it while it is cancelled
+		order, err := orderRepo.GetOrderForUpdate(ctx, helpers.Map{"id": orderId, "user_id": user.Id})
+		if err != nil {
+			return err
+		}
+		// customers can only cancel orders that are still waiting for payment
+		if order.Status != string(models.PENDING) {
+			return messages.ErrOrderCannotBeCancelled
+		}
+		// an order paid for while it waits for stock is cancelled by an admin, who refunds the payment
+		_, err = repo.NewPaymentRepo(tx).GetPaymentByFields(ctx, helpers.Map{"order_id": order.Id, "status": string(models.PAYMENT_SUCCESS)})
+		if err == nil {
+			return messages.ErrOrderCannotBeCancelled
+		}
+		if err != messages.ErrPaymentNotFound {
+			return err
+		}
+
+		order.History.Data = append(order.History.Data, models.OrderHistory{
+			Note:      "order cancelled",
+			Status:    string(models.CANCELLED),
+			CreatedAt: time.Now().UTC(),
+		})
+		err = orderRepo.UpdateOrderById(ctx, orderId, &models.Order{
+			Status:  string(models.CANCELLED),
+			History: order.History,
+		})
+		if err != nil {
+			return err
+		}
+		if err := releaseReservations(ctx, tx, orderId, models.RESERVATION_RELEASED); err != nil {
+			return err
+		}
+		return c.releaseCoupon(ctx, tx, orderId)
 	})
+	if err == messages.ErrOrderNotFound || err == messages.ErrOrderCannotBeCancelled {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
 	if err != nil {
 		return handleError(err, "server-error", http.StatusInternalServerError)
 	}
@@ -119,25 +412,75 @@ func (c *Controller) CancelOrder(ctx context.Context, orderId uuid.UUID, user *m
 
 // update order status
 func (c *Controller) UpdateOrderStatus(ctx context.Context, orderId uuid.UUID, data *models.UpdateOrderStatusDto, user *models.User) *models.ResponseObject {
-	order, err := c.orderRepo.GetOrderByFields(ctx, helpers.Map{"id": orderId})
-	if err != nil {
-		return handleError(err, "server-error", http.StatusInternalServerError)
+	// refund states follow the refunds issued on the order
+	if data.Status == models.REFUNDED || data.Status == models.PARTIALLY_REFUNDED {
+		return handleError(messages.ErrInvalidOrderStatus, "bad-request", http.StatusBadRequest)
 	}
 
-	if order.Status == string(models.CANCELLED) {
-		return handleError(messages.ErrOrderCannotBeCancelled, "bad-request", http.StatusBadRequest)
-	}
-	history := models.OrderHistory{
-		Note:      fmt.Sprintf("order %s", string(data.Status)),
-		Status:    string(data.Status),
-		CreatedAt: time.Now().UTC(),
-	}
-	order.History.Data = append(order.History.Data, history)
-	err = c.orderRepo.UpdateOrderById(ctx, orderId, &models.Order{
-		Status:  string(data.Status),
-		History: order.History,
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		orderRepo := repo.NewOrderRepo(tx)
+		// the order is locked so a payment being settled or another update cannot move it at the same time
+		order, err := orderRepo.GetOrderForUpdate(ctx, helpers.Map{"id": orderId})
+		if err != nil {
+			return err
+		}
+		if order.Status == string(models.CANCELLED) {
+			return messages.ErrOrderCannotBeCancelled
+		}
+		if !models.OrderStatus(order.Status).CanMoveTo(data.Status) {
+			return messages.ErrInvalidOrderStatus
+		}
+
+		// orders only move on from pending once they have been paid for
+		sold := order.Status == string(models.PENDING) && slices.Contains(models.PAID_ORDER_STATUSES, string(data.Status))
+		if sold {
+			_, err := repo.NewPaymentRepo(tx).GetPaymentByFields(ctx, helpers.Map{"order_id": order.Id, "status": string(models.PAYMENT_SUCCESS)})
+			if err == messages.ErrPaymentNotFound {
+				return messages.ErrOrderNotPaid
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		order.History.Data = append(order.History.Data, models.OrderHistory{
+			Note:      fmt.Sprintf("order %s", string(data.Status)),
+			Status:    string(data.Status),
+			CreatedAt: time.Now().UTC(),
+		})
+		err = orderRepo.UpdateOrderById(ctx, orderId, &models.Order{
+			Status:  string(data.Status),
+			History: order.History,
+		})
+		if err != nil {
+			return err
+		}
+		// stock is taken out once an order is paid for and put back when it is cancelled before shipping
+		if sold {
+			return sellOrder(ctx, tx, order, &user.Id)
+		}
+		if data.Status != models.CANCELLED {
+			return nil
+		}
+		if err := releaseReservations(ctx, tx, orderId, models.RESERVATION_RELEASED); err != nil {
+			return err
+		}
+		if order.Status == string(models.PROCESSING) {
+			if err := restockOrder(ctx, tx, order, &user.Id); err != nil {
+				return err
+			}
+		}
+		return c.releaseCoupon(ctx, tx, orderId)
 	})
 	if err != nil {
+		switch err {
+		case messages.ErrOrderNotFound,
+			messages.ErrOrderCannotBeCancelled,
+			messages.ErrInvalidOrderStatus,
+			messages.ErrOrderNotPaid,
+			messages.ErrInsufficientStock:
+			return handleError(err, "bad-request", http.StatusBadRequest)
+		}
 		return handleError(err, "server-error", http.StatusInternalServerError)
 	}
 	return handleSuccess(nil, "success", "order successfully updated", http.StatusOK)
@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/common/payments"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+const PAYMENT_REFERENCE_LENGTH = 16
+
+// InitializePayment starts a payment for a pending order and returns where to pay
+func (c *Controller) InitializePayment(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject {
+	order, err := c.orderRepo.GetOrderByFields(ctx, helpers.Map{"id": orderId, "user_id": user.Id})
+	if err == messages.ErrOrderNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if order.Status != string(models.PENDING) {
+		return handleError(messages.ErrOrderCannotBePaid, "bad-request", http.StatusBadRequest)
+	}
+
+	// reuse a payment that was started but never completed
+	existingPayment, err := c.paymentRepo.GetPaymentByFields(ctx, helpers.Map{"order_id": order.Id, "status": string(models.PAYMENT_PENDING)})
+	if err != nil && err != messages.ErrPaymentNotFound {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if existingPayment != nil && existingPayment.Provider == c.paymentProvider.Name() {
+		return handleSuccess(existingPayment, "success", "payment initialized successfully", http.StatusOK)
+	}
+
+	amountPayable, err := order.GetAmountPayable()
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	payment := &models.Payment{
+		Id:        uuid.New(),
+		OrderId:   order.Id,
+		UserId:    user.Id,
+		Provider:  c.paymentProvider.Name(),
+		Reference: helpers.GenerateUniqueReferenceId(PAYMENT_REFERENCE_LENGTH),
+		Amount:    amountPayable.Amount,
+		Currency:  order.Currency,
+		Status:    string(models.PAYMENT_PENDING),
+	}
+	initialized, err := c.paymentProvider.Initialize(ctx, &payments.InitializeRequest{
+		Reference: payment.Reference,
+		Amount:    payment.Amount,
+		Currency:  payment.Currency,
+		Email:     user.Email,
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	payment.ProviderReference = initialized.ProviderReference
+	payment.AuthorizationUrl = initialized.AuthorizationUrl
+
+	newPayment, err := c.paymentRepo.CreatePayment(ctx, payment)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(newPayment, "success", "payment initialized successfully", http.StatusCreated)
+}
+
+// VerifyPayment checks a payment with its provider and settles the order accordingly
+func (c *Controller) VerifyPayment(ctx context.Context, reference string, user *models.User) *models.ResponseObject {
+	fields := helpers.Map{"reference": reference}
+	if user.Role != string(models.USER_ROLE_ADMIN) {
+		fields["user_id"] = user.Id
+	}
+	payment, err := c.paymentRepo.GetPaymentByFields(ctx, fields)
+	if err == messages.ErrPaymentNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if payment.Status != string(models.PAYMENT_PENDING) {
+		return handleSuccess(payment, "success", "payment verified successfully", http.StatusOK)
+	}
+	if payment.Provider != c.paymentProvider.Name() {
+		return handleError(messages.ErrPaymentProviderNotSupported, "bad-request", http.StatusBadRequest)
+	}
+
+	verified, err := c.paymentProvider.Verify(ctx, payment.ProviderReference)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		payment, err = c.settlePayment(ctx, tx, payment.Id, verified)
+		return err
+	})
+	if err == messages.ErrPaymentAmountMismatch {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(payment, "success", "payment verified successfully", http.StatusOK)
+}
+
+// CompleteFakePayment stands in for the provider's payment page when using the fake provider
+func (c *Controller) CompleteFakePayment(ctx context.Context, providerReference string, status payments.ChargeStatus) *models.ResponseObject {
+	fakeProvider, ok := c.paymentProvider.(*payments.FakeProvider)
+	if !ok {
+		return handleError(messages.ErrPaymentProviderNotSupported, "bad-request", http.StatusBadRequest)
+	}
+	if status != payments.CHARGE_FAILED {
+		status = payments.CHARGE_SUCCESS
+	}
+	if err := fakeProvider.Complete(providerReference, status); err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+
+	payment, err := c.paymentRepo.GetPaymentByFields(ctx, helpers.Map{"provider_reference": providerReference})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	verified, err := c.paymentProvider.Verify(ctx, providerReference)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		payment, err = c.settlePayment(ctx, tx, payment.Id, verified)
+		return err
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(payment, "success", fmt.Sprintf("payment %s", payment.Status), http.StatusOK)
+}
+
+// settlePayment records the provider's outcome on a pending payment and moves a paid order to processing,
+// or leaves it pending when its stock is gone. It must run inside a transaction; payments that are no longer
+// pending are returned untouched.
+func (c *Controller) settlePayment(ctx context.Context, tx *db.Database, paymentId uuid.UUID, verified *payments.VerifyResponse) (*models.Payment, error) {
+	paymentRepo := repo.NewPaymentRepo(tx)
+	orderRepo := repo.NewOrderRepo(tx)
+
+	payment, err := paymentRepo.GetPaymentForUpdate(ctx, helpers.Map{"id": paymentId})
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != string(models.PAYMENT_PENDING) || verified.Status == payments.CHARGE_PENDING {
+		return payment, nil
+	}
+
+	// the order is locked so it is not moved by an admin while the payment is settled
+	order, err := orderRepo.GetOrderForUpdate(ctx, helpers.Map{"id": payment.OrderId})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	update := &models.Order{History: order.History}
+	if verified.Status == payments.CHARGE_SUCCESS {
+		if verified.Amount != payment.Amount || verified.Currency != payment.Currency {
+			return nil, messages.ErrPaymentAmountMismatch
+		}
+		payment.Status = string(models.PAYMENT_SUCCESS)
+		payment.PaidAt = &now
+
+		// the order may have been cancelled, or paid for by another payment, in the meantime
+		refundDue := order.Status != string(models.PENDING)
+		if !refundDue {
+			_, err := paymentRepo.GetPaymentByFields(ctx, helpers.Map{"order_id": order.Id, "status": []string{string(models.PAYMENT_SUCCESS), string(models.PAYMENT_PARTIALLY_REFUNDED)}})
+			if err != nil && err != messages.ErrPaymentNotFound {
+				return nil, err
+			}
+			refundDue = err == nil
+		}
+
+		status, note := order.Status, "payment confirmed"
+		if refundDue {
+			payment.Status = string(models.PAYMENT_REFUND_DUE)
+			note = fmt.Sprintf("payment %s received on a %s order that needs no payment, refund due", payment.Reference, order.Status)
+			log.Warn().Msgf("settlePayment: payment %s received on %s order %s that needs no payment, refund due", payment.Reference, order.Status, order.Id)
+		} else {
+			// sold in a savepoint, the order waits for stock when its reservations expired and the stock was sold since
+			err := tx.Transaction(ctx, func(tx *db.Database) error {
+				return sellOrder(ctx, tx, order, nil)
+			})
+			switch err {
+			case nil:
+				status = string(models.PROCESSING)
+				update.Status = status
+			case messages.ErrInsufficientStock:
+				note = "payment confirmed, waiting for stock"
+			default:
+				return nil, err
+			}
+		}
+		update.History.Data = append(update.History.Data, models.OrderHistory{
+			Note:      note,
+			Status:    status,
+			CreatedAt: now,
+		})
+	} else {
+		payment.Status = string(models.PAYMENT_FAILED)
+		update.History.Data = append(update.History.Data, models.OrderHistory{
+			Note:      "payment failed",
+			Status:    order.Status,
+			CreatedAt: now,
+		})
+	}
+
+	if err := paymentRepo.UpdatePaymentById(ctx, payment.Id, &models.Payment{Status: payment.Status, PaidAt: payment.PaidAt}); err != nil {
+		return nil, err
+	}
+	if err := orderRepo.UpdateOrderById(ctx, order.Id, update); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
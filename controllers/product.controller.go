@@ -3,17 +3,24 @@ package controllers
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"e-commerce/common/messages"
+	"e-commerce/db"
 	"e-commerce/helpers"
 	"e-commerce/models"
+	"e-commerce/repo"
 
 	"github.com/google/uuid"
 )
 
 // CreateProduct creates a new product
 func (c *Controller) CreateProduct(ctx context.Context, data *models.CreateProductDto, user *models.User) *models.ResponseObject {
-	slug := helpers.ToSlug(data.Name)
+	return c.createProduct(ctx, data, helpers.ToSlug(data.Name), &models.InventoryMovement{Type: string(models.INVENTORY_INITIAL), ActorId: &user.Id})
+}
+
+// createProduct creates a new product under slug, recording its stock and that of its variants as stock movements
+func (c *Controller) createProduct(ctx context.Context, data *models.CreateProductDto, slug string, stock *models.InventoryMovement) *models.ResponseObject {
 	existingProduct, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"slug": slug})
 	if err != nil && err != messages.ErrProductNotFound {
 		return handleError(err, "server-error", http.StatusInternalServerError)
@@ -22,17 +29,77 @@ func (c *Controller) CreateProduct(ctx context.Context, data *models.CreateProdu
 		return handleError(messages.ErrProductWithNameAlreadyExists, "bad-request", http.StatusBadRequest)
 	}
 
+	if data.Discount > data.Price {
+		return handleError(messages.ErrDiscountExceedsPrice, "bad-request", http.StatusBadRequest)
+	}
+	prices, err := toProductPrices(data.Prices, string(data.Currency))
+	if err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	categoryIds, err := c.toCategoryIds(ctx, data.CategoryIds)
+	if err == messages.ErrCategoryNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	attributes, err := c.toProductAttributes(ctx, categoryIds, data.Attributes)
+	if err != nil {
+		return handleAttributeError(err)
+	}
+
+	taxClass := models.TAX_CLASS_STANDARD
+	if data.TaxClass != "" {
+		taxClass = data.TaxClass
+	}
+	returnWindowDays := int64(models.DEFAULT_RETURN_WINDOW_DAYS)
+	if data.ReturnWindowDays != nil {
+		returnWindowDays = *data.ReturnWindowDays
+	}
+
 	newProduct := &models.Product{
+		Id:                uuid.New(),
 		Name:              data.Name,
 		Description:       data.Description,
+		Brand:             strings.TrimSpace(data.Brand),
 		Slug:              slug,
-		Price:             data.Price,
+		Price:             models.NewMoney(data.Price, data.Currency),
 		Currency:          string(data.Currency),
 		AvailableQuantity: data.Quantity,
-		Discount:          data.Discount,
+		Discount:          models.NewMoney(data.Discount, data.Currency),
+		TaxClass:          string(taxClass),
+		Weight:            data.Weight,
+		Length:            data.Length,
+		Width:             data.Width,
+		Height:            data.Height,
+		ReturnWindowDays:  returnWindowDays,
+		ReorderThreshold:  data.ReorderThreshold,
+		Attributes:        attributes,
 		Status:            string(models.IN_STOCK),
+		Prices:            prices,
+	}
+	if newProduct.Options, err = toProductOptions(data.Options); err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
 	}
-	product, err := c.productRepo.CreateProduct(ctx, newProduct)
+	if newProduct.Variants, err = c.toProductVariants(ctx, data.Variants, newProduct); err != nil {
+		return handleVariantError(err)
+	}
+	var product *models.Product
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		var err error
+		if product, err = repo.NewProductRepo(tx).CreateProduct(ctx, newProduct); err != nil {
+			return err
+		}
+		if err := recordStock(ctx, tx, stock, product.Id, nil, product.AvailableQuantity); err != nil {
+			return err
+		}
+		for _, variant := range product.Variants {
+			if err := recordStock(ctx, tx, stock, product.Id, &variant.Id, variant.AvailableQuantity); err != nil {
+				return err
+			}
+		}
+		return repo.NewProductCategoryRepo(tx).ReplaceProductCategories(ctx, product.Id, categoryIds)
+	})
 	if err != nil {
 		return handleError(err, "server-error", http.StatusInternalServerError)
 	}
@@ -45,19 +112,32 @@ func (c *Controller) GetSingleProduct(ctx context.Context, productId uuid.UUID)
 	if err != nil {
 		return handleError(err, "server-error", http.StatusInternalServerError)
 	}
+	c.setProductMediaUrls(product)
 	return handleSuccess(product, "success", "product fetched successfully", http.StatusOK)
 }
 
-func (c *Controller) UpdateProduct(ctx context.Context, data *models.UpdateProductDto, productId uuid.UUID) *models.ResponseObject {
+func (c *Controller) UpdateProduct(ctx context.Context, data *models.UpdateProductDto, productId uuid.UUID, user *models.User) *models.ResponseObject {
+	return c.updateProduct(ctx, data, productId, false, &models.InventoryMovement{Type: string(models.INVENTORY_ADJUSTMENT), Reason: data.QuantityReason, ActorId: &user.Id})
+}
+
+// updateProduct updates a product, a new quantity is recorded as a stock movement. A new name gives the
+// product the slug of the name unless keepSlug is set.
+func (c *Controller) updateProduct(ctx context.Context, data *models.UpdateProductDto, productId uuid.UUID, keepSlug bool, stock *models.InventoryMovement) *models.ResponseObject {
+	product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": productId})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
 	var update models.Product
 	if data.Name != nil {
+		update.Name = *data.Name
+	}
+	if data.Name != nil && !keepSlug {
 		slug := helpers.ToSlug(*data.Name)
 		_, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"slug": slug})
 		if err != nil && err != messages.ErrProductNotFound {
 			return handleError(err, "server-error", http.StatusInternalServerError)
 		}
-
-		update.Name = *data.Name
 		update.Slug = slug
 	}
 
@@ -65,42 +145,254 @@ func (c *Controller) UpdateProduct(ctx context.Context, data *models.UpdateProdu
 		update.Description = *data.Description
 	}
 
-	if data.Quantity != nil {
-		update.AvailableQuantity = *data.Quantity
-	}
-
+	price, discount := product.Price, product.Discount
 	if data.Price != nil {
-		update.Price = *data.Price
+		price.Amount = *data.Price
+		update.Price = price
 	}
 
 	if data.Discount != nil {
-		update.Discount = *data.Discount
+		discount.Amount = *data.Discount
+		update.Discount = discount
+	}
+
+	if discount.Amount > price.Amount {
+		return handleError(messages.ErrDiscountExceedsPrice, "bad-request", http.StatusBadRequest)
 	}
 
 	if data.Status != nil {
 		update.Status = string(*data.Status)
 	}
 
-	err := c.productRepo.UpdateProductById(ctx, productId, &update)
+	if data.TaxClass != nil {
+		update.TaxClass = string(*data.TaxClass)
+	}
+
+	if data.Weight != nil {
+		update.Weight = *data.Weight
+	}
+
+	if data.Length != nil {
+		update.Length = *data.Length
+	}
+
+	if data.Width != nil {
+		update.Width = *data.Width
+	}
+
+	if data.Height != nil {
+		update.Height = *data.Height
+	}
+
+	// columns that can be set to zero
+	columns := helpers.Map{}
+	if data.ReturnWindowDays != nil {
+		columns["return_window_days"] = *data.ReturnWindowDays
+	}
+	if data.Brand != nil {
+		columns["brand"] = strings.TrimSpace(*data.Brand)
+	}
+	if data.ReorderThreshold != nil {
+		columns["reorder_threshold"] = *data.ReorderThreshold
+	}
+
+	if data.Quantity == nil && data.Prices == nil && data.CategoryIds == nil && data.Options == nil && data.Attributes == nil && len(columns) == 0 {
+		err := c.productRepo.UpdateProductById(ctx, productId, &update)
+		if err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+		return handleSuccess(nil, "success", "product updated successfully", http.StatusOK)
+	}
+
+	var prices []*models.ProductPrice
+	if data.Prices != nil {
+		if prices, err = toProductPrices(*data.Prices, product.Currency); err != nil {
+			return handleError(err, "bad-request", http.StatusBadRequest)
+		}
+	}
+	var options []*models.ProductOption
+	if data.Options != nil {
+		if options, err = toProductOptions(*data.Options); err != nil {
+			return handleError(err, "bad-request", http.StatusBadRequest)
+		}
+		for _, variant := range product.Variants {
+			if !variant.Options.FitsOptions(options) {
+				return handleError(messages.ErrInvalidVariantOptions, "bad-request", http.StatusBadRequest)
+			}
+		}
+	}
+	var categoryIds []uuid.UUID
+	if data.CategoryIds != nil {
+		categoryIds, err = c.toCategoryIds(ctx, *data.CategoryIds)
+		if err == messages.ErrCategoryNotFound {
+			return handleError(err, "bad-request", http.StatusBadRequest)
+		}
+		if err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+	}
+	if data.CategoryIds != nil || data.Attributes != nil {
+		// the attributes must fit the categories the product ends up in
+		attributeCategoryIds, attributes := categoryIds, map[string]interface{}(product.Attributes)
+		if data.CategoryIds == nil {
+			attributeCategoryIds = []uuid.UUID{}
+			for _, category := range product.Categories {
+				attributeCategoryIds = append(attributeCategoryIds, category.Id)
+			}
+		}
+		if data.Attributes != nil {
+			attributes = *data.Attributes
+		}
+		validated, err := c.toProductAttributes(ctx, attributeCategoryIds, attributes)
+		if err != nil {
+			return handleAttributeError(err)
+		}
+		if data.Attributes != nil {
+			columns["attributes"] = validated
+		}
+	}
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		productRepo := repo.NewProductRepo(tx)
+		if err := productRepo.UpdateProductById(ctx, productId, &update); err != nil {
+			return err
+		}
+		if len(columns) > 0 {
+			if err := productRepo.UpdateProductColumns(ctx, productId, columns); err != nil {
+				return err
+			}
+		}
+		if data.Quantity != nil {
+			if err := setStock(ctx, tx, stock, productId, nil, *data.Quantity); err != nil {
+				return err
+			}
+		}
+		if data.CategoryIds != nil {
+			if err := repo.NewProductCategoryRepo(tx).ReplaceProductCategories(ctx, productId, categoryIds); err != nil {
+				return err
+			}
+		}
+		if data.Options != nil {
+			if err := repo.NewProductOptionRepo(tx).ReplaceProductOptions(ctx, productId, options); err != nil {
+				return err
+			}
+		}
+		if data.Prices == nil {
+			return nil
+		}
+		return repo.NewProductPriceRepo(tx).ReplaceProductPrices(ctx, productId, prices)
+	})
 	if err != nil {
 		return handleError(err, "server-error", http.StatusInternalServerError)
 	}
+	// customers waiting for the product are told once it can be ordered again
+	if data.Quantity != nil && product.SellableQuantity <= 0 && *data.Quantity-product.ReservedQuantity > 0 {
+		go c.notifyBackInStock(context.Background(), product)
+	}
 
 	return handleSuccess(nil, "success", "product updated successfully", http.StatusOK)
 }
 
-func (c *Controller) GetAllProducts(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject {
-	result, err := c.productRepo.GetAllProducts(ctx, query)
+func (c *Controller) GetAllProducts(ctx context.Context, query *models.APIPagingDto, data *models.ProductQueryDto) *models.ResponseObject {
+	filter, err := c.toProductFilter(ctx, data)
+	if err == messages.ErrCategoryNotFound || err == messages.ErrPriceFilterCurrencyRequired ||
+		err == messages.ErrInvalidPriceRange || err == messages.ErrInvalidOptionFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
 	if err != nil {
 		return handleError(err, "server-error", http.StatusInternalServerError)
 	}
+
+	result, err := c.productRepo.GetAllProducts(ctx, query, filter)
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if data.Facets {
+		if result.Facets, err = c.productRepo.GetProductFacets(ctx, query, filter); err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+	}
+	c.setProductMediaUrls(result.Products...)
 	return handleSuccess(result, "success", "products fetched successfully", http.StatusOK)
 }
 
+// DeleteProduct deletes a product that was never ordered, its stock history is kept
 func (c *Controller) DeleteProduct(ctx context.Context, productId uuid.UUID) *models.ResponseObject {
-	err := c.productRepo.DeleteProduct(ctx, &models.Product{Id: productId})
+	ordered, err := c.orderRecordRepo.CountOrderRecords(ctx, helpers.Map{"product_id": productId})
 	if err != nil {
 		return handleError(err, "server-error", http.StatusInternalServerError)
 	}
+	if ordered > 0 {
+		return handleError(messages.ErrProductInUse, "bad-request", http.StatusBadRequest)
+	}
+	media, err := c.productMediaRepo.GetMediaOfProduct(ctx, productId)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	err = c.productRepo.DeleteProduct(ctx, &models.Product{Id: productId})
+	if err == messages.ErrProductInUse {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	c.deleteMediaFiles(ctx, media...)
 	return handleSuccess(nil, "success", "product deleted successfully", http.StatusOK)
 }
+
+// toProductFilter builds what the products listing is narrowed down to from its query
+func (c *Controller) toProductFilter(ctx context.Context, data *models.ProductQueryDto) (*models.ProductFilter, error) {
+	if (data.MinPrice != nil || data.MaxPrice != nil) && data.Currency == "" {
+		return nil, messages.ErrPriceFilterCurrencyRequired
+	}
+	if data.MinPrice != nil && data.MaxPrice != nil && *data.MinPrice > *data.MaxPrice {
+		return nil, messages.ErrInvalidPriceRange
+	}
+
+	filter, err := c.productFilter(ctx, data.Category)
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range data.Status {
+		filter.Statuses = append(filter.Statuses, string(status))
+	}
+	filter.Brands = data.Brand
+	filter.Currency = string(data.Currency)
+	filter.MinPrice, filter.MaxPrice = data.MinPrice, data.MaxPrice
+	for _, option := range data.Options {
+		name, value, ok := strings.Cut(option, ":")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			return nil, messages.ErrInvalidOptionFilter
+		}
+		if filter.Options == nil {
+			filter.Options = map[string][]string{}
+		}
+		filter.Options[name] = append(filter.Options[name], value)
+	}
+	return filter, nil
+}
+
+// toProductPrices builds the prices of a product in other currencies, one per currency
+func toProductPrices(data []models.ProductPriceDto, productCurrency string) ([]*models.ProductPrice, error) {
+	prices := []*models.ProductPrice{}
+	seen := map[models.Currency]bool{}
+	for _, price := range data {
+		if seen[price.Currency] || string(price.Currency) == productCurrency {
+			return nil, messages.ErrDuplicateProductPrice
+		}
+		if price.Discount > price.Price {
+			return nil, messages.ErrDiscountExceedsPrice
+		}
+		seen[price.Currency] = true
+		prices = append(prices, &models.ProductPrice{
+			Id:       uuid.New(),
+			Currency: string(price.Currency),
+			Price:    models.NewMoney(price.Price, price.Currency),
+			Discount: models.NewMoney(price.Discount, price.Currency),
+		})
+	}
+	return prices, nil
+}
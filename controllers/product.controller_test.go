@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/models"
+)
+
+func TestDeleteProduct(t *testing.T) {
+	// every product has stock history from its initial stock, only orders keep it from being deleted
+	stocked, ordered, held := uuid.New(), uuid.New(), uuid.New()
+	tests := []struct {
+		name      string
+		productId uuid.UUID
+		deleteErr error
+		code      int
+		deleted   bool
+	}{
+		{name: "stocked but never ordered", productId: stocked, code: http.StatusOK, deleted: true},
+		{name: "ordered", productId: ordered, code: http.StatusBadRequest},
+		{name: "held back by the database", productId: held, deleteErr: messages.ErrProductInUse, code: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			productRepo := &fakeProductRepo{deleteErr: tt.deleteErr}
+			c := &Controller{
+				productRepo:      productRepo,
+				productMediaRepo: &fakeProductMediaRepo{},
+				orderRecordRepo:  &fakeOrderRecordRepo{records: []*models.OrderRecord{{ProductId: ordered, Quantity: 1}}},
+			}
+			result := c.DeleteProduct(context.Background(), tt.productId)
+			if result.Code != tt.code {
+				t.Fatalf("code = %d, want %d (%s)", result.Code, tt.code, result.Message)
+			}
+			if got := slices.Contains(productRepo.deleted, tt.productId); got != tt.deleted {
+				t.Errorf("deleted = %v, want %v", got, tt.deleted)
+			}
+		})
+	}
+}
+
+func TestDeleteProductVariant(t *testing.T) {
+	productId, stocked, ordered := uuid.New(), uuid.New(), uuid.New()
+	tests := []struct {
+		name      string
+		variantId uuid.UUID
+		code      int
+		deleted   bool
+	}{
+		{name: "stocked but never ordered", variantId: stocked, code: http.StatusOK, deleted: true},
+		{name: "ordered", variantId: ordered, code: http.StatusBadRequest},
+		{name: "of another product", variantId: uuid.New(), code: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variantRepo := &fakeProductVariantRepo{variants: []*models.ProductVariant{
+				{Id: stocked, ProductId: productId},
+				{Id: ordered, ProductId: productId},
+			}}
+			c := &Controller{
+				productVariantRepo: variantRepo,
+				orderRecordRepo:    &fakeOrderRecordRepo{records: []*models.OrderRecord{{ProductId: productId, VariantId: &ordered, Quantity: 1}}},
+			}
+			result := c.DeleteProductVariant(context.Background(), productId, tt.variantId)
+			if result.Code != tt.code {
+				t.Fatalf("code = %d, want %d (%s)", result.Code, tt.code, result.Message)
+			}
+			if got := slices.Contains(variantRepo.deleted, tt.variantId); got != tt.deleted {
+				t.Errorf("deleted = %v, want %v", got, tt.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,427 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// CreatePromotion creates a new automatic promotion
+func (c *Controller) CreatePromotion(ctx context.Context, data *models.CreatePromotionDto) *models.ResponseObject {
+	if !data.Rules.IsValidFor(data.Type) {
+		return handleError(messages.ErrInvalidPromotionRules, "bad-request", http.StatusBadRequest)
+	}
+	if data.Rules.NeedsCurrency(data.Type) && data.Currency == nil {
+		return handleError(messages.ErrPromotionCurrencyRequired, "bad-request", http.StatusBadRequest)
+	}
+
+	promotion := &models.Promotion{
+		Id:          uuid.New(),
+		Name:        data.Name,
+		Description: data.Description,
+		Type:        string(data.Type),
+		Rules:       data.Rules,
+		Priority:    data.Priority,
+		Exclusive:   data.Exclusive,
+		StartsAt:    data.StartsAt,
+		EndsAt:      data.EndsAt,
+		Status:      string(models.PROMOTION_ACTIVE),
+	}
+	if data.Currency != nil {
+		promotion.Currency = string(*data.Currency)
+	}
+
+	newPromotion, err := c.promotionRepo.CreatePromotion(ctx, promotion)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(newPromotion, "success", "promotion created successfully", http.StatusCreated)
+}
+
+// GetAllPromotions gets all promotions
+func (c *Controller) GetAllPromotions(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject {
+	result, err := c.promotionRepo.GetAllPromotions(ctx, query)
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(result, "success", "promotions fetched successfully", http.StatusOK)
+}
+
+// GetSinglePromotion gets a promotion by id
+func (c *Controller) GetSinglePromotion(ctx context.Context, promotionId uuid.UUID) *models.ResponseObject {
+	promotion, err := c.promotionRepo.GetPromotionByFields(ctx, helpers.Map{"id": promotionId})
+	if err == messages.ErrPromotionNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(promotion, "success", "promotion fetched successfully", http.StatusOK)
+}
+
+// UpdatePromotion updates a promotion
+func (c *Controller) UpdatePromotion(ctx context.Context, data *models.UpdatePromotionDto, promotionId uuid.UUID) *models.ResponseObject {
+	promotion, err := c.promotionRepo.GetPromotionByFields(ctx, helpers.Map{"id": promotionId})
+	if err == messages.ErrPromotionNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	update := helpers.Map{}
+	if data.Name != nil {
+		update["name"] = *data.Name
+	}
+	if data.Description != nil {
+		update["description"] = *data.Description
+	}
+	if data.Rules != nil {
+		if !data.Rules.IsValidFor(models.PromotionType(promotion.Type)) {
+			return handleError(messages.ErrInvalidPromotionRules, "bad-request", http.StatusBadRequest)
+		}
+		if data.Rules.NeedsCurrency(models.PromotionType(promotion.Type)) && promotion.Currency == "" {
+			return handleError(messages.ErrPromotionCurrencyRequired, "bad-request", http.StatusBadRequest)
+		}
+		update["rules"] = *data.Rules
+	}
+	if data.Priority != nil {
+		update["priority"] = *data.Priority
+	}
+	if data.Exclusive != nil {
+		update["exclusive"] = *data.Exclusive
+	}
+	if data.StartsAt != nil {
+		update["starts_at"] = *data.StartsAt
+	}
+	if data.EndsAt != nil {
+		update["ends_at"] = *data.EndsAt
+	}
+	if data.Status != nil {
+		update["status"] = string(*data.Status)
+	}
+
+	if err := c.promotionRepo.UpdatePromotionById(ctx, promotionId, update); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "promotion updated successfully", http.StatusOK)
+}
+
+// DeletePromotion deletes a promotion. Discount lines already on orders keep their description and amount.
+func (c *Controller) DeletePromotion(ctx context.Context, promotionId uuid.UUID) *models.ResponseObject {
+	_, err := c.promotionRepo.GetPromotionByFields(ctx, helpers.Map{"id": promotionId})
+	if err == messages.ErrPromotionNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	if err := c.promotionRepo.DeletePromotion(ctx, promotionId); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "promotion deleted successfully", http.StatusOK)
+}
+
+// applyPromotions evaluates the running promotions on a priced order, highest priority first,
+// and adds a discount line for every discount they give
+func (c *Controller) applyPromotions(ctx context.Context, quote *orderQuote) error {
+	promotions, err := c.promotionRepo.GetActivePromotions(ctx, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	order := quote.order
+	for _, promotion := range promotions {
+		if promotion.Currency != "" && promotion.Currency != order.Currency {
+			continue
+		}
+
+		var discounts []*models.OrderDiscount
+		switch models.PromotionType(promotion.Type) {
+		case models.PROMOTION_BUY_X_GET_Y:
+			discounts, err = quote.buyXGetY(promotion)
+		case models.PROMOTION_SPEND_THRESHOLD:
+			discounts, err = quote.spendThreshold(promotion)
+		case models.PROMOTION_QUANTITY_TIER:
+			discounts, err = quote.quantityTier(promotion)
+		case models.PROMOTION_BUNDLE:
+			discounts, err = quote.bundle(promotion)
+		}
+		if err != nil {
+			return err
+		}
+		if len(discounts) == 0 {
+			continue
+		}
+
+		order.Discounts = append(order.Discounts, discounts...)
+		if promotion.Exclusive {
+			break
+		}
+	}
+	return nil
+}
+
+// buyXGetY discounts the cheapest eligible units of every complete buy and get set
+func (q *orderQuote) buyXGetY(promotion *models.Promotion) ([]*models.OrderDiscount, error) {
+	var eligible []*models.OrderRecord
+	var quantity int64
+	for _, orderRecord := range q.order.OrderRecords {
+		if promotion.AppliesTo(orderRecord.ProductId) {
+			eligible = append(eligible, orderRecord)
+			quantity += orderRecord.Quantity
+		}
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return eligible[i].Amount.Amount < eligible[j].Amount.Amount
+	})
+
+	rules := promotion.Rules
+	percent := rules.Percent
+	if percent == 0 {
+		percent = 100
+	}
+	freeUnits := quantity / (rules.BuyQuantity + rules.GetQuantity) * rules.GetQuantity
+
+	var discounts []*models.OrderDiscount
+	for _, orderRecord := range eligible {
+		if freeUnits == 0 {
+			break
+		}
+		units := orderRecord.Quantity
+		if units > freeUnits {
+			units = freeUnits
+		}
+		freeUnits -= units
+
+		amount, err := orderRecord.Amount.Mul(units)
+		if err != nil {
+			return nil, err
+		}
+		if amount, err = amount.Percent(percent, models.ROUND_HALF_EVEN); err != nil {
+			return nil, err
+		}
+		discount, err := q.recordDiscount(promotion, orderRecord, amount)
+		if err != nil {
+			return nil, err
+		}
+		if discount != nil {
+			discounts = append(discounts, discount)
+		}
+	}
+	return discounts, nil
+}
+
+// spendThreshold discounts the order once the eligible amount reaches the threshold
+func (q *orderQuote) spendThreshold(promotion *models.Promotion) ([]*models.OrderDiscount, error) {
+	eligibleAmount := models.NewMoney(0, models.Currency(q.order.Currency))
+	for _, orderRecord := range q.order.OrderRecords {
+		if !promotion.AppliesTo(orderRecord.ProductId) {
+			continue
+		}
+		remaining, err := q.recordRemaining(orderRecord)
+		if err != nil {
+			return nil, err
+		}
+		if eligibleAmount, err = eligibleAmount.Add(remaining); err != nil {
+			return nil, err
+		}
+	}
+
+	rules := promotion.Rules
+	if eligibleAmount.Amount < rules.MinAmount {
+		return nil, nil
+	}
+	amount := models.NewMoney(rules.Amount, eligibleAmount.Currency)
+	var err error
+	if rules.Percent > 0 {
+		if amount, err = eligibleAmount.Percent(rules.Percent, models.ROUND_HALF_EVEN); err != nil {
+			return nil, err
+		}
+		if rules.MaxDiscount > 0 {
+			if amount, err = amount.Min(models.NewMoney(rules.MaxDiscount, amount.Currency)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if amount, err = amount.Min(eligibleAmount); err != nil {
+		return nil, err
+	}
+
+	discount, err := q.orderDiscount(promotion, amount)
+	if err != nil || discount == nil {
+		return nil, err
+	}
+	return []*models.OrderDiscount{discount}, nil
+}
+
+// quantityTier discounts each eligible order record by the best tier its quantity reaches
+func (q *orderQuote) quantityTier(promotion *models.Promotion) ([]*models.OrderDiscount, error) {
+	var discounts []*models.OrderDiscount
+	for _, orderRecord := range q.order.OrderRecords {
+		if !promotion.AppliesTo(orderRecord.ProductId) {
+			continue
+		}
+
+		var percent int64
+		for _, tier := range promotion.Rules.Tiers {
+			if orderRecord.Quantity >= tier.MinQuantity && tier.Percent > percent {
+				percent = tier.Percent
+			}
+		}
+		if percent == 0 {
+			continue
+		}
+
+		total, err := orderRecord.GetTotal()
+		if err != nil {
+			return nil, err
+		}
+		amount, err := total.Percent(percent, models.ROUND_HALF_EVEN)
+		if err != nil {
+			return nil, err
+		}
+		discount, err := q.recordDiscount(promotion, orderRecord, amount)
+		if err != nil {
+			return nil, err
+		}
+		if discount != nil {
+			discounts = append(discounts, discount)
+		}
+	}
+	return discounts, nil
+}
+
+// bundle discounts every complete bundle in the order down to the bundle price. A product in several
+// order records, as different variants, is priced at its cheapest unit so a bundle never saves more
+// than its items cost.
+func (q *orderQuote) bundle(promotion *models.Promotion) ([]*models.OrderDiscount, error) {
+	currency := models.Currency(q.order.Currency)
+	quantities := map[uuid.UUID]int64{}
+	amounts := map[uuid.UUID]models.Money{}
+	for _, orderRecord := range q.order.OrderRecords {
+		quantities[orderRecord.ProductId] += orderRecord.Quantity
+		if amount, ok := amounts[orderRecord.ProductId]; !ok || orderRecord.Amount.Amount < amount.Amount {
+			amounts[orderRecord.ProductId] = orderRecord.Amount
+		}
+	}
+
+	var bundles int64 = -1
+	regularPrice := models.NewMoney(0, currency)
+	for _, item := range promotion.Rules.Items {
+		count := quantities[item.ProductId] / item.Quantity
+		if bundles < 0 || count < bundles {
+			bundles = count
+		}
+		if count == 0 {
+			return nil, nil
+		}
+
+		itemPrice, err := amounts[item.ProductId].Mul(item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if regularPrice, err = regularPrice.Add(itemPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	saving, err := regularPrice.Sub(models.NewMoney(promotion.Rules.BundlePrice, currency))
+	if err == messages.ErrNegativeMoney || bundles <= 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if saving, err = saving.Mul(bundles); err != nil {
+		return nil, err
+	}
+
+	discount, err := q.orderDiscount(promotion, saving)
+	if err != nil || discount == nil {
+		return nil, err
+	}
+	for _, orderRecord := range q.order.OrderRecords {
+		for _, item := range promotion.Rules.Items {
+			if item.ProductId == orderRecord.ProductId {
+				q.promoted[orderRecord.Id] = true
+			}
+		}
+	}
+	return []*models.OrderDiscount{discount}, nil
+}
+
+// recordDiscount builds a discount line on an order record, capped at what is left of the record
+func (q *orderQuote) recordDiscount(promotion *models.Promotion, orderRecord *models.OrderRecord, amount models.Money) (*models.OrderDiscount, error) {
+	remaining, err := q.recordRemaining(orderRecord)
+	if err != nil {
+		return nil, err
+	}
+	if amount, err = amount.Min(remaining); err != nil {
+		return nil, err
+	}
+	if !amount.IsPositive() {
+		return nil, nil
+	}
+
+	q.promoted[orderRecord.Id] = true
+	return &models.OrderDiscount{
+		Id:            uuid.New(),
+		OrderId:       q.order.Id,
+		OrderRecordId: &orderRecord.Id,
+		PromotionId:   &promotion.Id,
+		Description:   promotion.Name,
+		Amount:        amount,
+		Currency:      q.order.Currency,
+	}, nil
+}
+
+// orderDiscount builds a discount line on the whole order, capped at what is left of the order
+func (q *orderQuote) orderDiscount(promotion *models.Promotion, amount models.Money) (*models.OrderDiscount, error) {
+	remaining, err := q.order.GetTotalAmount()
+	if err != nil {
+		return nil, err
+	}
+	if amount, err = amount.Min(remaining); err != nil {
+		return nil, err
+	}
+	if !amount.IsPositive() {
+		return nil, nil
+	}
+
+	return &models.OrderDiscount{
+		Id:          uuid.New(),
+		OrderId:     q.order.Id,
+		PromotionId: &promotion.Id,
+		Description: promotion.Name,
+		Amount:      amount,
+		Currency:    q.order.Currency,
+	}, nil
+}
+
+// recordRemaining gets the amount of an order record not yet covered by its discount lines
+func (q *orderQuote) recordRemaining(orderRecord *models.OrderRecord) (models.Money, error) {
+	remaining, err := orderRecord.GetTotal()
+	if err != nil {
+		return models.Money{}, err
+	}
+	for _, discount := range q.order.Discounts {
+		if discount.OrderRecordId != nil && *discount.OrderRecordId == orderRecord.Id {
+			if remaining, err = remaining.Sub(discount.Amount); err != nil {
+				return models.Money{}, err
+			}
+		}
+	}
+	return remaining, nil
+}
@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"e-commerce/models"
+)
+
+func TestApplyPromotions(t *testing.T) {
+	ngn := func(amount int64) models.Money { return models.NewMoney(amount, models.CURRENCY_NGN) }
+	shoe, sock, hat := uuid.New(), uuid.New(), uuid.New()
+	record := func(productId uuid.UUID, amount, quantity int64) *models.OrderRecord {
+		return &models.OrderRecord{Id: uuid.New(), ProductId: productId, Amount: ngn(amount), Quantity: quantity, Currency: string(models.CURRENCY_NGN)}
+	}
+	promotion := func(name string, promotionType models.PromotionType, rules models.PromotionRules) *models.Promotion {
+		return &models.Promotion{Id: uuid.New(), Name: name, Type: string(promotionType), Rules: rules}
+	}
+	exclusive := func(promotion *models.Promotion) *models.Promotion {
+		promotion.Exclusive = true
+		return promotion
+	}
+	inUsd := func(promotion *models.Promotion) *models.Promotion {
+		promotion.Currency = string(models.CURRENCY_USD)
+		return promotion
+	}
+
+	buyTwoGetOne := models.PromotionRules{BuyQuantity: 2, GetQuantity: 1}
+	tenOffTwo := models.PromotionRules{Tiers: []models.PromotionTier{{MinQuantity: 2, Percent: 10}}}
+	tests := []struct {
+		name       string
+		promotions []*models.Promotion
+		records    []*models.OrderRecord
+		// the discount lines given, as promotion:record index or order:amount
+		want []string
+		// the records left out of coupons that do not stack
+		promoted []int
+	}{
+		{
+			name:       "buy two get one takes the cheapest unit",
+			promotions: []*models.Promotion{promotion("b2g1", models.PROMOTION_BUY_X_GET_Y, buyTwoGetOne)},
+			records:    []*models.OrderRecord{record(shoe, 500, 2), record(sock, 300, 2)},
+			want:       []string{"b2g1:1:300"},
+			promoted:   []int{1},
+		},
+		{
+			name:       "free units are split over the cheapest records",
+			promotions: []*models.Promotion{promotion("b2g1", models.PROMOTION_BUY_X_GET_Y, buyTwoGetOne)},
+			records:    []*models.OrderRecord{record(shoe, 500, 3), record(sock, 300, 2), record(hat, 200, 1)},
+			want:       []string{"b2g1:2:200", "b2g1:1:300"},
+			promoted:   []int{1, 2},
+		},
+		{
+			name:       "free units at a percent off",
+			promotions: []*models.Promotion{promotion("bogo", models.PROMOTION_BUY_X_GET_Y, models.PromotionRules{BuyQuantity: 1, GetQuantity: 1, Percent: 50})},
+			records:    []*models.OrderRecord{record(shoe, 400, 2)},
+			want:       []string{"bogo:0:200"},
+			promoted:   []int{0},
+		},
+		{
+			name:       "only the products of the promotion count",
+			promotions: []*models.Promotion{promotion("b2g1", models.PROMOTION_BUY_X_GET_Y, models.PromotionRules{BuyQuantity: 2, GetQuantity: 1, ProductIds: models.UUIDList{shoe}})},
+			records:    []*models.OrderRecord{record(shoe, 500, 1), record(sock, 300, 2)},
+		},
+		{
+			name:       "spend under the threshold",
+			promotions: []*models.Promotion{promotion("spend", models.PROMOTION_SPEND_THRESHOLD, models.PromotionRules{MinAmount: 2000, Amount: 500})},
+			records:    []*models.OrderRecord{record(shoe, 500, 2)},
+		},
+		{
+			name:       "spend over the threshold with the percent capped",
+			promotions: []*models.Promotion{promotion("spend", models.PROMOTION_SPEND_THRESHOLD, models.PromotionRules{MinAmount: 1000, Percent: 20, MaxDiscount: 150})},
+			records:    []*models.OrderRecord{record(shoe, 500, 2)},
+			want:       []string{"spend:order:150"},
+		},
+		{
+			name:       "spend amount is capped at the eligible amount",
+			promotions: []*models.Promotion{promotion("spend", models.PROMOTION_SPEND_THRESHOLD, models.PromotionRules{MinAmount: 100, Amount: 5000})},
+			records:    []*models.OrderRecord{record(shoe, 100, 2)},
+			want:       []string{"spend:order:200"},
+		},
+		{
+			name:       "quantity tiers take the best tier each record reaches",
+			promotions: []*models.Promotion{promotion("tier", models.PROMOTION_QUANTITY_TIER, models.PromotionRules{Tiers: []models.PromotionTier{{MinQuantity: 2, Percent: 10}, {MinQuantity: 5, Percent: 20}}})},
+			records:    []*models.OrderRecord{record(shoe, 100, 5), record(sock, 100, 3), record(hat, 100, 1)},
+			want:       []string{"tier:0:100", "tier:1:30"},
+			promoted:   []int{0, 1},
+		},
+		{
+			name:       "bundles are priced at the cheapest unit of their products",
+			promotions: []*models.Promotion{promotion("bundle", models.PROMOTION_BUNDLE, models.PromotionRules{Items: []models.BundleItem{{ProductId: shoe, Quantity: 1}, {ProductId: sock, Quantity: 2}}, BundlePrice: 500})},
+			records:    []*models.OrderRecord{record(shoe, 300, 1), record(shoe, 350, 1), record(sock, 200, 4), record(hat, 100, 1)},
+			want:       []string{"bundle:order:400"},
+			promoted:   []int{0, 1, 2},
+		},
+		{
+			name:       "incomplete bundle",
+			promotions: []*models.Promotion{promotion("bundle", models.PROMOTION_BUNDLE, models.PromotionRules{Items: []models.BundleItem{{ProductId: shoe, Quantity: 1}, {ProductId: sock, Quantity: 2}}, BundlePrice: 500})},
+			records:    []*models.OrderRecord{record(shoe, 300, 2), record(sock, 200, 1)},
+		},
+		{
+			name:       "bundle that costs more than its items",
+			promotions: []*models.Promotion{promotion("bundle", models.PROMOTION_BUNDLE, models.PromotionRules{Items: []models.BundleItem{{ProductId: shoe, Quantity: 1}}, BundlePrice: 800})},
+			records:    []*models.OrderRecord{record(shoe, 300, 1)},
+		},
+		{
+			name:       "promotions in another currency are left out",
+			promotions: []*models.Promotion{inUsd(exclusive(promotion("usd", models.PROMOTION_QUANTITY_TIER, tenOffTwo))), promotion("tier", models.PROMOTION_QUANTITY_TIER, tenOffTwo)},
+			records:    []*models.OrderRecord{record(shoe, 100, 2)},
+			want:       []string{"tier:0:20"},
+			promoted:   []int{0},
+		},
+		{
+			name:       "promotions stack",
+			promotions: []*models.Promotion{promotion("tier", models.PROMOTION_QUANTITY_TIER, tenOffTwo), promotion("b2g1", models.PROMOTION_BUY_X_GET_Y, buyTwoGetOne)},
+			records:    []*models.OrderRecord{record(shoe, 100, 3)},
+			want:       []string{"tier:0:30", "b2g1:0:100"},
+			promoted:   []int{0},
+		},
+		{
+			name:       "an exclusive promotion stops the ones after it",
+			promotions: []*models.Promotion{exclusive(promotion("tier", models.PROMOTION_QUANTITY_TIER, tenOffTwo)), promotion("b2g1", models.PROMOTION_BUY_X_GET_Y, buyTwoGetOne)},
+			records:    []*models.OrderRecord{record(shoe, 100, 3)},
+			want:       []string{"tier:0:30"},
+			promoted:   []int{0},
+		},
+		{
+			name:       "an exclusive promotion that gives nothing does not",
+			promotions: []*models.Promotion{exclusive(promotion("spend", models.PROMOTION_SPEND_THRESHOLD, models.PromotionRules{MinAmount: 10000, Amount: 500})), promotion("tier", models.PROMOTION_QUANTITY_TIER, tenOffTwo)},
+			records:    []*models.OrderRecord{record(shoe, 100, 3)},
+			want:       []string{"tier:0:30"},
+			promoted:   []int{0},
+		},
+		{
+			name:       "thresholds are reached after the discounts before them",
+			promotions: []*models.Promotion{promotion("tier", models.PROMOTION_QUANTITY_TIER, tenOffTwo), promotion("spend", models.PROMOTION_SPEND_THRESHOLD, models.PromotionRules{MinAmount: 1000, Amount: 100})},
+			records:    []*models.OrderRecord{record(shoe, 500, 2)},
+			want:       []string{"tier:0:100"},
+			promoted:   []int{0},
+		},
+		{
+			name:       "record discounts are capped at what is left of the record",
+			promotions: []*models.Promotion{promotion("free", models.PROMOTION_QUANTITY_TIER, models.PromotionRules{Tiers: []models.PromotionTier{{MinQuantity: 1, Percent: 100}}}), promotion("b2g1", models.PROMOTION_BUY_X_GET_Y, buyTwoGetOne)},
+			records:    []*models.OrderRecord{record(shoe, 100, 3)},
+			want:       []string{"free:0:300"},
+			promoted:   []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &models.Order{Id: uuid.New(), Currency: string(models.CURRENCY_NGN), OrderRecords: tt.records}
+			quote := &orderQuote{order: order, promoted: map[uuid.UUID]bool{}}
+			c := &Controller{promotionRepo: &fakePromotionRepo{promotions: tt.promotions}}
+			if err := c.applyPromotions(context.Background(), quote); err != nil {
+				t.Fatalf("err = %v", err)
+			}
+
+			got := []string{}
+			for _, discount := range order.Discounts {
+				line := "order"
+				if discount.OrderRecordId != nil {
+					line = fmt.Sprint(slices.IndexFunc(tt.records, func(orderRecord *models.OrderRecord) bool { return orderRecord.Id == *discount.OrderRecordId }))
+				}
+				got = append(got, fmt.Sprintf("%s:%s:%d", discount.Description, line, discount.Amount.Amount))
+			}
+			if !slices.Equal(got, tt.want) && (len(got) > 0 || len(tt.want) > 0) {
+				t.Errorf("discounts = %v, want %v", got, tt.want)
+			}
+			promoted := []int{}
+			for i, orderRecord := range tt.records {
+				if quote.promoted[orderRecord.Id] {
+					promoted = append(promoted, i)
+				}
+			}
+			if !slices.Equal(promoted, tt.promoted) && (len(promoted) > 0 || len(tt.promoted) > 0) {
+				t.Errorf("promoted = %v, want %v", promoted, tt.promoted)
+			}
+		})
+	}
+}
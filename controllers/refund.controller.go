@@ -0,0 +1,276 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/common/payments"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+const REFUND_REFERENCE_LENGTH = 16
+
+// CreateRefund refunds an order's payment by amount or by order records, optionally restocking the items
+func (c *Controller) CreateRefund(ctx context.Context, orderId uuid.UUID, data *models.CreateRefundDto, user *models.User) *models.ResponseObject {
+	if data.Amount > 0 && len(data.Items) > 0 {
+		return handleError(messages.ErrRefundAmountWithItems, "bad-request", http.StatusBadRequest)
+	}
+
+	order, err := c.orderRepo.GetOrderByFields(ctx, helpers.Map{"id": orderId})
+	if err == messages.ErrOrderNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	// the payment the order was paid with unless one is picked, else a payment that is due a refund
+	fields := helpers.Map{"order_id": order.Id, "status": []string{string(models.PAYMENT_SUCCESS), string(models.PAYMENT_PARTIALLY_REFUNDED)}}
+	if data.PaymentId != "" {
+		fields["id"] = data.PaymentId
+		fields["status"] = []string{string(models.PAYMENT_SUCCESS), string(models.PAYMENT_REFUND_DUE), string(models.PAYMENT_PARTIALLY_REFUNDED)}
+	}
+	payment, err := c.paymentRepo.GetPaymentByFields(ctx, fields)
+	if err == messages.ErrPaymentNotFound && data.PaymentId == "" {
+		payment, err = c.paymentRepo.GetPaymentByFields(ctx, helpers.Map{"order_id": order.Id, "status": string(models.PAYMENT_REFUND_DUE)})
+	}
+	if err == messages.ErrPaymentNotFound {
+		return handleError(messages.ErrOrderNotRefundable, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	// a payment not used for the order paid for none of its items
+	if payment.Status == string(models.PAYMENT_REFUND_DUE) && len(data.Items) > 0 {
+		return handleError(messages.ErrRefundDueByAmount, "bad-request", http.StatusBadRequest)
+	}
+	if payment.Provider != c.paymentProvider.Name() {
+		return handleError(messages.ErrPaymentProviderNotSupported, "bad-request", http.StatusBadRequest)
+	}
+
+	refund := &models.Refund{
+		Id:        uuid.New(),
+		OrderId:   order.Id,
+		PaymentId: payment.Id,
+		Reference: helpers.GenerateUniqueReferenceId(REFUND_REFERENCE_LENGTH),
+		Amount:    data.Amount,
+		Currency:  payment.Currency,
+		Reason:    data.Reason,
+		Status:    string(models.REFUND_PENDING),
+		Restock:   data.Restock && len(data.Items) > 0,
+		CreatedBy: user.Id,
+	}
+
+	orderRecords := map[uuid.UUID]*models.OrderRecord{}
+	for _, orderRecord := range order.OrderRecords {
+		orderRecords[orderRecord.Id] = orderRecord
+	}
+	// items are refunded what was paid for them, after the discounts on them and on the order
+	netAmounts, err := taxableAmounts(order)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	for _, item := range data.Items {
+		id, _ := uuid.Parse(item.OrderRecordId)
+		orderRecord, ok := orderRecords[id]
+		if !ok {
+			return handleError(messages.ErrOrderRecordNotFound, "bad-request", http.StatusBadRequest)
+		}
+		amount, err := orderRecord.GetRefundAmount(netAmounts[orderRecord.Id], item.Quantity)
+		if err != nil {
+			return handleError(err, "bad-request", http.StatusBadRequest)
+		}
+		refund.Amount += amount.Amount
+		refund.RefundItems = append(refund.RefundItems, &models.RefundItem{
+			Id:            uuid.New(),
+			RefundId:      refund.Id,
+			OrderRecordId: orderRecord.Id,
+			ProductId:     orderRecord.ProductId,
+			VariantId:     orderRecord.VariantId,
+			Quantity:      item.Quantity,
+			Amount:        amount.Amount,
+		})
+	}
+	if data.Amount == 0 && len(data.Items) == 0 {
+		refund.Amount = payment.Amount - payment.RefundedAmount
+	}
+	if refund.Amount <= 0 {
+		return handleError(messages.ErrRefundExceedsPayment, "bad-request", http.StatusBadRequest)
+	}
+
+	// hold the amount and quantities before calling the provider so concurrent refunds cannot exceed them
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		return c.reserveRefund(ctx, tx, refund, 1)
+	})
+	if err == messages.ErrRefundExceedsPayment || err == messages.ErrRefundQuantityExceeded {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	refunded, err := c.refundProvider.Refund(ctx, &payments.RefundRequest{
+		ProviderReference: payment.ProviderReference,
+		Reference:         refund.Reference,
+		Amount:            refund.Amount,
+		Currency:          refund.Currency,
+	})
+	if err == nil && refunded.Status == payments.CHARGE_FAILED {
+		err = messages.ErrRefundFailed
+	}
+	if err != nil {
+		releaseErr := c.db.Transaction(ctx, func(tx *db.Database) error {
+			return c.failRefund(ctx, tx, refund)
+		})
+		if releaseErr != nil {
+			log.Err(releaseErr).Msgf("CreateRefund: could not release refund %s: %v", refund.Reference, releaseErr)
+		}
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	refund.ProviderReference = refunded.ProviderReference
+	if refunded.Status == payments.CHARGE_PENDING {
+		// the provider confirms the refund later through its webhook
+		err = c.refundRepo.UpdateRefundById(ctx, refund.Id, &models.Refund{ProviderReference: refund.ProviderReference})
+		if err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+		return handleSuccess(refund, "success", "refund initiated successfully", http.StatusAccepted)
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		return c.completeRefund(ctx, tx, refund)
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(refund, "success", "refund issued successfully", http.StatusCreated)
+}
+
+// GetOrderRefunds gets all refunds of an order
+func (c *Controller) GetOrderRefunds(ctx context.Context, orderId uuid.UUID) *models.ResponseObject {
+	refunds, err := c.refundRepo.GetRefundsByFields(ctx, helpers.Map{"order_id": orderId})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(refunds, "success", "refunds fetched successfully", http.StatusOK)
+}
+
+// reserveRefund holds (direction 1) or releases (direction -1) a refund's amount and item quantities
+func (c *Controller) reserveRefund(ctx context.Context, tx *db.Database, refund *models.Refund, direction int64) error {
+	orderRecordRepo := repo.NewOrderRecordRepo(tx)
+	for _, item := range refund.RefundItems {
+		if err := orderRecordRepo.AddRefundedQuantity(ctx, item.OrderRecordId, direction*item.Quantity); err != nil {
+			return err
+		}
+	}
+	if err := repo.NewPaymentRepo(tx).AddRefundedAmount(ctx, refund.PaymentId, direction*refund.Amount); err != nil {
+		return err
+	}
+	if direction > 0 {
+		_, err := repo.NewRefundRepo(tx).CreateRefund(ctx, refund)
+		return err
+	}
+	return nil
+}
+
+// failRefund marks a refund the provider did not issue as failed and releases its amount and item quantities
+func (c *Controller) failRefund(ctx context.Context, tx *db.Database, refund *models.Refund) error {
+	if err := c.reserveRefund(ctx, tx, refund, -1); err != nil {
+		return err
+	}
+	return repo.NewRefundRepo(tx).UpdateRefundById(ctx, refund.Id, &models.Refund{Status: string(models.REFUND_FAILED)})
+}
+
+// completeRefund marks a refund as succeeded, restocks its items and moves the payment to its refunded state.
+// Orders are only moved once their payment is refunded in full.
+func (c *Controller) completeRefund(ctx context.Context, tx *db.Database, refund *models.Refund) error {
+	paymentRepo := repo.NewPaymentRepo(tx)
+	orderRepo := repo.NewOrderRepo(tx)
+
+	refund.Status = string(models.REFUND_SUCCEEDED)
+	err := repo.NewRefundRepo(tx).UpdateRefundById(ctx, refund.Id, &models.Refund{
+		Status:            refund.Status,
+		ProviderReference: refund.ProviderReference,
+	})
+	if err != nil {
+		return err
+	}
+
+	payment, err := paymentRepo.GetPaymentForUpdate(ctx, helpers.Map{"id": refund.PaymentId})
+	if err != nil {
+		return err
+	}
+	// a payment due a refund stays due until it is refunded in full
+	refundDue := payment.Status == string(models.PAYMENT_REFUND_DUE)
+	paymentStatus := models.PAYMENT_PARTIALLY_REFUNDED
+	if refundDue {
+		paymentStatus = models.PAYMENT_REFUND_DUE
+	}
+	if payment.RefundedAmount >= payment.Amount {
+		paymentStatus = models.PAYMENT_REFUNDED
+	}
+	if err := paymentRepo.UpdatePaymentById(ctx, payment.Id, &models.Payment{Status: string(paymentStatus)}); err != nil {
+		return err
+	}
+
+	// the order is locked before restocking so a cancellation cannot put the same units back. Only units that
+	// were not shipped are restocked, shipped ones go back in stock when their return is received.
+	order, err := orderRepo.GetOrderForUpdate(ctx, helpers.Map{"id": refund.OrderId})
+	if err != nil {
+		return err
+	}
+	if refund.Restock {
+		orderRecords := map[uuid.UUID]*models.OrderRecord{}
+		for _, orderRecord := range order.OrderRecords {
+			orderRecords[orderRecord.Id] = orderRecord
+		}
+		stock := &models.InventoryMovement{Type: string(models.INVENTORY_REFUND), ReferenceId: &refund.Id, ActorId: &refund.CreatedBy}
+		for _, item := range refund.RefundItems {
+			orderRecord, ok := orderRecords[item.OrderRecordId]
+			if !ok {
+				return messages.ErrOrderRecordNotFound
+			}
+			if err := restockUnshipped(ctx, tx, stock, orderRecord, item.Quantity); err != nil {
+				return err
+			}
+		}
+	}
+
+	// a partial refund marks the order partially refunded and leaves it where it is in fulfilment, a full
+	// refund ends it. Refunds of a payment the order was not paid with are only noted on it.
+	orderStatus, refundStatus, refundedAmount := order.Status, order.RefundStatus, order.RefundedAmount.Amount
+	historyStatus := orderStatus
+	if !refundDue {
+		refundedAmount += refund.Amount
+		refundStatus = string(models.PARTIALLY_REFUNDED)
+		if paymentStatus == models.PAYMENT_REFUNDED {
+			refundStatus = string(models.REFUNDED)
+			if slices.Contains(models.PAID_ORDER_STATUSES, order.Status) {
+				orderStatus = string(models.REFUNDED)
+			}
+		}
+		historyStatus = refundStatus
+	}
+	order.History.Data = append(order.History.Data, models.OrderHistory{
+		Note:      fmt.Sprintf("refund of %s issued on payment %s: %s", models.NewMoney(refund.Amount, models.Currency(refund.Currency)), payment.Reference, refund.Reason),
+		Status:    historyStatus,
+		CreatedAt: time.Now().UTC(),
+	})
+	return orderRepo.UpdateOrderById(ctx, order.Id, &models.Order{
+		Status:         orderStatus,
+		RefundStatus:   refundStatus,
+		RefundedAmount: models.NewMoney(refundedAmount, order.RefundedAmount.Currency),
+		History:        order.History,
+	})
+}
@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+const (
+	// DEFAULT_RESERVATION_TTL is how long stock is held for a pending order when RESERVATION_TTL is not set
+	DEFAULT_RESERVATION_TTL = 30 * time.Minute
+	// RESERVATION_SWEEP_BATCH is how many expired reservations are read at a time
+	RESERVATION_SWEEP_BATCH = 100
+)
+
+// ReleaseExpiredReservations releases the stock held for pending orders that were not paid for in time,
+// so it can be sold to other orders
+func (c *Controller) ReleaseExpiredReservations(ctx context.Context) error {
+	for {
+		reservations, err := c.stockReservationRepo.GetExpiredStockReservations(ctx, time.Now().UTC(), RESERVATION_SWEEP_BATCH)
+		if err != nil {
+			return err
+		}
+		for _, reservation := range reservations {
+			err := c.db.Transaction(ctx, func(tx *db.Database) error {
+				return releaseReservation(ctx, tx, reservation, models.RESERVATION_RELEASED)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(reservations) < RESERVATION_SWEEP_BATCH {
+			return nil
+		}
+	}
+}
+
+// reserveOrder holds the stock allocated to a pending order until it is paid for, cancelled or the
+// reservation expires. It must run inside a transaction.
+func (c *Controller) reserveOrder(ctx context.Context, tx *db.Database, order *models.Order) error {
+	expiresAt := time.Now().UTC().Add(c.reservationTtl())
+	reservations := []*models.StockReservation{}
+	for _, orderRecord := range order.OrderRecords {
+		for _, allocation := range orderRecord.Allocations {
+			reservation := &models.StockReservation{
+				Id:            uuid.New(),
+				OrderId:       order.Id,
+				OrderRecordId: orderRecord.Id,
+				ProductId:     orderRecord.ProductId,
+				VariantId:     orderRecord.VariantId,
+				WarehouseId:   allocation.WarehouseId,
+				Quantity:      allocation.Quantity,
+				Status:        string(models.RESERVATION_ACTIVE),
+				ExpiresAt:     expiresAt,
+			}
+			if err := holdStock(ctx, tx, reservation, reservation.Quantity); err != nil {
+				return err
+			}
+			reservations = append(reservations, reservation)
+		}
+	}
+	return repo.NewStockReservationRepo(tx).CreateStockReservations(ctx, reservations)
+}
+
+// reholdOrder holds the stock allocated to an order again where its reservations expired before it was
+// paid for, failing with ErrInsufficientStock when the stock was sold to other orders since. It must run
+// inside a transaction.
+func reholdOrder(ctx context.Context, tx *db.Database, order *models.Order) error {
+	reservationRepo := repo.NewStockReservationRepo(tx)
+	active, err := reservationRepo.GetStockReservationsByFields(ctx, helpers.Map{"order_id": order.Id, "status": string(models.RESERVATION_ACTIVE)})
+	if err != nil {
+		return err
+	}
+	type heldKey struct {
+		orderRecordId uuid.UUID
+		warehouseId   uuid.UUID
+	}
+	held := map[heldKey]int64{}
+	for _, reservation := range active {
+		held[heldKey{reservation.OrderRecordId, reservation.WarehouseId}] += reservation.Quantity
+	}
+
+	now := time.Now().UTC()
+	reservations := []*models.StockReservation{}
+	for _, orderRecord := range order.OrderRecords {
+		for _, allocation := range orderRecord.Allocations {
+			quantity := allocation.Quantity - held[heldKey{orderRecord.Id, allocation.WarehouseId}]
+			if quantity <= 0 {
+				continue
+			}
+			reservation := &models.StockReservation{
+				Id:            uuid.New(),
+				OrderId:       order.Id,
+				OrderRecordId: orderRecord.Id,
+				ProductId:     orderRecord.ProductId,
+				VariantId:     orderRecord.VariantId,
+				WarehouseId:   allocation.WarehouseId,
+				Quantity:      quantity,
+				Status:        string(models.RESERVATION_ACTIVE),
+				ExpiresAt:     now,
+			}
+			if err := holdStock(ctx, tx, reservation, quantity); err != nil {
+				return err
+			}
+			reservations = append(reservations, reservation)
+		}
+	}
+	return reservationRepo.CreateStockReservations(ctx, reservations)
+}
+
+// reservationTtl is how long stock is held for a pending order
+func (c *Controller) reservationTtl() time.Duration {
+	ttl, err := time.ParseDuration(c.Config.ReservationTtl)
+	if err != nil || ttl <= 0 {
+		return DEFAULT_RESERVATION_TTL
+	}
+	return ttl
+}
+
+// releaseReservations ends the active reservations of an order, released when the order is cancelled or
+// converted when its stock is taken out. It must run inside a transaction.
+func releaseReservations(ctx context.Context, tx *db.Database, orderId uuid.UUID, status models.ReservationStatus) error {
+	reservations, err := repo.NewStockReservationRepo(tx).GetStockReservationsByFields(ctx, helpers.Map{"order_id": orderId, "status": string(models.RESERVATION_ACTIVE)})
+	if err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		if err := releaseReservation(ctx, tx, reservation, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseReservation ends a reservation that is still active and stops holding its stock.
+// It must run inside a transaction.
+func releaseReservation(ctx context.Context, tx *db.Database, reservation *models.StockReservation, status models.ReservationStatus) error {
+	err := repo.NewStockReservationRepo(tx).MoveStockReservation(ctx, reservation.Id, string(models.RESERVATION_ACTIVE), string(status))
+	// it was already released or converted
+	if err == messages.ErrReservationNotActive {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return holdStock(ctx, tx, reservation, -reservation.Quantity)
+}
+
+// holdStock moves the quantity of a product or variant held for pending orders, in the warehouse of a
+// reservation and in total. It must run inside a transaction.
+func holdStock(ctx context.Context, tx *db.Database, reservation *models.StockReservation, quantity int64) error {
+	err := repo.NewWarehouseStockRepo(tx).ReserveWarehouseStock(ctx, reservation.WarehouseId, reservation.ProductId, reservation.VariantId, quantity)
+	if err != nil {
+		return err
+	}
+	if reservation.VariantId != nil {
+		err = repo.NewProductVariantRepo(tx).AddVariantReservedQuantity(ctx, *reservation.VariantId, quantity)
+	} else {
+		err = repo.NewProductRepo(tx).AddProductReservedQuantity(ctx, reservation.ProductId, quantity)
+	}
+	// stock of products and variants deleted since is no longer tracked
+	if err == messages.ErrProductNotFound || err == messages.ErrProductVariantNotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,327 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// CreateReturn asks to return some of the records of a delivered order within their products' return windows
+func (c *Controller) CreateReturn(ctx context.Context, orderId uuid.UUID, data *models.CreateReturnDto, user *models.User) *models.ResponseObject {
+	order, err := c.orderRepo.GetOrderByFields(ctx, helpers.Map{"id": orderId, "user_id": user.Id})
+	if err == messages.ErrOrderNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	deliveredAt := order.GetDeliveredAt()
+	if deliveredAt == nil || order.Status != string(models.DELIVERED) {
+		return handleError(messages.ErrOrderNotReturnable, "bad-request", http.StatusBadRequest)
+	}
+
+	returnRequest := &models.ReturnRequest{
+		Id:               uuid.New(),
+		OrderId:          order.Id,
+		UserId:           user.Id,
+		Status:           string(models.RETURN_REQUESTED),
+		Comment:          data.Comment,
+		RefundableAmount: models.NewMoney(0, models.Currency(order.Currency)),
+		Currency:         order.Currency,
+	}
+
+	orderRecords := map[uuid.UUID]*models.OrderRecord{}
+	for _, orderRecord := range order.OrderRecords {
+		orderRecords[orderRecord.Id] = orderRecord
+	}
+	now := time.Now().UTC()
+	for _, item := range data.Items {
+		id, _ := uuid.Parse(item.OrderRecordId)
+		orderRecord, ok := orderRecords[id]
+		if !ok {
+			return handleError(messages.ErrOrderRecordNotFound, "bad-request", http.StatusBadRequest)
+		}
+		if item.Quantity > orderRecord.GetReturnableQuantity() {
+			return handleError(messages.ErrReturnQuantityExceeded, "bad-request", http.StatusBadRequest)
+		}
+		product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": orderRecord.ProductId})
+		if err == messages.ErrProductNotFound {
+			return handleError(messages.ErrProductNotReturnable, "bad-request", http.StatusBadRequest)
+		}
+		if err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+		if product.ReturnWindowDays <= 0 {
+			return handleError(messages.ErrProductNotReturnable, "bad-request", http.StatusBadRequest)
+		}
+		if !product.IsReturnableAt(*deliveredAt, now) {
+			return handleError(messages.ErrReturnWindowClosed, "bad-request", http.StatusBadRequest)
+		}
+
+		returnRequest.Items = append(returnRequest.Items, &models.ReturnItem{
+			Id:               uuid.New(),
+			ReturnRequestId:  returnRequest.Id,
+			OrderRecordId:    orderRecord.Id,
+			ProductId:        orderRecord.ProductId,
+			VariantId:        orderRecord.VariantId,
+			Quantity:         item.Quantity,
+			Reason:           string(item.Reason),
+			RefundableAmount: models.NewMoney(0, models.Currency(order.Currency)),
+			Currency:         order.Currency,
+		})
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		orderRecordRepo := repo.NewOrderRecordRepo(tx)
+		for _, item := range returnRequest.Items {
+			if err := orderRecordRepo.AddReturnedQuantity(ctx, item.OrderRecordId, item.Quantity); err != nil {
+				return err
+			}
+		}
+		if _, err := repo.NewReturnRequestRepo(tx).CreateReturnRequest(ctx, returnRequest); err != nil {
+			return err
+		}
+		return c.addOrderNote(ctx, tx, order.Id, fmt.Sprintf("return %s requested", returnRequest.Id))
+	})
+	if err == messages.ErrReturnQuantityExceeded {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(returnRequest, "success", "return requested successfully", http.StatusCreated)
+}
+
+// GetOrderReturns gets the returns of an order
+func (c *Controller) GetOrderReturns(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject {
+	fields := helpers.Map{"order_id": orderId}
+	if user.Role != string(models.USER_ROLE_ADMIN) {
+		fields["user_id"] = user.Id
+	}
+	returnRequests, err := c.returnRequestRepo.GetReturnRequestsByFields(ctx, fields)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(returnRequests, "success", "returns fetched successfully", http.StatusOK)
+}
+
+// GetAllReturns gets all returns, filterable by status
+func (c *Controller) GetAllReturns(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject {
+	result, err := c.returnRequestRepo.GetAllReturnRequests(ctx, query)
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(result, "success", "returns fetched successfully", http.StatusOK)
+}
+
+// GetSingleReturn gets a return by id
+func (c *Controller) GetSingleReturn(ctx context.Context, returnId uuid.UUID, user *models.User) *models.ResponseObject {
+	fields := helpers.Map{"id": returnId}
+	if user.Role != string(models.USER_ROLE_ADMIN) {
+		fields["user_id"] = user.Id
+	}
+	returnRequest, err := c.returnRequestRepo.GetReturnRequestByFields(ctx, fields)
+	if err == messages.ErrReturnNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(returnRequest, "success", "return fetched successfully", http.StatusOK)
+}
+
+// ApproveReturn accepts a return and works out how much of the order can be refunded for it
+func (c *Controller) ApproveReturn(ctx context.Context, returnId uuid.UUID, data *models.ReviewReturnDto, user *models.User) *models.ResponseObject {
+	returnRequest, errResponse := c.returnFor(ctx, returnId, models.RETURN_APPROVED)
+	if errResponse != nil {
+		return errResponse
+	}
+	order, err := c.orderRepo.GetOrderByFields(ctx, helpers.Map{"id": returnRequest.OrderId})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	orderRecords := map[uuid.UUID]*models.OrderRecord{}
+	for _, orderRecord := range order.OrderRecords {
+		orderRecords[orderRecord.Id] = orderRecord
+	}
+
+	netAmounts, err := taxableAmounts(order)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	refundableAmount := models.NewMoney(0, models.Currency(order.Currency))
+	for _, item := range returnRequest.Items {
+		orderRecord, ok := orderRecords[item.OrderRecordId]
+		if !ok {
+			return handleError(messages.ErrOrderRecordNotFound, "server-error", http.StatusInternalServerError)
+		}
+		if item.RefundableAmount, err = orderRecord.GetRefundAmount(netAmounts[orderRecord.Id], item.Quantity); err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+		if refundableAmount, err = refundableAmount.Add(item.RefundableAmount); err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+	}
+
+	now := time.Now().UTC()
+	returnRequest.Status = string(models.RETURN_APPROVED)
+	returnRequest.RefundableAmount = refundableAmount
+	returnRequest.ReviewNote = data.Note
+	returnRequest.ReviewedBy = &user.Id
+	returnRequest.ReviewedAt = &now
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		returnRequestRepo := repo.NewReturnRequestRepo(tx)
+		err := returnRequestRepo.MoveReturnRequest(ctx, returnRequest.Id, string(models.RETURN_REQUESTED), helpers.Map{
+			"status":            returnRequest.Status,
+			"refundable_amount": returnRequest.RefundableAmount,
+			"review_note":       returnRequest.ReviewNote,
+			"reviewed_by":       returnRequest.ReviewedBy,
+			"reviewed_at":       returnRequest.ReviewedAt,
+		})
+		if err != nil {
+			return err
+		}
+		for _, item := range returnRequest.Items {
+			if err := returnRequestRepo.UpdateReturnItemById(ctx, item.Id, helpers.Map{"refundable_amount": item.RefundableAmount}); err != nil {
+				return err
+			}
+		}
+		return c.addOrderNote(ctx, tx, returnRequest.OrderId, fmt.Sprintf("return %s approved, %s refundable", returnRequest.Id, refundableAmount))
+	})
+	if err == messages.ErrInvalidReturnStatus {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(returnRequest, "success", "return approved successfully", http.StatusOK)
+}
+
+// RejectReturn turns down a return, freeing its quantities to be asked for again
+func (c *Controller) RejectReturn(ctx context.Context, returnId uuid.UUID, data *models.ReviewReturnDto, user *models.User) *models.ResponseObject {
+	returnRequest, errResponse := c.returnFor(ctx, returnId, models.RETURN_REJECTED)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	now := time.Now().UTC()
+	returnRequest.Status = string(models.RETURN_REJECTED)
+	returnRequest.ReviewNote = data.Note
+	returnRequest.ReviewedBy = &user.Id
+	returnRequest.ReviewedAt = &now
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		err := repo.NewReturnRequestRepo(tx).MoveReturnRequest(ctx, returnRequest.Id, string(models.RETURN_REQUESTED), helpers.Map{
+			"status":      returnRequest.Status,
+			"review_note": returnRequest.ReviewNote,
+			"reviewed_by": returnRequest.ReviewedBy,
+			"reviewed_at": returnRequest.ReviewedAt,
+		})
+		if err != nil {
+			return err
+		}
+		orderRecordRepo := repo.NewOrderRecordRepo(tx)
+		for _, item := range returnRequest.Items {
+			if err := orderRecordRepo.AddReturnedQuantity(ctx, item.OrderRecordId, -item.Quantity); err != nil {
+				return err
+			}
+		}
+		return c.addOrderNote(ctx, tx, returnRequest.OrderId, fmt.Sprintf("return %s rejected", returnRequest.Id))
+	})
+	if err == messages.ErrInvalidReturnStatus {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(returnRequest, "success", "return rejected successfully", http.StatusOK)
+}
+
+// ReceiveReturn records that the items of an approved return have arrived, optionally putting them back in stock
+func (c *Controller) ReceiveReturn(ctx context.Context, returnId uuid.UUID, data *models.ReceiveReturnDto, user *models.User) *models.ResponseObject {
+	returnRequest, errResponse := c.returnFor(ctx, returnId, models.RETURN_RECEIVED)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	now := time.Now().UTC()
+	returnRequest.Status = string(models.RETURN_RECEIVED)
+	returnRequest.Restocked = data.Restock
+	returnRequest.ReceivedAt = &now
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		err := repo.NewReturnRequestRepo(tx).MoveReturnRequest(ctx, returnRequest.Id, string(models.RETURN_APPROVED), helpers.Map{
+			"status":      returnRequest.Status,
+			"restocked":   returnRequest.Restocked,
+			"received_at": returnRequest.ReceivedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if returnRequest.Restocked {
+			stock := &models.InventoryMovement{Type: string(models.INVENTORY_RETURN), ReferenceId: &returnRequest.Id, ActorId: &user.Id}
+			for _, item := range returnRequest.Items {
+				movement := stock.For(item.ProductId, item.VariantId, item.Quantity)
+				if movement.WarehouseId, err = fulfilmentWarehouse(ctx, tx, item.OrderRecordId); err != nil {
+					return err
+				}
+				if err := moveStock(ctx, tx, movement); err != nil {
+					return err
+				}
+			}
+		}
+		note := fmt.Sprintf("return %s received", returnRequest.Id)
+		if returnRequest.Restocked {
+			note += " and restocked"
+		}
+		return c.addOrderNote(ctx, tx, returnRequest.OrderId, note)
+	})
+	if err == messages.ErrInvalidReturnStatus {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(returnRequest, "success", "return received successfully", http.StatusOK)
+}
+
+// returnFor gets a return that can move to a status
+func (c *Controller) returnFor(ctx context.Context, returnId uuid.UUID, status models.ReturnStatus) (*models.ReturnRequest, *models.ResponseObject) {
+	returnRequest, err := c.returnRequestRepo.GetReturnRequestByFields(ctx, helpers.Map{"id": returnId})
+	if err == messages.ErrReturnNotFound {
+		return nil, handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return nil, handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if !returnRequest.CanMoveTo(status) {
+		return nil, handleError(messages.ErrInvalidReturnStatus, "bad-request", http.StatusBadRequest)
+	}
+	return returnRequest, nil
+}
+
+// addOrderNote adds a note to an order's history without changing its status
+func (c *Controller) addOrderNote(ctx context.Context, tx *db.Database, orderId uuid.UUID, note string) error {
+	orderRepo := repo.NewOrderRepo(tx)
+	order, err := orderRepo.GetOrderForUpdate(ctx, helpers.Map{"id": orderId})
+	if err != nil {
+		return err
+	}
+	order.History.Data = append(order.History.Data, models.OrderHistory{
+		Note:      note,
+		Status:    order.Status,
+		CreatedAt: time.Now().UTC(),
+	})
+	return orderRepo.UpdateOrderById(ctx, orderId, &models.Order{History: order.History})
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"e-commerce/common/messages"
+	"e-commerce/models"
+)
+
+const DEFAULT_AUTOCOMPLETE_LIMIT = 8
+
+// SearchProducts searches product names and descriptions, best matches first with the matched words highlighted
+func (c *Controller) SearchProducts(ctx context.Context, query *models.APIPagingDto, data *models.ProductSearchDto) *models.ResponseObject {
+	filter, err := c.productFilter(ctx, data.Category)
+	if err == messages.ErrCategoryNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	result, err := c.productRepo.SearchProducts(ctx, query, strings.TrimSpace(data.Query), filter)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	for _, found := range result.Results {
+		c.setProductMediaUrls(found.Product)
+	}
+	return handleSuccess(result, "success", "products searched successfully", http.StatusOK)
+}
+
+// AutocompleteProducts suggests products for what has been typed in the search box
+func (c *Controller) AutocompleteProducts(ctx context.Context, data *models.ProductAutocompleteDto) *models.ResponseObject {
+	limit := data.Limit
+	if limit == 0 {
+		limit = DEFAULT_AUTOCOMPLETE_LIMIT
+	}
+	suggestions, err := c.productRepo.AutocompleteProducts(ctx, strings.TrimSpace(data.Query), limit)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(suggestions, "success", "product suggestions fetched successfully", http.StatusOK)
+}
@@ -0,0 +1,369 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/carriers"
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// CreateShipment books a parcel of some or all of an order's records with the carrier
+func (c *Controller) CreateShipment(ctx context.Context, orderId uuid.UUID, data *models.CreateShipmentDto, user *models.User) *models.ResponseObject {
+	order, err := c.orderRepo.GetOrderByFields(ctx, helpers.Map{"id": orderId})
+	if err == messages.ErrOrderNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if order.Status != string(models.PROCESSING) {
+		return handleError(messages.ErrOrderNotShippable, "bad-request", http.StatusBadRequest)
+	}
+	if order.ShippingAddress == nil {
+		return handleError(messages.ErrShippingAddressRequired, "bad-request", http.StatusBadRequest)
+	}
+
+	shipment := &models.Shipment{
+		Id:        uuid.New(),
+		OrderId:   order.Id,
+		Carrier:   c.carrier.Name(),
+		Status:    string(models.SHIPMENT_PENDING),
+		CreatedBy: user.Id,
+	}
+
+	orderRecords := map[uuid.UUID]*models.OrderRecord{}
+	for _, orderRecord := range order.OrderRecords {
+		orderRecords[orderRecord.Id] = orderRecord
+		if len(data.Items) == 0 && orderRecord.GetShippableQuantity() > 0 {
+			shipment.Items = append(shipment.Items, &models.ShipmentItem{
+				Id:            uuid.New(),
+				ShipmentId:    shipment.Id,
+				OrderRecordId: orderRecord.Id,
+				ProductId:     orderRecord.ProductId,
+				Quantity:      orderRecord.GetShippableQuantity(),
+			})
+		}
+	}
+	for _, item := range data.Items {
+		id, _ := uuid.Parse(item.OrderRecordId)
+		orderRecord, ok := orderRecords[id]
+		if !ok {
+			return handleError(messages.ErrOrderRecordNotFound, "bad-request", http.StatusBadRequest)
+		}
+		if item.Quantity > orderRecord.GetShippableQuantity() {
+			return handleError(messages.ErrShipmentQuantityExceeded, "bad-request", http.StatusBadRequest)
+		}
+		shipment.Items = append(shipment.Items, &models.ShipmentItem{
+			Id:            uuid.New(),
+			ShipmentId:    shipment.Id,
+			OrderRecordId: orderRecord.Id,
+			ProductId:     orderRecord.ProductId,
+			Quantity:      item.Quantity,
+		})
+	}
+	if len(shipment.Items) == 0 {
+		return handleError(messages.ErrNothingToShip, "bad-request", http.StatusBadRequest)
+	}
+
+	for _, item := range shipment.Items {
+		product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": item.ProductId})
+		if err != nil {
+			return handleError(err, "server-error", http.StatusInternalServerError)
+		}
+		shipment.Weight += product.GetBillableWeight() * item.Quantity
+	}
+
+	// hold the quantities before booking the parcel so concurrent shipments cannot exceed them
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		return c.reserveShipment(ctx, tx, shipment, 1)
+	})
+	if err == messages.ErrShipmentQuantityExceeded {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	address := order.ShippingAddress
+	label, err := c.carrier.CreateLabel(ctx, &carriers.LabelRequest{
+		Reference: shipment.Id.String(),
+		Weight:    shipment.Weight,
+		To: carriers.Address{
+			Name:       address.FullName,
+			Phone:      address.Phone,
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+		},
+	})
+	if err != nil {
+		releaseErr := c.db.Transaction(ctx, func(tx *db.Database) error {
+			if err := c.reserveShipment(ctx, tx, shipment, -1); err != nil {
+				return err
+			}
+			return repo.NewShipmentRepo(tx).UpdateShipmentById(ctx, shipment.Id, &models.Shipment{Status: string(models.SHIPMENT_FAILED)})
+		})
+		if releaseErr != nil {
+			log.Err(releaseErr).Msgf("CreateShipment: could not release shipment %s: %v", shipment.Id, releaseErr)
+		}
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	shipment.TrackingNumber = label.TrackingNumber
+	shipment.LabelUrl = label.LabelUrl
+	shipment.Status = string(models.SHIPMENT_LABEL_CREATED)
+	err = c.shipmentRepo.UpdateShipmentById(ctx, shipment.Id, &models.Shipment{
+		TrackingNumber: shipment.TrackingNumber,
+		LabelUrl:       shipment.LabelUrl,
+		Status:         shipment.Status,
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if err := c.trackShipment(ctx, shipment); err != nil {
+		log.Err(err).Msgf("CreateShipment: could not track shipment %s: %v", shipment.TrackingNumber, err)
+	}
+	return handleSuccess(shipment, "success", "shipment created successfully", http.StatusCreated)
+}
+
+// GetOrderShipments gets the shipments of an order with their tracking events
+func (c *Controller) GetOrderShipments(ctx context.Context, orderId uuid.UUID, user *models.User) *models.ResponseObject {
+	fields := helpers.Map{"id": orderId}
+	if user.Role != string(models.USER_ROLE_ADMIN) {
+		fields["user_id"] = user.Id
+	}
+	order, err := c.orderRepo.GetOrderByFields(ctx, fields)
+	if err == messages.ErrOrderNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	shipments, err := c.shipmentRepo.GetShipmentsByFields(ctx, helpers.Map{"order_id": order.Id})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(shipments, "success", "shipments fetched successfully", http.StatusOK)
+}
+
+// RefreshShipmentTracking polls the carrier for a shipment's tracking events without waiting for the poller
+func (c *Controller) RefreshShipmentTracking(ctx context.Context, shipmentId uuid.UUID) *models.ResponseObject {
+	shipment, err := c.shipmentRepo.GetShipmentByFields(ctx, helpers.Map{"id": shipmentId})
+	if err == messages.ErrShipmentNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if shipment.Carrier != c.carrier.Name() {
+		return handleError(messages.ErrCarrierNotSupported, "bad-request", http.StatusBadRequest)
+	}
+
+	if err := c.trackShipment(ctx, shipment); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(shipment, "success", "shipment tracking refreshed successfully", http.StatusOK)
+}
+
+// ScanFakeShipment moves a parcel of the fake carrier, standing in for the carrier handling it
+func (c *Controller) ScanFakeShipment(ctx context.Context, trackingNumber string, status carriers.TrackingStatus, location string) *models.ResponseObject {
+	fakeCarrier, ok := c.carrier.(*carriers.FakeCarrier)
+	if !ok {
+		return handleError(messages.ErrCarrierNotSupported, "bad-request", http.StatusBadRequest)
+	}
+	if status == "" {
+		status = carriers.TRACKING_IN_TRANSIT
+	}
+	if err := fakeCarrier.Scan(trackingNumber, status, location); err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+
+	shipment, err := c.shipmentRepo.GetShipmentByFields(ctx, helpers.Map{"tracking_number": trackingNumber})
+	if err == messages.ErrShipmentNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if err := c.trackShipment(ctx, shipment); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(shipment, "success", fmt.Sprintf("shipment %s", shipment.Status), http.StatusOK)
+}
+
+// PollShipmentTracking polls the carrier for every shipment it has yet to deliver
+func (c *Controller) PollShipmentTracking(ctx context.Context) error {
+	shipments, err := c.shipmentRepo.GetShipmentsByFields(ctx, helpers.Map{
+		"carrier": c.carrier.Name(),
+		"status":  models.TRACKED_SHIPMENT_STATUSES,
+	})
+	if err != nil {
+		return err
+	}
+	for _, shipment := range shipments {
+		if err := c.trackShipment(ctx, shipment); err != nil {
+			log.Err(err).Msgf("PollShipmentTracking: could not track shipment %s: %v", shipment.TrackingNumber, err)
+		}
+	}
+	return nil
+}
+
+// reserveShipment holds (direction 1) or releases (direction -1) the quantities of a shipment's items
+func (c *Controller) reserveShipment(ctx context.Context, tx *db.Database, shipment *models.Shipment, direction int64) error {
+	orderRecordRepo := repo.NewOrderRecordRepo(tx)
+	for _, item := range shipment.Items {
+		if err := orderRecordRepo.AddShippedQuantity(ctx, item.OrderRecordId, direction*item.Quantity); err != nil {
+			return err
+		}
+	}
+	if direction > 0 {
+		_, err := repo.NewShipmentRepo(tx).CreateShipment(ctx, shipment)
+		return err
+	}
+	return nil
+}
+
+// trackShipment records the tracking events of a shipment it does not have yet, moves the shipment to
+// its latest status and advances its order when every item has left or been delivered
+func (c *Controller) trackShipment(ctx context.Context, shipment *models.Shipment) error {
+	events, err := c.carrier.Track(ctx, shipment.TrackingNumber)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+
+	return c.db.Transaction(ctx, func(tx *db.Database) error {
+		shipmentEventRepo := repo.NewShipmentEventRepo(tx)
+
+		recorded := false
+		for _, event := range events {
+			shipmentEvent := &models.ShipmentEvent{
+				Id:          uuid.New(),
+				ShipmentId:  shipment.Id,
+				Status:      string(event.Status),
+				Description: event.Description,
+				Location:    event.Location,
+				OccurredAt:  event.OccurredAt.UTC().Truncate(time.Microsecond),
+			}
+			created, err := shipmentEventRepo.CreateShipmentEvent(ctx, shipmentEvent)
+			if err != nil {
+				return err
+			}
+			if created {
+				recorded = true
+				shipment.Events = append(shipment.Events, shipmentEvent)
+			}
+		}
+		if !recorded {
+			return nil
+		}
+
+		update := &models.Shipment{}
+		for _, event := range events {
+			occurredAt := event.OccurredAt.UTC().Truncate(time.Microsecond)
+			switch event.Status {
+			case carriers.TRACKING_IN_TRANSIT, carriers.TRACKING_OUT_FOR_DELIVERY, carriers.TRACKING_DELIVERED:
+				if shipment.ShippedAt == nil {
+					shipment.ShippedAt = &occurredAt
+					update.ShippedAt = shipment.ShippedAt
+				}
+			}
+			if event.Status == carriers.TRACKING_DELIVERED && shipment.DeliveredAt == nil {
+				shipment.DeliveredAt = &occurredAt
+				update.DeliveredAt = shipment.DeliveredAt
+			}
+		}
+		if len(events) > 0 {
+			shipment.Status = string(events[len(events)-1].Status)
+		}
+		// delivered parcels stay delivered whatever the carrier reports afterwards
+		if shipment.IsDelivered() {
+			shipment.Status = string(models.SHIPMENT_DELIVERED)
+		}
+		update.Status = shipment.Status
+		if err := repo.NewShipmentRepo(tx).UpdateShipmentById(ctx, shipment.Id, update); err != nil {
+			return err
+		}
+		return c.advanceOrder(ctx, tx, shipment.OrderId)
+	})
+}
+
+// advanceOrder moves an order being processed to shipped once all its items have left with the carrier,
+// and to delivered once they have all been delivered
+func (c *Controller) advanceOrder(ctx context.Context, tx *db.Database, orderId uuid.UUID) error {
+	orderRepo := repo.NewOrderRepo(tx)
+	order, err := orderRepo.GetOrderForUpdate(ctx, helpers.Map{"id": orderId})
+	if err != nil {
+		return err
+	}
+	if order.Status != string(models.PROCESSING) && order.Status != string(models.SHIPPED) {
+		return nil
+	}
+
+	shipments, err := repo.NewShipmentRepo(tx).GetShipmentsByFields(ctx, helpers.Map{"order_id": orderId})
+	if err != nil {
+		return err
+	}
+	left, delivered := map[uuid.UUID]int64{}, map[uuid.UUID]int64{}
+	for _, shipment := range shipments {
+		for _, item := range shipment.Items {
+			if shipment.HasLeft() {
+				left[item.OrderRecordId] += item.Quantity
+			}
+			if shipment.IsDelivered() {
+				delivered[item.OrderRecordId] += item.Quantity
+			}
+		}
+	}
+	if len(left) == 0 {
+		return nil
+	}
+
+	allLeft, allDelivered := true, true
+	for _, orderRecord := range order.OrderRecords {
+		quantity := orderRecord.Quantity - orderRecord.RefundedQuantity
+		if left[orderRecord.Id] < quantity {
+			allLeft = false
+		}
+		if delivered[orderRecord.Id] < quantity {
+			allDelivered = false
+		}
+	}
+
+	var status models.OrderStatus
+	switch {
+	case allDelivered:
+		status = models.DELIVERED
+	case allLeft && order.Status == string(models.PROCESSING):
+		status = models.SHIPPED
+	default:
+		return nil
+	}
+
+	order.History.Data = append(order.History.Data, models.OrderHistory{
+		Note:      fmt.Sprintf("order %s", string(status)),
+		Status:    string(status),
+		CreatedAt: time.Now().UTC(),
+	})
+	return orderRepo.UpdateOrderById(ctx, orderId, &models.Order{
+		Status:  string(status),
+		History: order.History,
+	})
+}
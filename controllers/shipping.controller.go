@@ -0,0 +1,344 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// CreateShippingZone creates a new shipping zone
+func (c *Controller) CreateShippingZone(ctx context.Context, data *models.CreateShippingZoneDto) *models.ResponseObject {
+	zone := &models.ShippingZone{
+		Id:        uuid.New(),
+		Name:      data.Name,
+		Countries: toCountries(data.Countries),
+		Regions:   models.StringList(data.Regions),
+		Status:    string(models.SHIPPING_ACTIVE),
+	}
+	newZone, err := c.shippingZoneRepo.CreateShippingZone(ctx, zone)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(newZone, "success", "shipping zone created successfully", http.StatusCreated)
+}
+
+// GetAllShippingZones gets all shipping zones with their methods
+func (c *Controller) GetAllShippingZones(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject {
+	result, err := c.shippingZoneRepo.GetAllShippingZones(ctx, query)
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(result, "success", "shipping zones fetched successfully", http.StatusOK)
+}
+
+// GetSingleShippingZone gets a shipping zone by id with its methods
+func (c *Controller) GetSingleShippingZone(ctx context.Context, zoneId uuid.UUID) *models.ResponseObject {
+	zone, err := c.shippingZoneRepo.GetShippingZoneByFields(ctx, helpers.Map{"id": zoneId})
+	if err == messages.ErrShippingZoneNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(zone, "success", "shipping zone fetched successfully", http.StatusOK)
+}
+
+// UpdateShippingZone updates a shipping zone
+func (c *Controller) UpdateShippingZone(ctx context.Context, data *models.UpdateShippingZoneDto, zoneId uuid.UUID) *models.ResponseObject {
+	_, err := c.shippingZoneRepo.GetShippingZoneByFields(ctx, helpers.Map{"id": zoneId})
+	if err == messages.ErrShippingZoneNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	update := helpers.Map{}
+	if data.Name != nil {
+		update["name"] = *data.Name
+	}
+	if data.Countries != nil {
+		update["countries"] = toCountries(*data.Countries)
+	}
+	if data.Regions != nil {
+		update["regions"] = models.StringList(*data.Regions)
+	}
+	if data.Status != nil {
+		update["status"] = string(*data.Status)
+	}
+
+	if err := c.shippingZoneRepo.UpdateShippingZoneById(ctx, zoneId, update); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "shipping zone updated successfully", http.StatusOK)
+}
+
+// DeleteShippingZone deletes a shipping zone and its methods
+func (c *Controller) DeleteShippingZone(ctx context.Context, zoneId uuid.UUID) *models.ResponseObject {
+	if err := c.shippingZoneRepo.DeleteShippingZone(ctx, zoneId); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "shipping zone deleted successfully", http.StatusOK)
+}
+
+// CreateShippingMethod adds a shipping method to a zone
+func (c *Controller) CreateShippingMethod(ctx context.Context, data *models.CreateShippingMethodDto, zoneId uuid.UUID) *models.ResponseObject {
+	_, err := c.shippingZoneRepo.GetShippingZoneByFields(ctx, helpers.Map{"id": zoneId})
+	if err == messages.ErrShippingZoneNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if !data.Rates.IsValidFor(data.Type) {
+		return handleError(messages.ErrInvalidShippingRates, "bad-request", http.StatusBadRequest)
+	}
+
+	method := &models.ShippingMethod{
+		Id:              uuid.New(),
+		ZoneId:          zoneId,
+		Name:            data.Name,
+		Type:            string(data.Type),
+		Rates:           data.Rates,
+		Currency:        string(data.Currency),
+		MinDeliveryDays: data.MinDeliveryDays,
+		MaxDeliveryDays: data.MaxDeliveryDays,
+		Status:          string(models.SHIPPING_ACTIVE),
+	}
+	newMethod, err := c.shippingMethodRepo.CreateShippingMethod(ctx, method)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(newMethod, "success", "shipping method created successfully", http.StatusCreated)
+}
+
+// UpdateShippingMethod updates a shipping method, orders already placed keep the fee they were charged
+func (c *Controller) UpdateShippingMethod(ctx context.Context, data *models.UpdateShippingMethodDto, methodId uuid.UUID) *models.ResponseObject {
+	method, err := c.shippingMethodRepo.GetShippingMethodByFields(ctx, helpers.Map{"id": methodId})
+	if err == messages.ErrShippingMethodNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	update := helpers.Map{}
+	if data.Name != nil {
+		update["name"] = *data.Name
+	}
+	if data.Rates != nil {
+		if !data.Rates.IsValidFor(models.ShippingMethodType(method.Type)) {
+			return handleError(messages.ErrInvalidShippingRates, "bad-request", http.StatusBadRequest)
+		}
+		update["rates"] = *data.Rates
+	}
+	if data.MinDeliveryDays != nil {
+		method.MinDeliveryDays = *data.MinDeliveryDays
+		update["min_delivery_days"] = method.MinDeliveryDays
+	}
+	if data.MaxDeliveryDays != nil {
+		method.MaxDeliveryDays = *data.MaxDeliveryDays
+		update["max_delivery_days"] = method.MaxDeliveryDays
+	}
+	if method.MaxDeliveryDays < method.MinDeliveryDays {
+		return handleError(messages.ErrInvalidInput, "bad-request", http.StatusBadRequest)
+	}
+	if data.Status != nil {
+		update["status"] = string(*data.Status)
+	}
+
+	if err := c.shippingMethodRepo.UpdateShippingMethodById(ctx, methodId, update); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "shipping method updated successfully", http.StatusOK)
+}
+
+// DeleteShippingMethod deletes a shipping method
+func (c *Controller) DeleteShippingMethod(ctx context.Context, methodId uuid.UUID) *models.ResponseObject {
+	if err := c.shippingMethodRepo.DeleteShippingMethod(ctx, methodId); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "shipping method deleted successfully", http.StatusOK)
+}
+
+// QuoteShipping gets the shipping methods available for products to a destination, cheapest first
+func (c *Controller) QuoteShipping(ctx context.Context, data *models.ShippingQuoteDto) *models.ResponseObject {
+	order := &models.Order{
+		Currency: string(data.Currency),
+		Country:  strings.ToUpper(data.Country),
+		Region:   data.Region,
+	}
+	products := map[uuid.UUID]*models.Product{}
+	for _, item := range data.Items {
+		id, _ := uuid.Parse(item.ProductId)
+		product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": id})
+		if err != nil {
+			return handleOrderError(err)
+		}
+		products[product.Id] = product
+
+		price, discount, err := c.productPrice(ctx, order, product)
+		if err != nil {
+			return handleOrderError(err)
+		}
+		amount, err := price.Sub(discount)
+		if err != nil {
+			return handleOrderError(err)
+		}
+		order.OrderRecords = append(order.OrderRecords, &models.OrderRecord{
+			ProductId: product.Id,
+			Quantity:  item.Quantity,
+			Amount:    amount,
+			Currency:  order.Currency,
+		})
+	}
+
+	quotes, err := c.shippingQuotes(ctx, order, products)
+	if err != nil {
+		return handleOrderError(err)
+	}
+	return handleSuccess(quotes, "success", "shipping quoted successfully", http.StatusOK)
+}
+
+// setOrderShipping charges the order for the chosen shipping method to its shipping address,
+// or the cheapest one when none was chosen
+func (c *Controller) setOrderShipping(ctx context.Context, quote *orderQuote, shippingMethodId string) error {
+	order := quote.order
+	order.Fee = models.NewMoney(0, models.Currency(order.Currency))
+	if order.ShippingAddress == nil {
+		return nil
+	}
+
+	quotes, err := c.shippingQuotes(ctx, order, quote.products)
+	if err != nil {
+		return err
+	}
+	if len(quotes) == 0 {
+		return messages.ErrNoShippingMethod
+	}
+
+	chosen := quotes[0]
+	if shippingMethodId != "" {
+		chosen = nil
+		for _, shippingQuote := range quotes {
+			if shippingQuote.ShippingMethodId.String() == shippingMethodId {
+				chosen = shippingQuote
+			}
+		}
+		if chosen == nil {
+			return messages.ErrShippingMethodNotAvailable
+		}
+	}
+
+	order.ShippingMethodId = &chosen.ShippingMethodId
+	order.ShippingMethod = chosen.Name
+	order.Fee = chosen.Fee
+	return nil
+}
+
+// shippingQuotes gets the fee of every method that can ship an order to its destination, cheapest
+// first. Zones naming the destination region are used over zones covering the whole country.
+func (c *Controller) shippingQuotes(ctx context.Context, order *models.Order, products map[uuid.UUID]*models.Product) ([]*models.ShippingQuote, error) {
+	zones, err := c.shippingZoneRepo.GetActiveShippingZones(ctx, order.Country)
+	if err != nil {
+		return nil, err
+	}
+	var regionZones, countryZones []*models.ShippingZone
+	for _, zone := range zones {
+		if !zone.Covers(order.Country, order.Region) {
+			continue
+		}
+		if len(zone.Regions) > 0 {
+			regionZones = append(regionZones, zone)
+		} else {
+			countryZones = append(countryZones, zone)
+		}
+	}
+	if len(regionZones) > 0 {
+		zones = regionZones
+	} else {
+		zones = countryZones
+	}
+
+	var weight int64
+	for _, orderRecord := range order.OrderRecords {
+		weight += products[orderRecord.ProductId].GetBillableWeight() * orderRecord.Quantity
+	}
+	goodsTotal, err := order.GetTotalAmount()
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := []*models.ShippingQuote{}
+	for _, zone := range zones {
+		for _, method := range zone.Methods {
+			fee, ok, err := c.shippingFee(ctx, order, method, weight, goodsTotal)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			quotes = append(quotes, &models.ShippingQuote{
+				ShippingMethodId: method.Id,
+				Name:             method.Name,
+				Type:             method.Type,
+				Fee:              fee,
+				MinDeliveryDays:  method.MinDeliveryDays,
+				MaxDeliveryDays:  method.MaxDeliveryDays,
+			})
+		}
+	}
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].Fee.Amount < quotes[j].Fee.Amount
+	})
+	return quotes, nil
+}
+
+// shippingFee works out the fee of a method in the order currency, it is not ok when the
+// method cannot ship the weight
+func (c *Controller) shippingFee(ctx context.Context, order *models.Order, method *models.ShippingMethod, weight int64, goodsTotal models.Money) (models.Money, bool, error) {
+	currency := models.Currency(method.Currency)
+	fee := models.NewMoney(method.Rates.Amount, currency)
+
+	switch models.ShippingMethodType(method.Type) {
+	case models.SHIPPING_WEIGHT_BANDED:
+		amount, ok := method.Rates.BandFor(weight)
+		if !ok {
+			return models.Money{}, false, nil
+		}
+		fee = models.NewMoney(amount, currency)
+	case models.SHIPPING_FREE_OVER_THRESHOLD:
+		threshold, err := c.convert(ctx, order, models.NewMoney(method.Rates.Threshold, currency))
+		if err != nil {
+			return models.Money{}, false, err
+		}
+		if goodsTotal.Amount >= threshold.Amount {
+			fee = models.NewMoney(0, currency)
+		}
+	}
+
+	fee, err := c.convert(ctx, order, fee)
+	if err != nil {
+		return models.Money{}, false, err
+	}
+	return fee, true, nil
+}
+
+func toCountries(countries []string) models.StringList {
+	list := models.StringList{}
+	for _, country := range countries {
+		list = append(list, strings.ToUpper(country))
+	}
+	return list
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"e-commerce/models"
+)
+
+func TestShippingQuotes(t *testing.T) {
+	ngn := func(amount int64) models.Money { return models.NewMoney(amount, models.CURRENCY_NGN) }
+	method := func(name string, methodType models.ShippingMethodType, currency models.Currency, rates models.ShippingRates) *models.ShippingMethod {
+		return &models.ShippingMethod{Id: uuid.New(), Name: name, Type: string(methodType), Currency: string(currency), Rates: rates}
+	}
+	zone := func(regions models.StringList, methods ...*models.ShippingMethod) *models.ShippingZone {
+		return &models.ShippingZone{Id: uuid.New(), Countries: models.StringList{"NG"}, Regions: regions, Methods: methods}
+	}
+	// parcels up to 1kg and up to 5kg
+	banded := method("banded", models.SHIPPING_WEIGHT_BANDED, models.CURRENCY_NGN, models.ShippingRates{Bands: []models.WeightBand{{MaxWeight: 1000, Amount: 50000}, {MaxWeight: 5000, Amount: 150000}}})
+	freeOver := method("free", models.SHIPPING_FREE_OVER_THRESHOLD, models.CURRENCY_NGN, models.ShippingRates{Amount: 200000, Threshold: 1000000})
+	flat := method("flat", models.SHIPPING_FLAT, models.CURRENCY_NGN, models.ShippingRates{Amount: 100000})
+	// 1 USD is 1,500 NGN
+	flatUsd := method("flat-usd", models.SHIPPING_FLAT, models.CURRENCY_USD, models.ShippingRates{Amount: 500})
+	freeOverUsd := method("free-usd", models.SHIPPING_FREE_OVER_THRESHOLD, models.CURRENCY_USD, models.ShippingRates{Amount: 500, Threshold: 1000})
+
+	tests := []struct {
+		name    string
+		zones   []*models.ShippingZone
+		region  string
+		product models.Product
+		// quantity of the product at 1,000.00 each, and a discount on the order
+		quantity int64
+		discount int64
+		// the methods quoted and their fees, cheapest first
+		want []string
+	}{
+		{name: "weight on the edge of a band", zones: []*models.ShippingZone{zone(nil, banded)}, product: models.Product{Weight: 500}, quantity: 2, want: []string{"banded:50000"}},
+		{name: "weight just over a band", zones: []*models.ShippingZone{zone(nil, banded)}, product: models.Product{Weight: 1001}, quantity: 1, want: []string{"banded:150000"}},
+		{name: "weight on the edge of the last band", zones: []*models.ShippingZone{zone(nil, banded)}, product: models.Product{Weight: 1000}, quantity: 5, want: []string{"banded:150000"}},
+		{name: "weight over the last band", zones: []*models.ShippingZone{zone(nil, banded, flat)}, product: models.Product{Weight: 5001}, quantity: 1, want: []string{"flat:100000"}},
+		{name: "bulky parcels are charged by volume", zones: []*models.ShippingZone{zone(nil, banded)}, product: models.Product{Weight: 100, Length: 10, Width: 10, Height: 10}, quantity: 6, want: []string{"banded:150000"}},
+		{name: "under the free shipping threshold", zones: []*models.ShippingZone{zone(nil, freeOver)}, quantity: 9, want: []string{"free:200000"}},
+		{name: "on the free shipping threshold", zones: []*models.ShippingZone{zone(nil, freeOver)}, quantity: 10, want: []string{"free:0"}},
+		{name: "under the threshold after discounts", zones: []*models.ShippingZone{zone(nil, freeOver)}, quantity: 10, discount: 1, want: []string{"free:200000"}},
+		{name: "fee in another currency", zones: []*models.ShippingZone{zone(nil, flatUsd)}, quantity: 1, want: []string{"flat-usd:750000"}},
+		{name: "under a threshold in another currency", zones: []*models.ShippingZone{zone(nil, freeOverUsd)}, quantity: 14, want: []string{"free-usd:750000"}},
+		{name: "on a threshold in another currency", zones: []*models.ShippingZone{zone(nil, freeOverUsd)}, quantity: 15, want: []string{"free-usd:0"}},
+		{name: "cheapest first", zones: []*models.ShippingZone{zone(nil, freeOver, flatUsd, flat, banded)}, product: models.Product{Weight: 100}, quantity: 1, want: []string{"banded:50000", "flat:100000", "free:200000", "flat-usd:750000"}},
+		{name: "zones of the region over the country", zones: []*models.ShippingZone{zone(nil, flat), zone(models.StringList{"Lagos"}, freeOver)}, region: "Lagos", quantity: 1, want: []string{"free:200000"}},
+		{name: "zones of the country outside the regions", zones: []*models.ShippingZone{zone(nil, flat), zone(models.StringList{"Lagos"}, freeOver)}, region: "Abuja", quantity: 1, want: []string{"flat:100000"}},
+		{name: "no zone for the destination", zones: []*models.ShippingZone{zone(models.StringList{"Lagos"}, flat)}, region: "Abuja", quantity: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := tt.product
+			product.Id = uuid.New()
+			order := &models.Order{
+				Currency:      string(models.CURRENCY_NGN),
+				Country:       "NG",
+				Region:        tt.region,
+				ExchangeRates: models.ExchangeRateSnapshot{"USD": "1500"},
+				OrderRecords:  []*models.OrderRecord{{Id: uuid.New(), ProductId: product.Id, Amount: ngn(100000), Quantity: tt.quantity, Currency: string(models.CURRENCY_NGN)}},
+			}
+			if tt.discount > 0 {
+				order.Discounts = []*models.OrderDiscount{{Amount: ngn(tt.discount)}}
+			}
+			c := &Controller{shippingZoneRepo: &fakeShippingZoneRepo{zones: tt.zones}}
+
+			quotes, err := c.shippingQuotes(context.Background(), order, map[uuid.UUID]*models.Product{product.Id: &product})
+			if err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			got := []string{}
+			for _, quote := range quotes {
+				if quote.Fee.Currency != models.CURRENCY_NGN {
+					t.Errorf("%s fee is in %s", quote.Name, quote.Fee.Currency)
+				}
+				got = append(got, fmt.Sprintf("%s:%d", quote.Name, quote.Fee.Amount))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("quotes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package controllers
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// CreateTaxRate creates a new tax rate
+func (c *Controller) CreateTaxRate(ctx context.Context, data *models.CreateTaxRateDto) *models.ResponseObject {
+	rate, ok := models.ParseTaxRate(data.Rate)
+	if !ok {
+		return handleError(messages.ErrInvalidTaxRate, "bad-request", http.StatusBadRequest)
+	}
+
+	country := strings.ToUpper(data.Country)
+	existingRate, err := c.taxRateRepo.GetTaxRateByFields(ctx, helpers.Map{"country": country, "region": data.Region, "tax_class": string(data.TaxClass)})
+	if err != nil && err != messages.ErrTaxRateNotFound {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if existingRate != nil {
+		return handleError(messages.ErrTaxRateAlreadyExists, "bad-request", http.StatusBadRequest)
+	}
+
+	taxRate := &models.TaxRate{
+		Id:        uuid.New(),
+		Name:      data.Name,
+		Country:   country,
+		Region:    data.Region,
+		TaxClass:  string(data.TaxClass),
+		Rate:      rate.FloatString(models.TAX_RATE_PRECISION),
+		Inclusive: data.Inclusive,
+		Status:    string(models.TAX_RATE_ACTIVE),
+	}
+	newRate, err := c.taxRateRepo.CreateTaxRate(ctx, taxRate)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(newRate, "success", "tax rate created successfully", http.StatusCreated)
+}
+
+// GetAllTaxRates gets all tax rates
+func (c *Controller) GetAllTaxRates(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject {
+	result, err := c.taxRateRepo.GetAllTaxRates(ctx, query)
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(result, "success", "tax rates fetched successfully", http.StatusOK)
+}
+
+// GetSingleTaxRate gets a tax rate by id
+func (c *Controller) GetSingleTaxRate(ctx context.Context, taxRateId uuid.UUID) *models.ResponseObject {
+	taxRate, err := c.taxRateRepo.GetTaxRateByFields(ctx, helpers.Map{"id": taxRateId})
+	if err == messages.ErrTaxRateNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(taxRate, "success", "tax rate fetched successfully", http.StatusOK)
+}
+
+// UpdateTaxRate updates a tax rate, orders already placed keep the rate they were taxed at
+func (c *Controller) UpdateTaxRate(ctx context.Context, data *models.UpdateTaxRateDto, taxRateId uuid.UUID) *models.ResponseObject {
+	_, err := c.taxRateRepo.GetTaxRateByFields(ctx, helpers.Map{"id": taxRateId})
+	if err == messages.ErrTaxRateNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	update := helpers.Map{}
+	if data.Name != nil {
+		update["name"] = *data.Name
+	}
+	if data.Rate != nil {
+		rate, ok := models.ParseTaxRate(*data.Rate)
+		if !ok {
+			return handleError(messages.ErrInvalidTaxRate, "bad-request", http.StatusBadRequest)
+		}
+		update["rate"] = rate.FloatString(models.TAX_RATE_PRECISION)
+	}
+	if data.Inclusive != nil {
+		update["inclusive"] = *data.Inclusive
+	}
+	if data.Status != nil {
+		update["status"] = string(*data.Status)
+	}
+
+	if err := c.taxRateRepo.UpdateTaxRateById(ctx, taxRateId, update); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "tax rate updated successfully", http.StatusOK)
+}
+
+// SetTaxExemption flags a customer as exempt from tax, or removes the flag
+func (c *Controller) SetTaxExemption(ctx context.Context, userId uuid.UUID, data *models.SetTaxExemptionDto) *models.ResponseObject {
+	_, err := c.userRepo.GetUserByFields(ctx, helpers.Map{"id": userId})
+	if err == messages.ErrUserNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	if err := c.userRepo.UpdateUserById(ctx, userId, helpers.Map{"tax_exempt": data.TaxExempt}); err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "tax exemption updated successfully", http.StatusOK)
+}
+
+// GetTaxReport sums the tax collected on paid orders by period and tax rate
+func (c *Controller) GetTaxReport(ctx context.Context, query *models.TaxReportDto) *models.ResponseObject {
+	if query.Period == "" {
+		query.Period = models.TAX_REPORT_MONTH
+	}
+
+	rows, err := c.orderTaxRepo.GetTaxReport(ctx, query)
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	report := &models.TaxReport{
+		From:   query.From,
+		To:     query.To,
+		Period: query.Period,
+		Rows:   rows,
+	}
+	return handleSuccess(report, "success", "tax report fetched successfully", http.StatusOK)
+}
+
+// applyTaxes adds tax lines to the order records at the rates of the order country and region,
+// on the amounts left after discounts
+func (c *Controller) applyTaxes(ctx context.Context, quote *orderQuote, user *models.User) error {
+	order := quote.order
+	if order.Country == "" {
+		return nil
+	}
+	if user.TaxExempt {
+		order.TaxExempt = true
+		return nil
+	}
+
+	rates, err := c.taxRateRepo.GetActiveTaxRates(ctx, order.Country)
+	if err != nil {
+		return err
+	}
+	taxableAmounts, err := taxableAmounts(order)
+	if err != nil {
+		return err
+	}
+
+	for _, orderRecord := range order.OrderRecords {
+		rate := matchTaxRate(rates, order.Region, quote.products[orderRecord.ProductId].TaxClass)
+		if rate == nil {
+			continue
+		}
+		taxableAmount := taxableAmounts[orderRecord.Id]
+		amount, err := rate.TaxOn(taxableAmount)
+		if err != nil {
+			return err
+		}
+
+		orderRecord.Tax = amount
+		orderRecord.TaxLines = append(orderRecord.TaxLines, &models.OrderTax{
+			Id:            uuid.New(),
+			OrderId:       order.Id,
+			OrderRecordId: orderRecord.Id,
+			TaxRateId:     rate.Id,
+			Name:          rate.Name,
+			Rate:          rate.Rate,
+			Inclusive:     rate.Inclusive,
+			TaxableAmount: taxableAmount,
+			Amount:        amount,
+			Currency:      order.Currency,
+		})
+	}
+
+	order.Tax, err = order.GetTaxTotal()
+	return err
+}
+
+// matchTaxRate gets the rate for a tax class in a region, rates are ordered region specific first
+func matchTaxRate(rates []*models.TaxRate, region string, taxClass string) *models.TaxRate {
+	for _, rate := range rates {
+		if rate.Matches(region, taxClass) {
+			return rate
+		}
+	}
+	return nil
+}
+
+// taxableAmounts gets the amount of each order record after its own discount lines and its share
+// of the discount lines on the whole order, shared by amount with the rounding left on the last record
+func taxableAmounts(order *models.Order) (map[uuid.UUID]models.Money, error) {
+	amounts := map[uuid.UUID]models.Money{}
+	orderDiscount := models.NewMoney(0, models.Currency(order.Currency))
+	subTotal := models.NewMoney(0, models.Currency(order.Currency))
+
+	for _, orderRecord := range order.OrderRecords {
+		amount, err := orderRecord.GetTotal()
+		if err != nil {
+			return nil, err
+		}
+		amounts[orderRecord.Id] = amount
+	}
+	for _, discount := range order.Discounts {
+		var err error
+		if discount.OrderRecordId == nil {
+			orderDiscount, err = orderDiscount.Add(discount.Amount)
+		} else {
+			amounts[*discount.OrderRecordId], err = amounts[*discount.OrderRecordId].Sub(discount.Amount)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, amount := range amounts {
+		var err error
+		if subTotal, err = subTotal.Add(amount); err != nil {
+			return nil, err
+		}
+	}
+	if !orderDiscount.IsPositive() || !subTotal.IsPositive() {
+		return amounts, nil
+	}
+
+	remaining := orderDiscount
+	for i, orderRecord := range order.OrderRecords {
+		amount := amounts[orderRecord.Id]
+		share := remaining
+		if i < len(order.OrderRecords)-1 {
+			var err error
+			share, err = orderDiscount.MulRat(big.NewRat(amount.Amount, subTotal.Amount), models.ROUND_HALF_UP)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var err error
+		if share, err = share.Min(amount); err != nil {
+			return nil, err
+		}
+		if share, err = share.Min(remaining); err != nil {
+			return nil, err
+		}
+		if amounts[orderRecord.Id], err = amount.Sub(share); err != nil {
+			return nil, err
+		}
+		if remaining, err = remaining.Sub(share); err != nil {
+			return nil, err
+		}
+	}
+	return amounts, nil
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/models"
+)
+
+func TestTaxableAmounts(t *testing.T) {
+	ngn := func(amount int64) models.Money { return models.NewMoney(amount, models.CURRENCY_NGN) }
+	record := func(amount, quantity int64) *models.OrderRecord {
+		return &models.OrderRecord{Id: uuid.New(), Amount: ngn(amount), Quantity: quantity, Currency: string(models.CURRENCY_NGN)}
+	}
+	orderDiscount := func(amount int64) *models.OrderDiscount {
+		return &models.OrderDiscount{Amount: ngn(amount)}
+	}
+	recordDiscount := func(orderRecord *models.OrderRecord, amount int64) *models.OrderDiscount {
+		return &models.OrderDiscount{OrderRecordId: &orderRecord.Id, Amount: ngn(amount)}
+	}
+
+	tests := []struct {
+		name      string
+		records   []*models.OrderRecord
+		discounts func(records []*models.OrderRecord) []*models.OrderDiscount
+		want      []int64
+		err       error
+	}{
+		{
+			name:    "no discounts",
+			records: []*models.OrderRecord{record(500, 2), record(300, 1)},
+			want:    []int64{1000, 300},
+		},
+		{
+			name:    "record discount stays on its record",
+			records: []*models.OrderRecord{record(500, 2), record(300, 1)},
+			discounts: func(records []*models.OrderRecord) []*models.OrderDiscount {
+				return []*models.OrderDiscount{recordDiscount(records[0], 100)}
+			},
+			want: []int64{900, 300},
+		},
+		{
+			name:    "order discount is shared by amount",
+			records: []*models.OrderRecord{record(500, 2), record(300, 1)},
+			discounts: func(records []*models.OrderRecord) []*models.OrderDiscount {
+				return []*models.OrderDiscount{orderDiscount(130)}
+			},
+			want: []int64{900, 270},
+		},
+		{
+			name:    "rounding is left on the last record",
+			records: []*models.OrderRecord{record(100, 1), record(100, 1), record(100, 1)},
+			discounts: func(records []*models.OrderRecord) []*models.OrderDiscount {
+				return []*models.OrderDiscount{orderDiscount(100)}
+			},
+			want: []int64{67, 67, 66},
+		},
+		{
+			name:    "order discount is shared after record discounts",
+			records: []*models.OrderRecord{record(500, 2), record(200, 1)},
+			discounts: func(records []*models.OrderRecord) []*models.OrderDiscount {
+				return []*models.OrderDiscount{recordDiscount(records[0], 200), orderDiscount(100)}
+			},
+			want: []int64{720, 180},
+		},
+		{
+			name:    "order discount over the subtotal leaves nothing taxable",
+			records: []*models.OrderRecord{record(100, 1), record(100, 1)},
+			discounts: func(records []*models.OrderRecord) []*models.OrderDiscount {
+				return []*models.OrderDiscount{orderDiscount(500)}
+			},
+			want: []int64{0, 0},
+		},
+		{
+			name:    "fully discounted records take no order discount",
+			records: []*models.OrderRecord{record(100, 1), record(300, 1)},
+			discounts: func(records []*models.OrderRecord) []*models.OrderDiscount {
+				return []*models.OrderDiscount{recordDiscount(records[0], 100), orderDiscount(60)}
+			},
+			want: []int64{0, 240},
+		},
+		{
+			name:    "record discount over the record",
+			records: []*models.OrderRecord{record(100, 1)},
+			discounts: func(records []*models.OrderRecord) []*models.OrderDiscount {
+				return []*models.OrderDiscount{recordDiscount(records[0], 101)}
+			},
+			err: messages.ErrNegativeMoney,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &models.Order{Currency: string(models.CURRENCY_NGN), OrderRecords: tt.records}
+			if tt.discounts != nil {
+				order.Discounts = tt.discounts(tt.records)
+			}
+			amounts, err := taxableAmounts(order)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			for i, want := range tt.want {
+				if got := amounts[tt.records[i].Id]; got != ngn(want) {
+					t.Errorf("record %d = %v, want %v", i, got, ngn(want))
+				}
+			}
+		})
+	}
+}
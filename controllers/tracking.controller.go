@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// TrackOrder gets the status timeline of an order by its tracking code for whoever knows the
+// customer's email. A wrong email is reported as a missing order so codes cannot be confirmed.
+func (c *Controller) TrackOrder(ctx context.Context, trackingCode string, data *models.TrackOrderDto) *models.ResponseObject {
+	order, err := c.orderRepo.GetOrderByFields(ctx, helpers.Map{"tracking_code": trackingCode})
+	if err == messages.ErrOrderNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	user, err := c.userRepo.GetUserByFields(ctx, helpers.Map{"id": order.UserId})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	email := strings.ToLower(strings.TrimSpace(data.Email))
+	if subtle.ConstantTimeCompare([]byte(email), []byte(strings.ToLower(user.Email))) != 1 {
+		return handleError(messages.ErrOrderNotFound, "bad-request", http.StatusBadRequest)
+	}
+
+	return handleSuccess(order.Tracking(), "success", "order tracked successfully", http.StatusOK)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 
 	"e-commerce/common/messages"
 	"e-commerce/helpers"
@@ -91,6 +92,13 @@ func (c *Controller) Login(ctx context.Context, data *models.SignInDto) *models.
 			Error:   err,
 		}
 	}
+	// carry over whatever was added to the cart before logging in
+	if data.CartToken != nil {
+		if err := c.mergeGuestCart(ctx, *data.CartToken, user); err != nil {
+			log.Err(err).Msgf("Login::mergeGuestCart error: %v", err)
+		}
+	}
+
 	authUser := &models.AuthenticatedUser{
 		User:        user,
 		AccessToken: token,
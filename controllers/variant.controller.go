@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// CreateProductVariant adds a variant to a product, with a value for each of the product's options
+func (c *Controller) CreateProductVariant(ctx context.Context, productId uuid.UUID, data *models.CreateVariantDto, user *models.User) *models.ResponseObject {
+	return c.createProductVariant(ctx, productId, data, &models.InventoryMovement{Type: string(models.INVENTORY_INITIAL), ActorId: &user.Id})
+}
+
+// createProductVariant adds a variant to a product, recording its stock as a stock movement
+func (c *Controller) createProductVariant(ctx context.Context, productId uuid.UUID, data *models.CreateVariantDto, stock *models.InventoryMovement) *models.ResponseObject {
+	product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": productId})
+	if err == messages.ErrProductNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	variant := toProductVariant(data, product.Currency)
+	if err := c.checkVariant(ctx, product, variant); err != nil {
+		return handleVariantError(err)
+	}
+	variant.ProductId = product.Id
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		if err := repo.NewProductVariantRepo(tx).CreateProductVariants(ctx, []*models.ProductVariant{variant}); err != nil {
+			return err
+		}
+		return recordStock(ctx, tx, stock, product.Id, &variant.Id, variant.AvailableQuantity)
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(variant, "success", "product variant created successfully", http.StatusCreated)
+}
+
+// UpdateProductVariant updates a variant of a product
+func (c *Controller) UpdateProductVariant(ctx context.Context, productId uuid.UUID, variantId uuid.UUID, data *models.UpdateVariantDto, user *models.User) *models.ResponseObject {
+	return c.updateProductVariant(ctx, productId, variantId, data, &models.InventoryMovement{Type: string(models.INVENTORY_ADJUSTMENT), Reason: data.QuantityReason, ActorId: &user.Id})
+}
+
+// updateProductVariant updates a variant of a product, a new quantity is recorded as a stock movement
+func (c *Controller) updateProductVariant(ctx context.Context, productId uuid.UUID, variantId uuid.UUID, data *models.UpdateVariantDto, stock *models.InventoryMovement) *models.ResponseObject {
+	product, err := c.productRepo.GetProductByFields(ctx, helpers.Map{"id": productId})
+	if err == messages.ErrProductNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	existing := product.GetVariant(variantId)
+	if existing == nil {
+		return handleError(messages.ErrProductVariantNotFound, "bad-request", http.StatusBadRequest)
+	}
+
+	variant := *existing
+	update := helpers.Map{}
+	if data.Sku != nil {
+		variant.Sku = *data.Sku
+		update["sku"] = variant.Sku
+	}
+	if data.Options != nil {
+		variant.Options = models.VariantOptions(*data.Options)
+		update["options"] = variant.Options
+	}
+	if data.ResetPrice {
+		variant.Price, variant.Discount = nil, nil
+		update["price"], update["discount"] = nil, nil
+	}
+	if data.Price != nil {
+		price := models.NewMoney(*data.Price, models.Currency(product.Currency))
+		variant.Price = &price
+		update["price"] = price
+	}
+	if data.Discount != nil {
+		discount := models.NewMoney(*data.Discount, models.Currency(product.Currency))
+		variant.Discount = &discount
+		update["discount"] = discount
+	}
+	if data.Status != nil {
+		update["status"] = string(*data.Status)
+	}
+	if data.Position != nil {
+		update["position"] = *data.Position
+	}
+
+	if err := c.checkVariant(ctx, product, &variant); err != nil {
+		return handleVariantError(err)
+	}
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		if err := repo.NewProductVariantRepo(tx).UpdateProductVariantById(ctx, variantId, update); err != nil {
+			return err
+		}
+		if data.Quantity == nil {
+			return nil
+		}
+		return setStock(ctx, tx, stock, productId, &variantId, *data.Quantity)
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "product variant updated successfully", http.StatusOK)
+}
+
+// DeleteProductVariant deletes a variant of a product that was never ordered, its stock history is kept
+func (c *Controller) DeleteProductVariant(ctx context.Context, productId uuid.UUID, variantId uuid.UUID) *models.ResponseObject {
+	_, err := c.productVariantRepo.GetProductVariantByFields(ctx, helpers.Map{"id": variantId, "product_id": productId})
+	if err == messages.ErrProductVariantNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	ordered, err := c.orderRecordRepo.CountOrderRecords(ctx, helpers.Map{"variant_id": variantId})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if ordered > 0 {
+		return handleError(messages.ErrProductVariantInUse, "bad-request", http.StatusBadRequest)
+	}
+
+	err = c.productVariantRepo.DeleteProductVariant(ctx, variantId)
+	if err == messages.ErrProductVariantInUse {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "product variant deleted successfully", http.StatusOK)
+}
+
+// checkVariant checks that a new or changed variant fits the product and that no other variant has its SKU
+func (c *Controller) checkVariant(ctx context.Context, product *models.Product, variant *models.ProductVariant) error {
+	if err := fitVariant(product, product.Variants, variant); err != nil {
+		return err
+	}
+	existing, err := c.productVariantRepo.GetProductVariantByFields(ctx, helpers.Map{"sku": variant.Sku})
+	if err != nil && err != messages.ErrProductVariantNotFound {
+		return err
+	}
+	if existing != nil && existing.Id != variant.Id {
+		return messages.ErrVariantWithSkuAlreadyExists
+	}
+	return nil
+}
+
+// fitVariant checks that a variant has a value for each of the product's options, does not repeat
+// the options of another variant and is not discounted below zero
+func fitVariant(product *models.Product, others []*models.ProductVariant, variant *models.ProductVariant) error {
+	if !variant.Options.FitsOptions(product.Options) {
+		return messages.ErrInvalidVariantOptions
+	}
+	for _, other := range others {
+		if other.Id != variant.Id && other.Options.Equals(variant.Options) {
+			return messages.ErrDuplicateVariant
+		}
+	}
+	priced := product.ForVariant(variant)
+	if priced.Discount.Amount > priced.Price.Amount {
+		return messages.ErrDiscountExceedsPrice
+	}
+	return nil
+}
+
+// toProductOptions builds the options of a product, one per name
+func toProductOptions(data []models.ProductOptionDto) ([]*models.ProductOption, error) {
+	options := []*models.ProductOption{}
+	seen := map[string]bool{}
+	for i, option := range data {
+		if seen[option.Name] {
+			return nil, messages.ErrDuplicateProductOption
+		}
+		seen[option.Name] = true
+		options = append(options, &models.ProductOption{
+			Id:       uuid.New(),
+			Name:     option.Name,
+			Values:   models.StringList(option.Values),
+			Position: int64(i),
+		})
+	}
+	return options, nil
+}
+
+// toProductVariant builds a variant from its data, priced in the product currency
+func toProductVariant(data *models.CreateVariantDto, currency string) *models.ProductVariant {
+	variant := &models.ProductVariant{
+		Id:                uuid.New(),
+		Sku:               data.Sku,
+		Options:           models.VariantOptions(data.Options),
+		Currency:          currency,
+		AvailableQuantity: data.Quantity,
+		Status:            string(models.IN_STOCK),
+		Position:          data.Position,
+	}
+	if data.Price != nil {
+		price := models.NewMoney(*data.Price, models.Currency(currency))
+		variant.Price = &price
+	}
+	if data.Discount != nil {
+		discount := models.NewMoney(*data.Discount, models.Currency(currency))
+		variant.Discount = &discount
+	}
+	return variant
+}
+
+// toProductVariants builds the variants of a new product, checking each against its options and the others
+func (c *Controller) toProductVariants(ctx context.Context, data []models.CreateVariantDto, product *models.Product) ([]*models.ProductVariant, error) {
+	variants := []*models.ProductVariant{}
+	for i := range data {
+		variant := toProductVariant(&data[i], product.Currency)
+		for _, other := range variants {
+			if other.Sku == variant.Sku {
+				return nil, messages.ErrVariantWithSkuAlreadyExists
+			}
+		}
+		if err := fitVariant(product, variants, variant); err != nil {
+			return nil, err
+		}
+		existing, err := c.productVariantRepo.GetProductVariantByFields(ctx, helpers.Map{"sku": variant.Sku})
+		if err != nil && err != messages.ErrProductVariantNotFound {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, messages.ErrVariantWithSkuAlreadyExists
+		}
+		variants = append(variants, variant)
+	}
+	return variants, nil
+}
+
+// productVariant picks the variant of a product being bought, products sold through variants need one
+func productVariant(product *models.Product, variantId *uuid.UUID) (*models.ProductVariant, error) {
+	if variantId == nil {
+		if product.HasVariants() {
+			return nil, messages.ErrVariantRequired
+		}
+		return nil, nil
+	}
+	variant := product.GetVariant(*variantId)
+	if variant == nil {
+		return nil, messages.ErrProductVariantNotFound
+	}
+	return variant, nil
+}
+
+func handleVariantError(err error) *models.ResponseObject {
+	switch err {
+	case messages.ErrInvalidVariantOptions,
+		messages.ErrDuplicateVariant,
+		messages.ErrDiscountExceedsPrice,
+		messages.ErrVariantWithSkuAlreadyExists:
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	return handleError(err, "server-error", http.StatusInternalServerError)
+}
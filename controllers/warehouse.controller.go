@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// CreateWarehouse creates a new warehouse, a default warehouse takes over from the current one
+func (c *Controller) CreateWarehouse(ctx context.Context, data *models.CreateWarehouseDto) *models.ResponseObject {
+	warehouse := &models.Warehouse{
+		Id:        uuid.New(),
+		Name:      data.Name,
+		Code:      data.Code,
+		Country:   strings.ToUpper(data.Country),
+		Region:    data.Region,
+		Priority:  data.Priority,
+		Status:    string(models.WAREHOUSE_ACTIVE),
+		IsDefault: data.IsDefault,
+	}
+	err := c.db.Transaction(ctx, func(tx *db.Database) error {
+		warehouseRepo := repo.NewWarehouseRepo(tx)
+		if warehouse.IsDefault {
+			if err := warehouseRepo.UnsetDefaultWarehouse(ctx); err != nil {
+				return err
+			}
+		}
+		_, err := warehouseRepo.CreateWarehouse(ctx, warehouse)
+		return err
+	})
+	if err == messages.ErrWarehouseWithCodeAlreadyExists {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(warehouse, "success", "warehouse created successfully", http.StatusCreated)
+}
+
+// GetAllWarehouses gets all warehouses
+func (c *Controller) GetAllWarehouses(ctx context.Context, query *models.APIPagingDto) *models.ResponseObject {
+	result, err := c.warehouseRepo.GetAllWarehouses(ctx, query)
+	if err == messages.ErrInvalidFilter {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(result, "success", "warehouses fetched successfully", http.StatusOK)
+}
+
+// GetSingleWarehouse gets a warehouse by id
+func (c *Controller) GetSingleWarehouse(ctx context.Context, warehouseId uuid.UUID) *models.ResponseObject {
+	warehouse, err := c.warehouseRepo.GetWarehouseByFields(ctx, helpers.Map{"id": warehouseId})
+	if err == messages.ErrWarehouseNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(warehouse, "success", "warehouse fetched successfully", http.StatusOK)
+}
+
+// UpdateWarehouse updates a warehouse. The default warehouse cannot be deactivated, and a warehouse
+// made the default is activated.
+func (c *Controller) UpdateWarehouse(ctx context.Context, data *models.UpdateWarehouseDto, warehouseId uuid.UUID) *models.ResponseObject {
+	warehouse, err := c.warehouseRepo.GetWarehouseByFields(ctx, helpers.Map{"id": warehouseId})
+	if err == messages.ErrWarehouseNotFound {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	if data.Status != nil && *data.Status == models.WAREHOUSE_INACTIVE && (warehouse.IsDefault || data.IsDefault) {
+		return handleError(messages.ErrDefaultWarehouseRequired, "bad-request", http.StatusBadRequest)
+	}
+
+	update := helpers.Map{}
+	if data.Name != nil {
+		update["name"] = *data.Name
+	}
+	if data.Country != nil {
+		update["country"] = strings.ToUpper(*data.Country)
+	}
+	if data.Region != nil {
+		update["region"] = *data.Region
+	}
+	if data.Priority != nil {
+		update["priority"] = *data.Priority
+	}
+	if data.Status != nil {
+		update["status"] = string(*data.Status)
+	}
+	makeDefault := data.IsDefault && !warehouse.IsDefault
+	if makeDefault {
+		update["is_default"] = true
+		update["status"] = string(models.WAREHOUSE_ACTIVE)
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		warehouseRepo := repo.NewWarehouseRepo(tx)
+		if makeDefault {
+			if err := warehouseRepo.UnsetDefaultWarehouse(ctx); err != nil {
+				return err
+			}
+		}
+		return warehouseRepo.UpdateWarehouseById(ctx, warehouseId, update)
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+	return handleSuccess(nil, "success", "warehouse updated successfully", http.StatusOK)
+}
+
+// stockKey is the stock of a product, or of one of its variants, in a warehouse
+type stockKey struct {
+	warehouseId uuid.UUID
+	productId   uuid.UUID
+	variantId   uuid.UUID
+}
+
+// allocateOrder picks the active warehouses that fulfil each record of an order with the allocation
+// strategy, from stock that is not reserved for other orders. Records are allocated in turn, so a record
+// only gets the stock the records before it left.
+func (c *Controller) allocateOrder(ctx context.Context, order *models.Order) error {
+	warehouses, err := c.warehouseRepo.GetActiveWarehouses(ctx)
+	if err != nil {
+		return err
+	}
+	productIds := []uuid.UUID{}
+	for _, orderRecord := range order.OrderRecords {
+		if !slices.Contains(productIds, orderRecord.ProductId) {
+			productIds = append(productIds, orderRecord.ProductId)
+		}
+	}
+	stocks, err := c.warehouseStockRepo.GetWarehouseStocks(ctx, productIds)
+	if err != nil {
+		return err
+	}
+	return allocate(order, warehouses, stocks, c.allocationStrategy)
+}
+
+// allocate allocates each record of an order to warehouses, given by priority, with the strategy
+func allocate(order *models.Order, warehouses []*models.Warehouse, stocks []*models.WarehouseStock, strategy models.AllocationStrategy) error {
+	available := map[stockKey]int64{}
+	for _, stock := range stocks {
+		available[stockKey{stock.WarehouseId, stock.ProductId, deref(stock.VariantId)}] = stock.Quantity - stock.ReservedQuantity
+	}
+
+	// warehouses are by priority, the nearest ones are moved first unless allocating by priority. Orders
+	// without a shipping address are near none of them and keep the priority order.
+	if strategy != models.ALLOCATE_PRIORITY {
+		slices.SortStableFunc(warehouses, func(a, b *models.Warehouse) int {
+			return b.Proximity(order.Country, order.Region) - a.Proximity(order.Country, order.Region)
+		})
+	}
+
+	for _, orderRecord := range order.OrderRecords {
+		orderRecord.Allocations = nil
+		left := orderRecord.Quantity
+		for _, warehouse := range warehouses {
+			key := stockKey{warehouse.Id, orderRecord.ProductId, deref(orderRecord.VariantId)}
+			quantity := min(left, available[key])
+			// only a split takes part of a record from a warehouse
+			if quantity <= 0 || (quantity < left && strategy != models.ALLOCATE_SPLIT) {
+				continue
+			}
+			orderRecord.Allocations = append(orderRecord.Allocations, &models.OrderAllocation{
+				Id:            uuid.New(),
+				OrderRecordId: orderRecord.Id,
+				WarehouseId:   warehouse.Id,
+				Quantity:      quantity,
+			})
+			available[key] -= quantity
+			left -= quantity
+			if left == 0 {
+				break
+			}
+		}
+		if left > 0 {
+			return messages.ErrInsufficientStock
+		}
+	}
+	return nil
+}
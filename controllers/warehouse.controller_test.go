@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/models"
+)
+
+func TestAllocate(t *testing.T) {
+	product, variant := uuid.New(), uuid.New()
+	// by priority: lagos, abuja, then accra
+	lagos := &models.Warehouse{Id: uuid.New(), Code: "LOS", Country: "NG", Region: "Lagos"}
+	abuja := &models.Warehouse{Id: uuid.New(), Code: "ABV", Country: "NG", Region: "Abuja"}
+	accra := &models.Warehouse{Id: uuid.New(), Code: "ACC", Country: "GH", Region: "Greater Accra"}
+	stocks := []*models.WarehouseStock{
+		{WarehouseId: lagos.Id, ProductId: product, Quantity: 5, ReservedQuantity: 1},
+		{WarehouseId: abuja.Id, ProductId: product, Quantity: 3},
+		{WarehouseId: accra.Id, ProductId: product, Quantity: 10},
+		{WarehouseId: accra.Id, ProductId: product, VariantId: &variant, Quantity: 2},
+	}
+	record := func(quantity int64) *models.OrderRecord {
+		return &models.OrderRecord{Id: uuid.New(), ProductId: product, Quantity: quantity}
+	}
+	variantRecord := func(quantity int64) *models.OrderRecord {
+		return &models.OrderRecord{Id: uuid.New(), ProductId: product, VariantId: &variant, Quantity: quantity}
+	}
+
+	tests := []struct {
+		name     string
+		strategy models.AllocationStrategy
+		region   string
+		records  []*models.OrderRecord
+		// the warehouse codes and quantities each record is allocated
+		want [][]string
+		err  error
+	}{
+		{name: "priority takes the first warehouse with all of it", strategy: models.ALLOCATE_PRIORITY, region: "Abuja", records: []*models.OrderRecord{record(4)}, want: [][]string{{"LOS:4"}}},
+		{name: "priority leaves out reserved stock", strategy: models.ALLOCATE_PRIORITY, region: "Abuja", records: []*models.OrderRecord{record(5)}, want: [][]string{{"ACC:5"}}},
+		{name: "nearest takes the same region first", strategy: models.ALLOCATE_NEAREST, region: "Abuja", records: []*models.OrderRecord{record(3)}, want: [][]string{{"ABV:3"}}},
+		{name: "nearest moves on to the same country", strategy: models.ALLOCATE_NEAREST, region: "Abuja", records: []*models.OrderRecord{record(4)}, want: [][]string{{"LOS:4"}}},
+		{name: "nearest without an address keeps priority", strategy: models.ALLOCATE_NEAREST, records: []*models.OrderRecord{record(3)}, want: [][]string{{"LOS:3"}}},
+		{name: "split takes the closest first", strategy: models.ALLOCATE_SPLIT, region: "Abuja", records: []*models.OrderRecord{record(8)}, want: [][]string{{"ABV:3", "LOS:4", "ACC:1"}}},
+		{name: "records get the stock the ones before left", strategy: models.ALLOCATE_PRIORITY, region: "Abuja", records: []*models.OrderRecord{record(3), record(3)}, want: [][]string{{"LOS:3"}, {"ABV:3"}}},
+		{name: "variants only take their own stock", strategy: models.ALLOCATE_NEAREST, region: "Abuja", records: []*models.OrderRecord{variantRecord(2)}, want: [][]string{{"ACC:2"}}},
+		{name: "priority needs a warehouse with all of it", strategy: models.ALLOCATE_PRIORITY, region: "Abuja", records: []*models.OrderRecord{record(11)}, err: messages.ErrInsufficientStock},
+		{name: "split needs enough stock in all", strategy: models.ALLOCATE_SPLIT, region: "Abuja", records: []*models.OrderRecord{record(18)}, err: messages.ErrInsufficientStock},
+		{name: "variant stock is short", strategy: models.ALLOCATE_SPLIT, region: "Abuja", records: []*models.OrderRecord{variantRecord(3)}, err: messages.ErrInsufficientStock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &models.Order{OrderRecords: tt.records, Region: tt.region}
+			if tt.region != "" {
+				order.Country = "NG"
+			}
+			warehouses := []*models.Warehouse{lagos, abuja, accra}
+			codes := map[uuid.UUID]string{lagos.Id: lagos.Code, abuja.Id: abuja.Code, accra.Id: accra.Code}
+
+			err := allocate(order, warehouses, stocks, tt.strategy)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			for i, want := range tt.want {
+				got := []string{}
+				for _, allocation := range tt.records[i].Allocations {
+					if allocation.OrderRecordId != tt.records[i].Id {
+						t.Errorf("record %d allocation is for record %s", i, allocation.OrderRecordId)
+					}
+					got = append(got, fmt.Sprintf("%s:%d", codes[allocation.WarehouseId], allocation.Quantity))
+				}
+				if !slices.Equal(got, want) {
+					t.Errorf("record %d allocations = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
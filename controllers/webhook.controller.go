@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/common/payments"
+	"e-commerce/db"
+	"e-commerce/helpers"
+	"e-commerce/models"
+	"e-commerce/repo"
+)
+
+// HandlePaymentWebhook applies a signed charge or refund notification from a payment provider.
+// Events are recorded by provider event id so redeliveries are acknowledged without side effects.
+func (c *Controller) HandlePaymentWebhook(ctx context.Context, provider string, payload []byte, signature string) *models.ResponseObject {
+	if provider != c.paymentProvider.Name() {
+		log.Warn().Msgf("HandlePaymentWebhook: webhook received for unsupported provider %s", provider)
+		return handleError(messages.ErrPaymentProviderNotSupported, "bad-request", http.StatusBadRequest)
+	}
+
+	event, err := c.paymentProvider.ParseWebhook(payload, signature)
+	if err == payments.ErrInvalidSignature {
+		log.Warn().Msgf("HandlePaymentWebhook: invalid signature for %s webhook", provider)
+		return handleError(err, "unauthorized", http.StatusUnauthorized)
+	}
+	if err != nil {
+		return handleError(err, "bad-request", http.StatusBadRequest)
+	}
+
+	err = c.db.Transaction(ctx, func(tx *db.Database) error {
+		paymentEventRepo := repo.NewPaymentEventRepo(tx)
+
+		paymentEvent := &models.PaymentEvent{
+			Id:                uuid.New(),
+			Provider:          provider,
+			EventId:           event.Id,
+			EventType:         event.Type,
+			ProviderReference: event.ProviderReference,
+			Status:            string(models.PAYMENT_EVENT_PROCESSED),
+			Payload:           string(payload),
+		}
+		created, err := paymentEventRepo.CreatePaymentEvent(ctx, paymentEvent)
+		if err != nil {
+			return err
+		}
+		if !created {
+			log.Info().Msgf("HandlePaymentWebhook: replayed %s event %s ignored", provider, event.Id)
+			return nil
+		}
+
+		note, err := c.applyPaymentEvent(ctx, tx, provider, event)
+		if err != nil {
+			return err
+		}
+		if note != "" {
+			log.Info().Msgf("HandlePaymentWebhook: %s event %s ignored: %s", provider, event.Id, note)
+			return paymentEventRepo.UpdatePaymentEventById(ctx, paymentEvent.Id, &models.PaymentEvent{
+				Status: string(models.PAYMENT_EVENT_IGNORED),
+				Note:   note,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return handleError(err, "server-error", http.StatusInternalServerError)
+	}
+
+	return handleSuccess(nil, "success", "event acknowledged", http.StatusOK)
+}
+
+// applyPaymentEvent settles the payment an event refers to, returning why the event was ignored if it was
+func (c *Controller) applyPaymentEvent(ctx context.Context, tx *db.Database, provider string, event *payments.WebhookEvent) (string, error) {
+	if event.Status == "" {
+		return "unhandled event type", nil
+	}
+	if event.Refund {
+		return c.applyRefundEvent(ctx, tx, event)
+	}
+
+	payment, err := repo.NewPaymentRepo(tx).GetPaymentByFields(ctx, helpers.Map{"provider": provider, "provider_reference": event.ProviderReference})
+	if err == messages.ErrPaymentNotFound {
+		return "unknown payment", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if payment.Status != string(models.PAYMENT_PENDING) {
+		return "payment already " + payment.Status, nil
+	}
+
+	_, err = c.settlePayment(ctx, tx, payment.Id, &payments.VerifyResponse{
+		ProviderReference: event.ProviderReference,
+		Status:            event.Status,
+		Amount:            event.Amount,
+		Currency:          event.Currency,
+	})
+	if err == messages.ErrPaymentAmountMismatch {
+		return err.Error(), nil
+	}
+	return "", err
+}
+
+// applyRefundEvent completes or fails the pending refund an event refers to, returning why the event was
+// ignored if it was
+func (c *Controller) applyRefundEvent(ctx context.Context, tx *db.Database, event *payments.WebhookEvent) (string, error) {
+	refund, err := repo.NewRefundRepo(tx).GetRefundForUpdate(ctx, helpers.Map{"provider_reference": event.ProviderReference})
+	if err == messages.ErrRefundNotFound {
+		return "unknown refund", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if refund.Status != string(models.REFUND_PENDING) {
+		return "refund already " + refund.Status, nil
+	}
+	switch event.Status {
+	case payments.CHARGE_PENDING:
+		return "refund still pending", nil
+	case payments.CHARGE_FAILED:
+		return "", c.failRefund(ctx, tx, refund)
+	}
+	return "", c.completeRefund(ctx, tx, refund)
+}
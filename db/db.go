@@ -1,6 +1,8 @@
 package db
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 
 	"e-commerce/config"
@@ -16,3 +18,11 @@ func ConnectDB(config config.ConfigType) Database {
 	}
 	return db
 }
+
+// Transaction runs fn against a database bound to a single transaction,
+// committing when fn returns nil and rolling back otherwise
+func (d *Database) Transaction(ctx context.Context, fn func(tx *Database) error) error {
+	return d.PostgresDb.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Database{PostgresDb: tx})
+	})
+}
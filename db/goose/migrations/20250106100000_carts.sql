@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS carts
+(
+	id uuid constraint carts_pk primary key DEFAULT uuid_generate_v4(),
+	user_id uuid default null,
+    token varchar(256) not null UNIQUE,
+    status varchar(100) not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index carts_user_id_status_index on carts (user_id, status);
+
+create table IF NOT EXISTS cart_items
+(
+	id uuid constraint cart_items_pk primary key DEFAULT uuid_generate_v4(),
+	cart_id uuid not null,
+    product_id uuid not null,
+	quantity bigint not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+ALTER TABLE "carts" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "cart_items" ADD FOREIGN KEY ("cart_id") REFERENCES "carts" ("id") ON DELETE CASCADE;
+ALTER TABLE "cart_items" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table cart_items;
+DROP Table carts;
+-- +goose StatementEnd
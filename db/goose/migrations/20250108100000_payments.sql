@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS payments
+(
+	id uuid constraint payments_pk primary key DEFAULT uuid_generate_v4(),
+	order_id uuid not null,
+	user_id uuid not null,
+    provider varchar(100) not null,
+    reference varchar(256) not null UNIQUE,
+    provider_reference varchar(256) not null,
+    authorization_url text not null,
+	amount bigint not null,
+	currency varchar(256) not null,
+    status varchar(100) not null,
+	paid_at timestamp default null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index payments_order_id_index on payments (order_id);
+create index payments_provider_reference_index on payments (provider, provider_reference);
+
+ALTER TABLE "payments" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id");
+ALTER TABLE "payments" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table payments;
+-- +goose StatementEnd
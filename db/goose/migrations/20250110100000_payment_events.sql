@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS payment_events
+(
+	id uuid constraint payment_events_pk primary key DEFAULT uuid_generate_v4(),
+    provider varchar(100) not null,
+    event_id varchar(256) not null,
+    event_type varchar(256) not null,
+    provider_reference varchar(256) not null,
+    status varchar(100) not null,
+    note text not null,
+    payload jsonb not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create unique index payment_events_provider_event_id_uindex on payment_events (provider, event_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table payment_events;
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN refunded_amount bigint not null default 0;
+ALTER TABLE order_records ADD COLUMN refunded_quantity bigint not null default 0;
+ALTER TABLE payments ADD COLUMN refunded_amount bigint not null default 0;
+
+create table IF NOT EXISTS refunds
+(
+	id uuid constraint refunds_pk primary key DEFAULT uuid_generate_v4(),
+	order_id uuid not null,
+	payment_id uuid not null,
+    reference varchar(256) not null UNIQUE,
+    provider_reference varchar(256) not null,
+	amount bigint not null,
+	currency varchar(256) not null,
+    reason text not null,
+    status varchar(100) not null,
+    restock boolean not null default false,
+    created_by uuid not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index refunds_order_id_index on refunds (order_id);
+
+create table IF NOT EXISTS refund_items
+(
+	id uuid constraint refund_items_pk primary key DEFAULT uuid_generate_v4(),
+	refund_id uuid not null,
+	order_record_id uuid not null,
+	product_id uuid not null,
+	quantity bigint not null,
+	amount bigint not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+ALTER TABLE "refunds" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id");
+ALTER TABLE "refunds" ADD FOREIGN KEY ("payment_id") REFERENCES "payments" ("id");
+ALTER TABLE "refunds" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+ALTER TABLE "refund_items" ADD FOREIGN KEY ("refund_id") REFERENCES "refunds" ("id") ON DELETE CASCADE;
+ALTER TABLE "refund_items" ADD FOREIGN KEY ("order_record_id") REFERENCES "order_records" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table refund_items;
+DROP Table refunds;
+ALTER TABLE payments DROP COLUMN refunded_amount;
+ALTER TABLE order_records DROP COLUMN refunded_quantity;
+ALTER TABLE orders DROP COLUMN refunded_amount;
+-- +goose StatementEnd
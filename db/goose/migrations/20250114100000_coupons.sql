@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN coupon_code varchar(256) not null default '';
+ALTER TABLE orders ADD COLUMN discount bigint not null default 0;
+
+create table IF NOT EXISTS coupons
+(
+	id uuid constraint coupons_pk primary key DEFAULT uuid_generate_v4(),
+    code varchar(256) not null UNIQUE,
+    description text not null default '',
+    type varchar(100) not null,
+	value bigint not null,
+	max_discount bigint not null default 0,
+	currency varchar(256) not null default '',
+	min_order_amount bigint not null default 0,
+	product_ids jsonb not null default '[]',
+	usage_limit bigint not null default 0,
+	per_user_limit bigint not null default 0,
+	used_count bigint not null default 0,
+	starts_at timestamp default null,
+	ends_at timestamp default null,
+    stackable boolean not null default false,
+    status varchar(100) not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create table IF NOT EXISTS coupon_redemptions
+(
+	id uuid constraint coupon_redemptions_pk primary key DEFAULT uuid_generate_v4(),
+	coupon_id uuid not null,
+	user_id uuid not null,
+	order_id uuid not null,
+	amount bigint not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index coupon_redemptions_coupon_id_user_id_index on coupon_redemptions (coupon_id, user_id);
+create index coupon_redemptions_order_id_index on coupon_redemptions (order_id);
+
+ALTER TABLE "coupon_redemptions" ADD FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id");
+ALTER TABLE "coupon_redemptions" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "coupon_redemptions" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table coupon_redemptions;
+DROP Table coupons;
+ALTER TABLE orders DROP COLUMN discount;
+ALTER TABLE orders DROP COLUMN coupon_code;
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS promotions
+(
+	id uuid constraint promotions_pk primary key DEFAULT uuid_generate_v4(),
+    name varchar(256) not null,
+    description text not null default '',
+    type varchar(100) not null,
+	rules jsonb not null default '{}',
+	currency varchar(256) not null default '',
+	priority bigint not null default 0,
+    exclusive boolean not null default false,
+	starts_at timestamp default null,
+	ends_at timestamp default null,
+    status varchar(100) not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index promotions_status_priority_index on promotions (status, priority);
+
+create table IF NOT EXISTS order_discounts
+(
+	id uuid constraint order_discounts_pk primary key DEFAULT uuid_generate_v4(),
+	order_id uuid not null,
+	order_record_id uuid default null,
+	promotion_id uuid default null,
+	coupon_id uuid default null,
+    description text not null default '',
+	amount bigint not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index order_discounts_order_id_index on order_discounts (order_id);
+
+ALTER TABLE "order_discounts" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id");
+ALTER TABLE "order_discounts" ADD FOREIGN KEY ("order_record_id") REFERENCES "order_records" ("id");
+ALTER TABLE "order_discounts" ADD FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id");
+
+-- coupon discounts given before discount lines existed
+INSERT INTO order_discounts (order_id, coupon_id, description, amount, created_at, updated_at)
+SELECT r.order_id, r.coupon_id, c.code, r.amount, r.created_at, r.updated_at
+FROM coupon_redemptions r JOIN coupons c ON c.id = r.coupon_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table order_discounts;
+DROP Table promotions;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders ADD COLUMN exchange_rates jsonb not null default '{}';
+
+create table IF NOT EXISTS exchange_rates
+(
+	id uuid constraint exchange_rates_pk primary key DEFAULT uuid_generate_v4(),
+	base_currency varchar(256) not null,
+	quote_currency varchar(256) not null,
+	rate numeric(30, 12) not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null,
+	constraint exchange_rates_currencies_unique UNIQUE (base_currency, quote_currency)
+);
+
+create table IF NOT EXISTS product_prices
+(
+	id uuid constraint product_prices_pk primary key DEFAULT uuid_generate_v4(),
+	product_id uuid not null,
+	currency varchar(256) not null,
+	price bigint not null,
+	discount bigint not null default 0,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null,
+	constraint product_prices_product_id_currency_unique UNIQUE (product_id, currency)
+);
+
+ALTER TABLE "product_prices" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table product_prices;
+DROP Table exchange_rates;
+ALTER TABLE orders DROP COLUMN exchange_rates;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE order_records ADD COLUMN currency varchar(256) not null default '';
+ALTER TABLE order_discounts ADD COLUMN currency varchar(256) not null default '';
+
+-- amounts of order records and discount lines are in the currency of their order
+UPDATE order_records r SET currency = o.currency FROM orders o WHERE o.id = r.order_id;
+UPDATE order_discounts d SET currency = o.currency FROM orders o WHERE o.id = d.order_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_discounts DROP COLUMN currency;
+ALTER TABLE order_records DROP COLUMN currency;
+-- +goose StatementEnd
@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN tax_class varchar(100) not null default 'standard';
+ALTER TABLE users ADD COLUMN tax_exempt boolean not null default false;
+ALTER TABLE orders ADD COLUMN country varchar(2) not null default '';
+ALTER TABLE orders ADD COLUMN region varchar(256) not null default '';
+ALTER TABLE orders ADD COLUMN tax bigint not null default 0;
+ALTER TABLE orders ADD COLUMN tax_exempt boolean not null default false;
+ALTER TABLE order_records ADD COLUMN tax bigint not null default 0;
+
+create table IF NOT EXISTS tax_rates
+(
+	id uuid constraint tax_rates_pk primary key DEFAULT uuid_generate_v4(),
+    name varchar(256) not null,
+	country varchar(2) not null,
+	region varchar(256) not null default '',
+	tax_class varchar(100) not null,
+	rate numeric(7, 4) not null,
+    inclusive boolean not null default false,
+    status varchar(100) not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null,
+	constraint tax_rates_country_region_tax_class_unique UNIQUE (country, region, tax_class)
+);
+
+create table IF NOT EXISTS order_taxes
+(
+	id uuid constraint order_taxes_pk primary key DEFAULT uuid_generate_v4(),
+	order_id uuid not null,
+	order_record_id uuid not null,
+	tax_rate_id uuid not null,
+    name varchar(256) not null,
+	rate numeric(7, 4) not null,
+    inclusive boolean not null default false,
+	taxable_amount bigint not null,
+	amount bigint not null,
+	currency varchar(256) not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index order_taxes_order_id_index on order_taxes (order_id);
+create index order_taxes_order_record_id_index on order_taxes (order_record_id);
+
+ALTER TABLE "order_taxes" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id");
+ALTER TABLE "order_taxes" ADD FOREIGN KEY ("order_record_id") REFERENCES "order_records" ("id");
+ALTER TABLE "order_taxes" ADD FOREIGN KEY ("tax_rate_id") REFERENCES "tax_rates" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table order_taxes;
+DROP Table tax_rates;
+ALTER TABLE order_records DROP COLUMN tax;
+ALTER TABLE orders DROP COLUMN tax_exempt;
+ALTER TABLE orders DROP COLUMN tax;
+ALTER TABLE orders DROP COLUMN region;
+ALTER TABLE orders DROP COLUMN country;
+ALTER TABLE users DROP COLUMN tax_exempt;
+ALTER TABLE products DROP COLUMN tax_class;
+-- +goose StatementEnd
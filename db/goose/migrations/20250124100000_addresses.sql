@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS addresses
+(
+	id uuid constraint addresses_pk primary key DEFAULT uuid_generate_v4(),
+	user_id uuid not null,
+    full_name varchar(256) not null,
+    phone varchar(50) not null default '',
+    line1 varchar(256) not null,
+    line2 varchar(256) not null default '',
+    city varchar(256) not null,
+    region varchar(256) not null default '',
+    postal_code varchar(20) not null default '',
+	country varchar(2) not null,
+    is_default_shipping boolean not null default false,
+    is_default_billing boolean not null default false,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index addresses_user_id_index on addresses (user_id);
+create unique index addresses_default_shipping_unique on addresses (user_id) where is_default_shipping;
+create unique index addresses_default_billing_unique on addresses (user_id) where is_default_billing;
+
+ALTER TABLE "addresses" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE orders ADD COLUMN shipping_address jsonb default null;
+ALTER TABLE orders ADD COLUMN billing_address jsonb default null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN billing_address;
+ALTER TABLE orders DROP COLUMN shipping_address;
+DROP Table addresses;
+-- +goose StatementEnd
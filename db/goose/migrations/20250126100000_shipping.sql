@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN weight bigint not null default 0;
+ALTER TABLE products ADD COLUMN length bigint not null default 0;
+ALTER TABLE products ADD COLUMN width bigint not null default 0;
+ALTER TABLE products ADD COLUMN height bigint not null default 0;
+
+create table IF NOT EXISTS shipping_zones
+(
+	id uuid constraint shipping_zones_pk primary key DEFAULT uuid_generate_v4(),
+    name varchar(256) not null,
+    countries jsonb not null default '[]',
+    regions jsonb not null default '[]',
+	status varchar(20) not null default 'active',
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index shipping_zones_countries_index on shipping_zones using gin (countries);
+
+create table IF NOT EXISTS shipping_methods
+(
+	id uuid constraint shipping_methods_pk primary key DEFAULT uuid_generate_v4(),
+	zone_id uuid not null,
+    name varchar(256) not null,
+    type varchar(30) not null,
+    rates jsonb not null default '{}',
+    currency varchar(3) not null,
+    min_delivery_days bigint not null default 0,
+    max_delivery_days bigint not null default 0,
+	status varchar(20) not null default 'active',
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index shipping_methods_zone_id_index on shipping_methods (zone_id);
+
+ALTER TABLE "shipping_methods" ADD FOREIGN KEY ("zone_id") REFERENCES "shipping_zones" ("id") ON DELETE CASCADE;
+
+ALTER TABLE orders ADD COLUMN shipping_method_id uuid default null;
+ALTER TABLE orders ADD COLUMN shipping_method varchar(256) not null default '';
+
+ALTER TABLE "orders" ADD FOREIGN KEY ("shipping_method_id") REFERENCES "shipping_methods" ("id") ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN shipping_method;
+ALTER TABLE orders DROP COLUMN shipping_method_id;
+DROP Table shipping_methods;
+DROP Table shipping_zones;
+ALTER TABLE products DROP COLUMN height;
+ALTER TABLE products DROP COLUMN width;
+ALTER TABLE products DROP COLUMN length;
+ALTER TABLE products DROP COLUMN weight;
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS shipments
+(
+	id uuid constraint shipments_pk primary key DEFAULT uuid_generate_v4(),
+	order_id uuid not null,
+    carrier varchar(50) not null,
+    tracking_number varchar(256) not null default '',
+    label_url text not null default '',
+    weight bigint not null default 0,
+	status varchar(30) not null default 'pending',
+    shipped_at timestamp default null,
+    delivered_at timestamp default null,
+	created_by uuid not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index shipments_order_id_index on shipments (order_id);
+create index shipments_tracking_number_index on shipments (tracking_number);
+create index shipments_carrier_status_index on shipments (carrier, status);
+
+ALTER TABLE "shipments" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id");
+ALTER TABLE "shipments" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id");
+
+create table IF NOT EXISTS shipment_items
+(
+	id uuid constraint shipment_items_pk primary key DEFAULT uuid_generate_v4(),
+	shipment_id uuid not null,
+	order_record_id uuid not null,
+	product_id uuid not null,
+	quantity bigint not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index shipment_items_shipment_id_index on shipment_items (shipment_id);
+
+ALTER TABLE "shipment_items" ADD FOREIGN KEY ("shipment_id") REFERENCES "shipments" ("id");
+ALTER TABLE "shipment_items" ADD FOREIGN KEY ("order_record_id") REFERENCES "order_records" ("id");
+
+create table IF NOT EXISTS shipment_events
+(
+	id uuid constraint shipment_events_pk primary key DEFAULT uuid_generate_v4(),
+	shipment_id uuid not null,
+    status varchar(30) not null,
+    description text not null default '',
+    location varchar(256) not null default '',
+    occurred_at timestamp not null,
+	created_at timestamp default current_timestamp not null,
+	constraint shipment_events_unique UNIQUE (shipment_id, status, occurred_at)
+);
+
+ALTER TABLE "shipment_events" ADD FOREIGN KEY ("shipment_id") REFERENCES "shipments" ("id");
+
+ALTER TABLE order_records ADD COLUMN shipped_quantity bigint not null default 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_records DROP COLUMN shipped_quantity;
+DROP Table shipment_events;
+DROP Table shipment_items;
+DROP Table shipments;
+-- +goose StatementEnd
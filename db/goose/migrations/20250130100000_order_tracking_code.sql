@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+create index orders_tracking_code_index on orders (tracking_code);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX orders_tracking_code_index;
+-- +goose StatementEnd
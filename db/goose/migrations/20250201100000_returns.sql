@@ -0,0 +1,57 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN return_window_days bigint not null default 30;
+ALTER TABLE order_records ADD COLUMN returned_quantity bigint not null default 0;
+
+create table IF NOT EXISTS return_requests
+(
+	id uuid constraint return_requests_pk primary key DEFAULT uuid_generate_v4(),
+	order_id uuid not null,
+	user_id uuid not null,
+	status varchar(20) not null default 'requested',
+    comment text not null default '',
+    refundable_amount bigint not null default 0,
+    currency varchar(3) not null,
+    restocked boolean not null default false,
+    review_note text not null default '',
+    reviewed_by uuid default null,
+    reviewed_at timestamp default null,
+    received_at timestamp default null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index return_requests_order_id_index on return_requests (order_id);
+create index return_requests_status_index on return_requests (status);
+
+ALTER TABLE "return_requests" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id");
+ALTER TABLE "return_requests" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+ALTER TABLE "return_requests" ADD FOREIGN KEY ("reviewed_by") REFERENCES "users" ("id");
+
+create table IF NOT EXISTS return_items
+(
+	id uuid constraint return_items_pk primary key DEFAULT uuid_generate_v4(),
+	return_request_id uuid not null,
+	order_record_id uuid not null,
+	product_id uuid not null,
+	quantity bigint not null,
+    reason varchar(30) not null,
+    refundable_amount bigint not null default 0,
+    currency varchar(3) not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+create index return_items_return_request_id_index on return_items (return_request_id);
+
+ALTER TABLE "return_items" ADD FOREIGN KEY ("return_request_id") REFERENCES "return_requests" ("id");
+ALTER TABLE "return_items" ADD FOREIGN KEY ("order_record_id") REFERENCES "order_records" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table return_items;
+DROP Table return_requests;
+ALTER TABLE order_records DROP COLUMN returned_quantity;
+ALTER TABLE products DROP COLUMN return_window_days;
+-- +goose StatementEnd
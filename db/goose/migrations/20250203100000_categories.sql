@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS categories
+(
+	id uuid constraint categories_pk primary key DEFAULT uuid_generate_v4(),
+	parent_id uuid default null,
+	name varchar(256) not null,
+	slug varchar(256) not null,
+	description text not null default '',
+	position bigint not null default 0,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null,
+	constraint categories_slug_unique UNIQUE (slug)
+);
+
+create index categories_parent_id_index on categories (parent_id);
+
+ALTER TABLE "categories" ADD FOREIGN KEY ("parent_id") REFERENCES "categories" ("id");
+
+create table IF NOT EXISTS product_categories
+(
+	product_id uuid not null,
+	category_id uuid not null,
+	created_at timestamp default current_timestamp not null,
+	constraint product_categories_pk primary key (product_id, category_id)
+);
+
+create index product_categories_category_id_index on product_categories (category_id);
+
+ALTER TABLE "product_categories" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "product_categories" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP Table product_categories;
+DROP Table categories;
+-- +goose StatementEnd
@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS product_options
+(
+	id uuid constraint product_options_pk primary key DEFAULT uuid_generate_v4(),
+	product_id uuid not null,
+	name varchar(50) not null,
+	"values" jsonb not null default '[]',
+	position bigint not null default 0,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null,
+	constraint product_options_product_id_name_unique UNIQUE (product_id, name)
+);
+
+ALTER TABLE "product_options" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+
+create table IF NOT EXISTS product_variants
+(
+	id uuid constraint product_variants_pk primary key DEFAULT uuid_generate_v4(),
+	product_id uuid not null,
+	sku varchar(64) not null,
+	options jsonb not null default '{}',
+	price bigint default null,
+	discount bigint default null,
+	currency varchar(3) not null,
+	available_quantity bigint not null default 0,
+	status varchar(20) not null default 'in-stock',
+	position bigint not null default 0,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null,
+	constraint product_variants_sku_unique UNIQUE (sku),
+	constraint product_variants_product_id_options_unique UNIQUE (product_id, options)
+);
+
+ALTER TABLE "product_variants" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+
+ALTER TABLE order_records ADD COLUMN variant_id uuid default null;
+ALTER TABLE order_records ADD COLUMN sku varchar(64) not null default '';
+ALTER TABLE "order_records" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE SET NULL;
+
+ALTER TABLE cart_items ADD COLUMN variant_id uuid default null;
+ALTER TABLE "cart_items" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE;
+
+ALTER TABLE refund_items ADD COLUMN variant_id uuid default null;
+ALTER TABLE "refund_items" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE SET NULL;
+
+ALTER TABLE return_items ADD COLUMN variant_id uuid default null;
+ALTER TABLE "return_items" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE return_items DROP COLUMN variant_id;
+ALTER TABLE refund_items DROP COLUMN variant_id;
+ALTER TABLE cart_items DROP COLUMN variant_id;
+ALTER TABLE order_records DROP COLUMN sku;
+ALTER TABLE order_records DROP COLUMN variant_id;
+DROP Table product_variants;
+DROP Table product_options;
+-- +goose StatementEnd
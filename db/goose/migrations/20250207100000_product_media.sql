@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS product_media
+(
+	id uuid constraint product_media_pk primary key DEFAULT uuid_generate_v4(),
+	product_id uuid not null,
+	key varchar(255) not null,
+	content_type varchar(50) not null,
+	size bigint not null default 0,
+	width bigint not null default 0,
+	height bigint not null default 0,
+	thumbnails jsonb not null default '{}',
+	position bigint not null default 0,
+	is_primary boolean not null default false,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null
+);
+
+ALTER TABLE "product_media" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+
+CREATE INDEX product_media_product_id_idx ON product_media (product_id);
+-- a product has at most one primary image
+CREATE UNIQUE INDEX product_media_primary_unique ON product_media (product_id) WHERE is_primary;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_media;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- names weigh more than descriptions when ranking search results
+ALTER TABLE products ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+	setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+	setweight(to_tsvector('english', coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX products_search_vector_idx ON products USING gin (search_vector);
+-- typo tolerant and prefix matching of names for search and autocomplete
+CREATE INDEX products_name_trgm_idx ON products USING gin (name gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS products_name_trgm_idx;
+DROP INDEX IF EXISTS products_search_vector_idx;
+ALTER TABLE products DROP COLUMN search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN brand varchar(50) not null default '';
+
+CREATE INDEX products_brand_idx ON products (brand);
+CREATE INDEX products_status_idx ON products (status);
+-- option values of variants are counted and filtered on for the listing facets
+CREATE INDEX product_variants_options_idx ON product_variants USING gin (options);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS product_variants_options_idx;
+DROP INDEX IF EXISTS products_status_idx;
+DROP INDEX IF EXISTS products_brand_idx;
+ALTER TABLE products DROP COLUMN brand;
+-- +goose StatementEnd
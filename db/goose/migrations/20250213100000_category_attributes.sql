@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS category_attributes
+(
+	id uuid constraint category_attributes_pk primary key DEFAULT uuid_generate_v4(),
+	category_id uuid not null,
+	key varchar(50) not null,
+	name varchar(100) not null,
+	type varchar(20) not null,
+	"values" jsonb not null default '[]',
+	required boolean not null default false,
+	position bigint not null default 0,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null,
+	constraint category_attributes_category_id_key_unique UNIQUE (category_id, key)
+);
+
+ALTER TABLE "category_attributes" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE;
+
+ALTER TABLE products ADD COLUMN attributes jsonb not null default '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE products DROP COLUMN attributes;
+DROP TABLE IF EXISTS category_attributes;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS import_jobs
+(
+	id uuid constraint import_jobs_pk primary key DEFAULT uuid_generate_v4(),
+	format varchar(10) not null,
+	dry_run boolean not null default false,
+	status varchar(20) not null,
+	total_rows bigint not null default 0,
+	processed_rows bigint not null default 0,
+	created_rows bigint not null default 0,
+	updated_rows bigint not null default 0,
+	failed_rows bigint not null default 0,
+	errors jsonb not null default '[]',
+	error text not null default '',
+	created_by uuid,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default null,
+	finished_at timestamp default null
+);
+
+ALTER TABLE "import_jobs" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("id") ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS import_jobs;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS inventory_movements
+(
+	id uuid constraint inventory_movements_pk primary key DEFAULT uuid_generate_v4(),
+	product_id uuid not null,
+	variant_id uuid,
+	type varchar(20) not null,
+	quantity bigint not null,
+	balance bigint not null,
+	reason varchar(255) not null default '',
+	reference_id uuid,
+	actor_id uuid,
+	created_at timestamp default current_timestamp not null
+);
+
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE;
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("actor_id") REFERENCES "users" ("id") ON DELETE SET NULL;
+
+CREATE INDEX inventory_movements_product_id_created_at_index ON inventory_movements (product_id, created_at);
+
+-- the stock products and variants already have opens their ledger
+INSERT INTO inventory_movements (product_id, variant_id, type, quantity, balance)
+SELECT id, NULL, 'initial', available_quantity, available_quantity FROM products WHERE available_quantity <> 0;
+INSERT INTO inventory_movements (product_id, variant_id, type, quantity, balance)
+SELECT product_id, id, 'initial', available_quantity, available_quantity FROM product_variants WHERE available_quantity <> 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS inventory_movements;
+-- +goose StatementEnd
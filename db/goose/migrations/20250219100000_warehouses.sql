@@ -0,0 +1,71 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS warehouses
+(
+	id uuid constraint warehouses_pk primary key DEFAULT uuid_generate_v4(),
+	name varchar(256) not null,
+	code varchar(50) not null constraint warehouses_code_key unique,
+	country varchar(2) not null default '',
+	region varchar(256) not null default '',
+	priority bigint not null default 0,
+	status varchar(20) not null default 'active',
+	is_default boolean not null default false,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default current_timestamp not null
+);
+
+-- there is only ever one default warehouse
+CREATE UNIQUE INDEX warehouses_is_default_key ON warehouses (is_default) WHERE is_default;
+
+create table IF NOT EXISTS warehouse_stocks
+(
+	id uuid constraint warehouse_stocks_pk primary key DEFAULT uuid_generate_v4(),
+	warehouse_id uuid not null,
+	product_id uuid not null,
+	variant_id uuid,
+	quantity bigint not null default 0,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default current_timestamp not null
+);
+
+ALTER TABLE "warehouse_stocks" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id") ON DELETE CASCADE;
+ALTER TABLE "warehouse_stocks" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "warehouse_stocks" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE;
+
+-- a product's own stock has no variant, it is keyed on the nil uuid
+CREATE UNIQUE INDEX warehouse_stocks_warehouse_id_product_id_variant_id_key
+	ON warehouse_stocks (warehouse_id, product_id, (COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid)));
+
+create table IF NOT EXISTS order_allocations
+(
+	id uuid constraint order_allocations_pk primary key DEFAULT uuid_generate_v4(),
+	order_record_id uuid not null,
+	warehouse_id uuid not null,
+	quantity bigint not null,
+	created_at timestamp default current_timestamp not null
+);
+
+ALTER TABLE "order_allocations" ADD FOREIGN KEY ("order_record_id") REFERENCES "order_records" ("id") ON DELETE CASCADE;
+ALTER TABLE "order_allocations" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+CREATE INDEX order_allocations_order_record_id_index ON order_allocations (order_record_id);
+
+ALTER TABLE inventory_movements ADD COLUMN warehouse_id uuid;
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id") ON DELETE SET NULL;
+
+-- the stock products and variants already have is held in the default warehouse
+INSERT INTO warehouses (name, code, is_default) VALUES ('Default', 'default', true);
+INSERT INTO warehouse_stocks (warehouse_id, product_id, variant_id, quantity)
+SELECT w.id, p.id, NULL, p.available_quantity FROM products p, warehouses w WHERE w.is_default AND p.available_quantity <> 0;
+INSERT INTO warehouse_stocks (warehouse_id, product_id, variant_id, quantity)
+SELECT w.id, v.product_id, v.id, v.available_quantity FROM product_variants v, warehouses w WHERE w.is_default AND v.available_quantity <> 0;
+UPDATE inventory_movements SET warehouse_id = (SELECT id FROM warehouses WHERE is_default);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE inventory_movements DROP COLUMN IF EXISTS warehouse_id;
+DROP TABLE IF EXISTS order_allocations;
+DROP TABLE IF EXISTS warehouse_stocks;
+DROP TABLE IF EXISTS warehouses;
+-- +goose StatementEnd
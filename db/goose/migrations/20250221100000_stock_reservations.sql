@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+create table IF NOT EXISTS stock_reservations
+(
+	id uuid constraint stock_reservations_pk primary key DEFAULT uuid_generate_v4(),
+	order_id uuid not null,
+	order_record_id uuid not null,
+	product_id uuid not null,
+	variant_id uuid,
+	warehouse_id uuid not null,
+	quantity bigint not null,
+	status varchar(20) not null default 'active',
+	expires_at timestamp not null,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default current_timestamp not null
+);
+
+ALTER TABLE "stock_reservations" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE;
+ALTER TABLE "stock_reservations" ADD FOREIGN KEY ("order_record_id") REFERENCES "order_records" ("id") ON DELETE CASCADE;
+ALTER TABLE "stock_reservations" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+CREATE INDEX stock_reservations_order_id_index ON stock_reservations (order_id);
+-- the sweeper only looks at active reservations
+CREATE INDEX stock_reservations_expires_at_index ON stock_reservations (expires_at) WHERE status = 'active';
+
+ALTER TABLE products ADD COLUMN reserved_quantity bigint not null default 0;
+ALTER TABLE product_variants ADD COLUMN reserved_quantity bigint not null default 0;
+ALTER TABLE warehouse_stocks ADD COLUMN reserved_quantity bigint not null default 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE warehouse_stocks DROP COLUMN IF EXISTS reserved_quantity;
+ALTER TABLE product_variants DROP COLUMN IF EXISTS reserved_quantity;
+ALTER TABLE products DROP COLUMN IF EXISTS reserved_quantity;
+DROP TABLE IF EXISTS stock_reservations;
+-- +goose StatementEnd
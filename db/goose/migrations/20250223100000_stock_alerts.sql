@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN reorder_threshold bigint not null default 0;
+ALTER TABLE products ADD COLUMN low_stock_alerted_at timestamp;
+ALTER TABLE product_variants ADD COLUMN low_stock_alerted_at timestamp;
+
+create table IF NOT EXISTS stock_subscriptions
+(
+	id uuid constraint stock_subscriptions_pk primary key DEFAULT uuid_generate_v4(),
+	product_id uuid not null,
+	user_id uuid not null,
+	email varchar(256) not null,
+	status varchar(20) not null default 'pending',
+	notified_at timestamp,
+	created_at timestamp default current_timestamp not null,
+	updated_at timestamp default current_timestamp not null
+);
+
+ALTER TABLE "stock_subscriptions" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "stock_subscriptions" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+-- a customer waits on a product once, they can subscribe again after being notified
+CREATE UNIQUE INDEX stock_subscriptions_product_id_user_id_key ON stock_subscriptions (product_id, user_id) WHERE status = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS stock_subscriptions;
+ALTER TABLE product_variants DROP COLUMN IF EXISTS low_stock_alerted_at;
+ALTER TABLE products DROP COLUMN IF EXISTS low_stock_alerted_at;
+ALTER TABLE products DROP COLUMN IF EXISTS reorder_threshold;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- partially refunded orders go back to the last fulfilment status in their history
+UPDATE orders o SET status = COALESCE((
+    SELECT h.entry->>'status'
+    FROM jsonb_array_elements(o.history->'data') WITH ORDINALITY AS h(entry, position)
+    WHERE h.entry->>'status' IN ('processing', 'shipped', 'delivered')
+    ORDER BY h.position DESC
+    LIMIT 1
+), 'processing')
+WHERE o.status = 'partially-refunded';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE orders SET status = 'partially-refunded'
+WHERE status IN ('processing', 'shipped', 'delivered') AND refunded_amount > 0;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the inventory ledger is append only, and orders keep the variant they were for, so products and
+-- variants they refer to cannot be deleted
+ALTER TABLE inventory_movements DROP CONSTRAINT inventory_movements_product_id_fkey;
+ALTER TABLE inventory_movements DROP CONSTRAINT inventory_movements_variant_id_fkey;
+ALTER TABLE order_records DROP CONSTRAINT order_records_variant_id_fkey;
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE RESTRICT;
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "order_records" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE RESTRICT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE inventory_movements DROP CONSTRAINT inventory_movements_product_id_fkey;
+ALTER TABLE inventory_movements DROP CONSTRAINT inventory_movements_variant_id_fkey;
+ALTER TABLE order_records DROP CONSTRAINT order_records_variant_id_fkey;
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE CASCADE;
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE CASCADE;
+ALTER TABLE "order_records" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE SET NULL;
+-- +goose StatementEnd
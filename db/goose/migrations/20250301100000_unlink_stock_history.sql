@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- every product and variant has stock history from the moment it is created, so the ledger keeps the ids of
+-- deleted products and variants instead of holding them back. Orders still hold back what they were for.
+ALTER TABLE inventory_movements DROP CONSTRAINT inventory_movements_product_id_fkey;
+ALTER TABLE inventory_movements DROP CONSTRAINT inventory_movements_variant_id_fkey;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM inventory_movements WHERE product_id NOT IN (SELECT id FROM products);
+DELETE FROM inventory_movements WHERE variant_id IS NOT NULL AND variant_id NOT IN (SELECT id FROM product_variants);
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id") ON DELETE RESTRICT;
+ALTER TABLE "inventory_movements" ADD FOREIGN KEY ("variant_id") REFERENCES "product_variants" ("id") ON DELETE RESTRICT;
+-- +goose StatementEnd
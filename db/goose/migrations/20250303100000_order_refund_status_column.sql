@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- orders keep their fulfilment status when they are partially refunded, their refund status says how much
+-- of the payment was given back
+ALTER TABLE orders ADD COLUMN refund_status varchar(100) not null default '';
+UPDATE orders SET refund_status = 'refunded' WHERE status = 'refunded';
+UPDATE orders SET refund_status = 'partially-refunded' WHERE status <> 'refunded' AND refunded_amount > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE orders DROP COLUMN refund_status;
+-- +goose StatementEnd
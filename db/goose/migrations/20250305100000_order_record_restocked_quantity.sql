@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- units of an order record that were never shipped put back in stock by refunds and cancellations, each is
+-- put back once
+ALTER TABLE order_records ADD COLUMN restocked_quantity bigint not null default 0;
+UPDATE order_records SET restocked_quantity = LEAST(restocked.quantity, order_records.quantity - order_records.shipped_quantity)
+FROM (
+    SELECT refund_items.order_record_id, sum(refund_items.quantity) AS quantity FROM refund_items
+    JOIN refunds ON refunds.id = refund_items.refund_id
+    WHERE refunds.restock AND refunds.status = 'succeeded'
+    GROUP BY refund_items.order_record_id
+) AS restocked
+WHERE order_records.id = restocked.order_record_id;
+UPDATE order_records SET restocked_quantity = quantity - shipped_quantity
+FROM orders
+WHERE orders.id = order_records.order_id AND orders.status = 'cancelled' AND EXISTS (
+    SELECT 1 FROM inventory_movements WHERE inventory_movements.reference_id = orders.id AND inventory_movements.type = 'cancellation'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_records DROP COLUMN restocked_quantity;
+-- +goose StatementEnd
@@ -83,9 +83,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
+        "/cart": {
             "get": {
-                "description": "Gets All Orders",
+                "description": "Gets the cart of the authenticated user, or the guest cart of the X-Cart-Token header",
                 "consumes": [
                     "application/json"
                 ],
@@ -93,22 +93,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Cart"
                 ],
-                "summary": "Get All Orders",
+                "summary": "Get Cart",
                 "parameters": [
                     {
-                        "description": "data to query for all ",
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "X-Cart-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/checkout": {
+            "post": {
+                "description": "Converts the authenticated user's cart into an order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Checkout Cart",
+                "parameters": [
+                    {
+                        "description": "data to checkout cart with",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.APIPagingDto"
+                            "$ref": "#/definitions/models.CheckoutCartDto"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "201": {
                         "description": "desc",
                         "schema": {
                             "type": "string"
@@ -129,9 +167,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/cart/items": {
             "post": {
-                "description": "Places a new Order",
+                "description": "Adds a product to the cart. Guests get a cart token back to send as X-Cart-Token",
                 "consumes": [
                     "application/json"
                 ],
@@ -139,17 +179,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Cart"
                 ],
-                "summary": "Place Order",
+                "summary": "Add Cart Item",
                 "parameters": [
                     {
-                        "description": "data to place new order",
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "X-Cart-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "product to add to the cart",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PlaceOrderDto"
+                            "$ref": "#/definitions/models.AddCartItemDto"
                         }
                     }
                 ],
@@ -157,15 +203,22 @@ const docTemplate = `{
                     "200": {
                         "description": "desc",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseObject"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/orders/{id}": {
-            "get": {
-                "description": "Get Single Order by id",
+        "/cart/items/{id}": {
+            "put": {
+                "description": "Updates the quantity of a cart item",
                 "consumes": [
                     "application/json"
                 ],
@@ -173,16 +226,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Cart"
                 ],
-                "summary": "Get Single Order",
+                "summary": "Update Cart Item",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order Id",
+                        "description": "Guest cart token",
+                        "name": "X-Cart-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cart Item Id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "data to update cart item with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateCartItemDto"
+                        }
                     }
                 ],
                 "responses": {
@@ -198,9 +266,45 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes an item from the cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Remove Cart Item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "X-Cart-Token",
+                        "in": "header"
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    {
+                        "type": "string",
+                        "description": "Cart Item Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -209,9 +313,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/cancel": {
-            "put": {
-                "description": "Cancel Order with a given Id",
+        "/categories": {
+            "get": {
+                "description": "Gets every category nested under its parent, siblings ordered by position",
                 "consumes": [
                     "application/json"
                 ],
@@ -219,20 +323,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Cancel Order",
+                "summary": "Get Category Tree",
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a category at the top level or under a parent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Create Category",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Order Id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "data to create a category",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateCategoryDto"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "201": {
                         "description": "desc",
                         "schema": {
                             "type": "string"
@@ -255,9 +396,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/status": {
-            "put": {
-                "description": "Update Order Status with a given Id",
+        "/categories/{id}": {
+            "get": {
+                "description": "Gets a category by id or slug",
                 "consumes": [
                     "application/json"
                 ],
@@ -265,25 +406,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Order"
+                    "Category"
                 ],
-                "summary": "Update Order Status",
+                "summary": "Get Single Category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order Id",
+                        "description": "Category Id or Slug",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "data to update order status",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateOrderStatusDto"
-                        }
                     }
                 ],
                 "responses": {
@@ -308,11 +440,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/products": {
-            "get": {
-                "description": "Gets All products",
+            },
+            "put": {
+                "description": "Updates the name, description or position of a category",
                 "consumes": [
                     "application/json"
                 ],
@@ -320,17 +450,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Get All Products",
+                "summary": "Update Category",
                 "parameters": [
                     {
-                        "description": "data to query for all ",
+                        "type": "string",
+                        "description": "Category Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update a category",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.APIPagingDto"
+                            "$ref": "#/definitions/models.UpdateCategoryDto"
                         }
                     }
                 ],
@@ -357,8 +494,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Creates a new product",
+            "delete": {
+                "description": "Deletes a category that has no subcategories and no products",
                 "consumes": [
                     "application/json"
                 ],
@@ -366,33 +503,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Category"
                 ],
-                "summary": "Create new Product",
+                "summary": "Delete Category",
                 "parameters": [
                     {
-                        "description": "data to create new product",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateProductDto"
-                        }
+                        "type": "string",
+                        "description": "Category Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "desc",
                         "schema": {
-                            "$ref": "#/definitions/models.ResponseObject"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/products/{id}": {
+        "/categories/{id}/attributes": {
             "get": {
-                "description": "Get Single product by id",
+                "description": "Gets the attributes products of a category can have, those of the categories above it included",
                 "consumes": [
                     "application/json"
                 ],
@@ -400,13 +549,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Attribute"
                 ],
-                "summary": "Get Single product",
+                "summary": "Get Category Attributes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product Id",
+                        "description": "Category Id or Slug",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -435,8 +584,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Updates Product with a given Id",
+            "post": {
+                "description": "Defines a text, number, enum or boolean attribute products of a category, and of its subcategories, can be given",
                 "consumes": [
                     "application/json"
                 ],
@@ -444,29 +593,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Attribute"
                 ],
-                "summary": "Update Product",
+                "summary": "Create Category Attribute",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product Id",
+                        "description": "Category Id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "data to update product with",
+                        "description": "data to create an attribute",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateProductDto"
+                            "$ref": "#/definitions/models.CreateAttributeDto"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "201": {
                         "description": "desc",
                         "schema": {
                             "type": "string"
@@ -487,9 +636,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete product by id",
+            }
+        },
+        "/categories/{id}/attributes/{attribute_id}": {
+            "put": {
+                "description": "Updates an attribute of a category, its key and type cannot change",
                 "consumes": [
                     "application/json"
                 ],
@@ -497,16 +648,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Attribute"
                 ],
-                "summary": "Delete Product",
+                "summary": "Update Category Attribute",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product Id",
+                        "description": "Category Id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute Id",
+                        "name": "attribute_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update an attribute",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateAttributeDto"
+                        }
                     }
                 ],
                 "responses": {
@@ -531,48 +698,5788 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
+            },
+            "delete": {
+                "description": "Deletes an attribute of a category, its values are dropped from the products of the category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attribute"
+                ],
+                "summary": "Delete Category Attribute",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute Id",
+                        "name": "attribute_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}/move": {
+            "put": {
+                "description": "Moves a category and its subcategories under another category, or to the top level without a parent. A category cannot be moved under itself or one of its subcategories.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Move Category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new parent of the category",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MoveCategoryDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/coupons": {
+            "get": {
+                "description": "Gets all coupons",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupon"
+                ],
+                "summary": "Get All Coupons",
+                "parameters": [
+                    {
+                        "description": "data to query for all ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a percentage or fixed amount coupon",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupon"
+                ],
+                "summary": "Create Coupon",
+                "parameters": [
+                    {
+                        "description": "data to create coupon with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateCouponDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/coupons/{id}": {
+            "get": {
+                "description": "Gets a single coupon by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupon"
+                ],
+                "summary": "Get Single Coupon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coupon Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a coupon with a given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupon"
+                ],
+                "summary": "Update Coupon",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coupon Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update coupon with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateCouponDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates": {
+            "get": {
+                "description": "Gets all exchange rates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExchangeRate"
+                ],
+                "summary": "Get All Exchange Rates",
+                "parameters": [
+                    {
+                        "description": "data to query for all ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Sets the rate between two currencies, replacing the current one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExchangeRate"
+                ],
+                "summary": "Set Exchange Rate",
+                "parameters": [
+                    {
+                        "description": "rate to set",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetExchangeRateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates/import": {
+            "post": {
+                "description": "Sets exchange rates from a csv file of base_currency,quote_currency,rate rows",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ExchangeRate"
+                ],
+                "summary": "Import Exchange Rates",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "csv file of rates",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/addresses": {
+            "get": {
+                "description": "Gets the address book, default addresses first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address"
+                ],
+                "summary": "Get Addresses",
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds an address to the address book, the first address becomes the default shipping and billing address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address"
+                ],
+                "summary": "Create Address",
+                "parameters": [
+                    {
+                        "description": "data to create address with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateAddressDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me/addresses/{id}": {
+            "get": {
+                "description": "Gets an address from the address book by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address"
+                ],
+                "summary": "Get Single Address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Address Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an address in the address book with a given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address"
+                ],
+                "summary": "Update Address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Address Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update address with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateAddressDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes an address from the address book by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Address"
+                ],
+                "summary": "Delete Address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Address Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "description": "Gets All Orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get All Orders",
+                "parameters": [
+                    {
+                        "description": "data to query for all ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Places a new Order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Place Order",
+                "parameters": [
+                    {
+                        "description": "data to place new order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PlaceOrderDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/price": {
+            "post": {
+                "description": "Prices an order with its promotions and coupon without placing it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Price Order",
+                "parameters": [
+                    {
+                        "description": "data of the order to price",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PlaceOrderDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponseObject"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Get Single Order by id, admins can get any order with its shipping address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get Single Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/cancel": {
+            "put": {
+                "description": "Cancels an order of the user that is still waiting for payment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Cancel Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments": {
+            "post": {
+                "description": "Starts a payment for a pending order and returns the payment reference and url to pay at",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Initialize Payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/refunds": {
+            "get": {
+                "description": "Gets all refunds issued on an order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refund"
+                ],
+                "summary": "Get Order Refunds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Refunds an order in full, by amount, or by order records and quantities",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refund"
+                ],
+                "summary": "Create Refund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to refund order with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateRefundDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/returns": {
+            "get": {
+                "description": "Gets the returns of an order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Return"
+                ],
+                "summary": "Get Order Returns",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Asks to return some of the records of a delivered order, each with a reason, within the products' return windows",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Return"
+                ],
+                "summary": "Create Return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "order records to return",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateReturnDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/shipments": {
+            "get": {
+                "description": "Gets the shipments of an order with their tracking events",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipment"
+                ],
+                "summary": "Get Order Shipments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Books a parcel of some or all of an order's records with the carrier, everything not yet shipped when no items are given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipment"
+                ],
+                "summary": "Create Shipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "order records to ship",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateShipmentDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/status": {
+            "put": {
+                "description": "Update Order Status with a given Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Update Order Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update order status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateOrderStatusDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/fake/{reference}": {
+            "post": {
+                "description": "Completes a charge of the fake payment provider, standing in for the provider's payment page. Only served in local and dev environments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Complete Fake Payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider Reference",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "success or failed, defaults to success",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/{reference}/verify": {
+            "get": {
+                "description": "Verifies a payment with its provider and moves a paid order to processing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Verify Payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment Reference",
+                        "name": "reference",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Gets All products, narrowed down by category, status, brand, price and option values. With facets set, the\ncounts of products for each value of those are returned too, each applying every active filter but its own.\nThe filter parameter takes {field}|{eq,ne,in,like,gt,lt}|{value} entries on name, slug, brand, price, discount,\ncurrency, tax_class, status, created_at and attributes.{key}; other fields and conditions are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get All Products",
+                "parameters": [
+                    {
+                        "description": "data to query for all ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id or slug of a category, products of its subcategories are included",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Statuses of the products",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Brands of the products",
+                        "name": "brand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency of the products, needed to filter by price",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lowest price after discount, in the minor unit of currency",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Highest price after discount, in the minor unit of currency",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Option values as name:value",
+                        "name": "option",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return facet counts with the products",
+                        "name": "facets",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Create new Product",
+                "parameters": [
+                    {
+                        "description": "data to create new product",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProductDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "$ref": "#/definitions/models.ResponseObject"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/autocomplete": {
+            "get": {
+                "description": "Suggests products for what has been typed in the search box, names starting with it come first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Autocomplete Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "What has been typed so far",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of suggestions, 8 when empty",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/export": {
+            "get": {
+                "description": "Streams every product followed by its variants as a csv or jsonl file that can be imported back",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Export Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv or jsonl, csv when empty",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/import": {
+            "post": {
+                "description": "Creates or updates products and variants from a csv or jsonl file in the background. Rows with a sku are variants matched by sku, other rows are products matched by slug. Progress and the errors of the rows are on the import job.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Import Products",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "csv or jsonl file of products",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or jsonl, taken from the file extension when empty",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only check the rows, nothing is saved",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/imports/{id}": {
+            "get": {
+                "description": "Gets the status and progress of a product import, with the errors of its rows",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Import"
+                ],
+                "summary": "Get Product Import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import Job Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/search": {
+            "get": {
+                "description": "Searches product names and descriptions, names weigh more and small typos in names are tolerated. Results come best match first with the matched words wrapped in \u003cmark\u003e tags",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search Products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Words to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id or slug of a category, products of its subcategories are included",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page of results",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Get Single product by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get Single product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates Product with a given Id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Update Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update product with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateProductDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete product by id, products that were ordered cannot be deleted, their stock history is kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Delete Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/inventory": {
+            "get": {
+                "description": "Gets the stock of a product and its variants beside what the inventory ledger adds up to, with the history of its stock movements, latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Get Product Inventory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to query the movements",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds stock to, or takes stock from, a product or one of its variants, the reason is kept in the inventory ledger",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inventory"
+                ],
+                "summary": "Adjust Product Inventory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "quantity to add, negative to take out, and why",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AdjustInventoryDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/media": {
+            "post": {
+                "description": "Uploads a jpeg, png or gif image of a product, thumbnails are made in every size and the first image becomes the primary one",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Upload Product Media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "image of the product",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/media/{media_id}": {
+            "put": {
+                "description": "Moves an image of a product or makes it the primary one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Update Product Media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Media Id",
+                        "name": "media_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update an image",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMediaDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an image of a product with its thumbnails, the next image becomes primary when the primary one is deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Delete Product Media",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Media Id",
+                        "name": "media_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/notify-me": {
+            "post": {
+                "description": "Asks to be notified when a sold out product is back in stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Notify Me",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stops being notified when a product is back in stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Stop Notify Me",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/variants": {
+            "post": {
+                "description": "Adds a variant to a product with its own SKU, stock and optionally price, with a value for each of the product's options",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Variant"
+                ],
+                "summary": "Create Product Variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to create a variant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateVariantDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/variants/{variant_id}": {
+            "put": {
+                "description": "Updates a variant of a product, reset_price makes it sell at the product's price again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Variant"
+                ],
+                "summary": "Update Product Variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant Id",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update a variant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateVariantDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a variant of a product, variants that were ordered cannot be deleted, their stock history is kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Variant"
+                ],
+                "summary": "Delete Product Variant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variant Id",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "Gets all promotions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Get All Promotions",
+                "parameters": [
+                    {
+                        "description": "data to query for all ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an automatic promotion",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Create Promotion",
+                "parameters": [
+                    {
+                        "description": "data to create promotion with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePromotionDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "description": "Gets a single promotion by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Get Single Promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a promotion with a given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Update Promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update promotion with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePromotionDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a promotion by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotion"
+                ],
+                "summary": "Delete Promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/returns": {
+            "get": {
+                "description": "Gets all returns",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Return"
+                ],
+                "summary": "Get All Returns",
+                "parameters": [
+                    {
+                        "description": "data to query for all ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}": {
+            "get": {
+                "description": "Gets a single return by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Return"
+                ],
+                "summary": "Get Single Return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Return Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}/approve": {
+            "put": {
+                "description": "Approves a requested return and works out its refundable amount",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Return"
+                ],
+                "summary": "Approve Return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Return Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "review of the return",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewReturnDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}/receive": {
+            "put": {
+                "description": "Records the arrival of the items of an approved return, optionally restocking them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Return"
+                ],
+                "summary": "Receive Return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Return Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "whether to restock the items",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReceiveReturnDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/returns/{id}/reject": {
+            "put": {
+                "description": "Rejects a requested return",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Return"
+                ],
+                "summary": "Reject Return",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Return Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "review of the return",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReviewReturnDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shipments/fake/{tracking_number}": {
+            "post": {
+                "description": "Scans a parcel of the fake carrier, standing in for the carrier moving it. Only served in local and dev environments.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipment"
+                ],
+                "summary": "Scan Fake Shipment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tracking Number",
+                        "name": "tracking_number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "in-transit, out-for-delivery, delivered or exception, defaults to in-transit",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "where the parcel was scanned",
+                        "name": "location",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shipments/{id}/track": {
+            "post": {
+                "description": "Polls the carrier for the tracking events of a shipment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipment"
+                ],
+                "summary": "Refresh Shipment Tracking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shipment Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/methods/{id}": {
+            "put": {
+                "description": "Updates a shipping method with a given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Update Shipping Method",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shipping Method Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update shipping method with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateShippingMethodDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a shipping method by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Delete Shipping Method",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shipping Method Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/quote": {
+            "post": {
+                "description": "Gets the shipping methods available for products to a destination and their fees, cheapest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Quote Shipping",
+                "parameters": [
+                    {
+                        "description": "products and destination to quote shipping for",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ShippingQuoteDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/zones": {
+            "get": {
+                "description": "Gets all shipping zones with their methods",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Get All Shipping Zones",
+                "parameters": [
+                    {
+                        "description": "data to query for all ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a shipping zone of countries, or regions of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Create Shipping Zone",
+                "parameters": [
+                    {
+                        "description": "data to create shipping zone with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateShippingZoneDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/zones/{id}": {
+            "get": {
+                "description": "Gets a single shipping zone by id with its methods",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Get Single Shipping Zone",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shipping Zone Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a shipping zone with a given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Update Shipping Zone",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shipping Zone Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update shipping zone with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateShippingZoneDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a shipping zone and its methods by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Delete Shipping Zone",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shipping Zone Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping/zones/{id}/methods": {
+            "post": {
+                "description": "Adds a shipping method with its rate table to a shipping zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shipping"
+                ],
+                "summary": "Create Shipping Method",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Shipping Zone Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to create shipping method with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateShippingMethodDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/taxes/rates": {
+            "get": {
+                "description": "Gets all tax rates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Get All Tax Rates",
+                "parameters": [
+                    {
+                        "description": "data to query for all ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a tax rate for a tax class of products in a country or region",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Create Tax Rate",
+                "parameters": [
+                    {
+                        "description": "data to create tax rate with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateTaxRateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/taxes/rates/{id}": {
+            "get": {
+                "description": "Gets a single tax rate by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Get Single Tax Rate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tax Rate Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a tax rate with a given id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Update Tax Rate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tax Rate Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update tax rate with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateTaxRateDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/taxes/report": {
+            "get": {
+                "description": "Sums the tax collected on paid orders between two dates by period and tax rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Get Tax Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day of the report, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the report, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "day, week or month, month by default",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include orders in a currency",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/track/{code}": {
+            "get": {
+                "description": "Gets the status timeline of an order by its tracking code, with the customer's email as a second factor. Rate limited per client and per code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Track Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tracking Code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email of the customer who placed the order",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/tax-exemption": {
+            "put": {
+                "description": "Exempts a customer from tax on their orders, or removes the exemption",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax"
+                ],
+                "summary": "Set Tax Exemption",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "tax exemption to set",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SetTaxExemptionDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses": {
+            "get": {
+                "description": "Gets all warehouses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "Get All Warehouses",
+                "parameters": [
+                    {
+                        "description": "data to query for all ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.APIPagingDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a warehouse stock is held in and orders are fulfilled from",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "Create Warehouse",
+                "parameters": [
+                    {
+                        "description": "data to create warehouse with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateWarehouseDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}": {
+            "get": {
+                "description": "Gets a single warehouse by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "Get Single Warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates a warehouse with a given id, making it the default takes over from the current default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouse"
+                ],
+                "summary": "Update Warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Warehouse Id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "data to update warehouse with",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateWarehouseDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/payments/{provider}": {
+            "post": {
+                "description": "Receives charge notifications from a payment provider, signed with HMAC-SHA256 of the body",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Payment Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment Provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hex encoded HMAC-SHA256 signature of the body",
+                        "name": "X-Webhook-Signature",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
     "definitions": {
         "models.APIPagingDto": {
             "type": "object",
             "properties": {
-                "direction": {
-                    "type": "string"
+                "direction": {
+                    "type": "string"
+                },
+                "filter": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "select": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sort": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddCartItemDto": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "required for products sold through variants",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AdjustInventoryDto": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "reason"
+            ],
+            "properties": {
+                "quantity": {
+                    "description": "quantity to add, negative to take stock out",
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "variant_id": {
+                    "type": "string"
+                },
+                "warehouse_id": {
+                    "description": "the default warehouse when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AttributeType": {
+            "type": "string",
+            "enum": [
+                "text",
+                "number",
+                "enum",
+                "boolean"
+            ],
+            "x-enum-varnames": [
+                "ATTRIBUTE_TEXT",
+                "ATTRIBUTE_NUMBER",
+                "ATTRIBUTE_ENUM",
+                "ATTRIBUTE_BOOLEAN"
+            ]
+        },
+        "models.BundleItem": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CheckoutCartDto": {
+            "type": "object",
+            "required": [
+                "currency"
+            ],
+            "properties": {
+                "billing_address_id": {
+                    "type": "string"
+                },
+                "coupon_code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "shipping_address_id": {
+                    "type": "string"
+                },
+                "shipping_method_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CouponStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive"
+            ],
+            "x-enum-varnames": [
+                "COUPON_ACTIVE",
+                "COUPON_INACTIVE"
+            ]
+        },
+        "models.CouponType": {
+            "type": "string",
+            "enum": [
+                "percentage",
+                "fixed"
+            ],
+            "x-enum-varnames": [
+                "COUPON_PERCENTAGE",
+                "COUPON_FIXED"
+            ]
+        },
+        "models.CreateAddressDto": {
+            "type": "object",
+            "required": [
+                "city",
+                "country",
+                "full_name",
+                "line1",
+                "phone"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "country": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "is_default_billing": {
+                    "type": "boolean"
+                },
+                "is_default_shipping": {
+                    "type": "boolean"
+                },
+                "line1": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "line2": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "postal_code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "region": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "models.CreateAttributeDto": {
+            "type": "object",
+            "required": [
+                "key",
+                "name",
+                "type",
+                "values"
+            ],
+            "properties": {
+                "key": {
+                    "description": "lowercase letters, digits and underscores, starting with a letter",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.AttributeType"
+                },
+                "values": {
+                    "description": "needed for enum attributes",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CreateCategoryDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "parent_id": {
+                    "description": "top level when empty",
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.CreateCouponDto": {
+            "type": "object",
+            "required": [
+                "code",
+                "type",
+                "value"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                },
+                "currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "max_discount": {
+                    "type": "integer"
+                },
+                "min_order_amount": {
+                    "type": "integer"
+                },
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stackable": {
+                    "type": "boolean"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.CouponType"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateProductDto": {
+            "type": "object",
+            "required": [
+                "currency",
+                "description",
+                "name",
+                "price",
+                "quantity"
+            ],
+            "properties": {
+                "attributes": {
+                    "description": "values of the attributes defined by the categories of the product",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "brand": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 4
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "length": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 4
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductOptionDto"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductPriceDto"
+                    }
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reorder_threshold": {
+                    "description": "admins are alerted when the stock falls to it, 0 for no alerts",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "return_window_days": {
+                    "description": "DEFAULT_RETURN_WINDOW_DAYS when empty, 0 for products that cannot be returned",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "tax_class": {
+                    "description": "standard when empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TaxClass"
+                        }
+                    ]
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CreateVariantDto"
+                    }
+                },
+                "weight": {
+                    "description": "weight in grams and dimensions in centimetres",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "width": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.CreatePromotionDto": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "exclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "rules": {
+                    "$ref": "#/definitions/models.PromotionRules"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.PromotionType"
+                }
+            }
+        },
+        "models.CreateRefundDto": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RefundItemDto"
+                    }
+                },
+                "payment_id": {
+                    "description": "the payment the order was paid with when empty, else a payment that is due a refund",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 4
+                },
+                "restock": {
+                    "description": "puts back the items that were not shipped, shipped ones are restocked when their return is received",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.CreateReturnDto": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReturnItemDto"
+                    }
+                }
+            }
+        },
+        "models.CreateShipmentDto": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShipmentItemDto"
+                    }
+                }
+            }
+        },
+        "models.CreateShippingMethodDto": {
+            "type": "object",
+            "required": [
+                "currency",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "max_delivery_days": {
+                    "type": "integer"
+                },
+                "min_delivery_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "rates": {
+                    "$ref": "#/definitions/models.ShippingRates"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.ShippingMethodType"
+                }
+            }
+        },
+        "models.CreateShippingZoneDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CreateTaxRateDto": {
+            "type": "object",
+            "required": [
+                "country",
+                "name",
+                "rate",
+                "tax_class"
+            ],
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "rate": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "tax_class": {
+                    "$ref": "#/definitions/models.TaxClass"
+                }
+            }
+        },
+        "models.CreateVariantDto": {
+            "type": "object",
+            "required": [
+                "sku"
+            ],
+            "properties": {
+                "discount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "options": {
+                    "description": "a value for every option of the product",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "price": {
+                    "description": "the product's price and discount are used when empty",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "models.CreateWarehouseDto": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "country": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "priority": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "region": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "models.Currency": {
+            "type": "string",
+            "enum": [
+                "NGN",
+                "USD",
+                "EUR",
+                "GBP",
+                "GHS",
+                "KES",
+                "ZAR"
+            ],
+            "x-enum-varnames": [
+                "CURRENCY_NGN",
+                "CURRENCY_USD",
+                "CURRENCY_EUR",
+                "CURRENCY_GBP",
+                "CURRENCY_GHS",
+                "CURRENCY_KES",
+                "CURRENCY_ZAR"
+            ]
+        },
+        "models.ExchangeRateSnapshot": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "models.Money": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "$ref": "#/definitions/models.Currency"
+                }
+            }
+        },
+        "models.MoveCategoryDto": {
+            "type": "object",
+            "properties": {
+                "parent_id": {
+                    "description": "moves the category to the top level when empty",
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.Order": {
+            "type": "object",
+            "properties": {
+                "billing_address": {
+                    "$ref": "#/definitions/models.OrderAddress"
+                },
+                "country": {
+                    "description": "where the order is taxed, from the shipping address",
+                    "type": "string"
+                },
+                "coupon_code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "discount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderDiscount"
+                    }
+                },
+                "exchange_rates": {
+                    "description": "rates used to convert prices and the fee into the order currency",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ExchangeRateSnapshot"
+                        }
+                    ]
+                },
+                "fee": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "history": {
+                    "$ref": "#/definitions/models.OrderHistoryData"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderRecord"
+                    }
+                },
+                "refund_status": {
+                    "description": "partially-refunded or refunded once some of the payment is refunded, empty before",
+                    "type": "string"
+                },
+                "refunded_amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "shipping_address": {
+                    "$ref": "#/definitions/models.OrderAddress"
+                },
+                "shipping_method": {
+                    "type": "string"
+                },
+                "shipping_method_id": {
+                    "description": "the shipping method chosen and its fee in the order currency",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tax": {
+                    "description": "tax of all the order records, inclusive and exclusive",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Money"
+                        }
+                    ]
+                },
+                "tax_exempt": {
+                    "type": "boolean"
+                },
+                "total_amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "tracking_code": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderAddress": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "line1": {
+                    "type": "string"
+                },
+                "line2": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderAllocation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_record_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "warehouse_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderDiscount": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "coupon_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "order_record_id": {
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderHistory": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderHistoryData": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderHistory"
+                    }
+                }
+            }
+        },
+        "models.OrderRecord": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "description": "warehouses the record is fulfilled from",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderAllocation"
+                    }
+                },
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "refunded_quantity": {
+                    "type": "integer"
+                },
+                "restocked_quantity": {
+                    "description": "units that were never shipped put back in stock by refunds or the order being cancelled",
+                    "type": "integer"
+                },
+                "returned_quantity": {
+                    "type": "integer"
+                },
+                "shipped_quantity": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "tax": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "tax_lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderTax"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variant_id": {
+                    "description": "the variant bought and its SKU at the time, for products sold through variants",
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "processing",
+                "delivered",
+                "cancelled",
+                "shipped",
+                "refunded",
+                "partially-refunded"
+            ],
+            "x-enum-varnames": [
+                "PENDING",
+                "PROCESSING",
+                "DELIVERED",
+                "CANCELLED",
+                "SHIPPED",
+                "REFUNDED",
+                "PARTIALLY_REFUNDED"
+            ]
+        },
+        "models.OrderTax": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/models.Money"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "order_record_id": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string"
+                },
+                "tax_rate_id": {
+                    "type": "string"
+                },
+                "taxable_amount": {
+                    "description": "the amount the tax was worked out on, after discounts",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Money"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlaceOrder": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "description": "can be left out when a variant is given",
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "required for products sold through variants",
+                    "type": "string"
+                }
+            }
+        },
+        "models.PlaceOrderDto": {
+            "type": "object",
+            "required": [
+                "currency"
+            ],
+            "properties": {
+                "billing_address_id": {
+                    "description": "the default billing address of the user when empty, else the shipping address",
+                    "type": "string"
+                },
+                "coupon_code": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlaceOrder"
+                    }
+                },
+                "shipping_address_id": {
+                    "description": "the default shipping address of the user when empty",
+                    "type": "string"
+                },
+                "shipping_method_id": {
+                    "description": "the cheapest shipping method to the shipping address when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductOptionDto": {
+            "type": "object",
+            "required": [
+                "name",
+                "values"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ProductPriceDto": {
+            "type": "object",
+            "required": [
+                "currency",
+                "price"
+            ],
+            "properties": {
+                "currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProductStatus": {
+            "type": "string",
+            "enum": [
+                "in-stock",
+                "not-in-stock",
+                "sold-out"
+            ],
+            "x-enum-varnames": [
+                "IN_STOCK",
+                "NOT_IN_STOCK",
+                "SOLD_OUT"
+            ]
+        },
+        "models.PromotionRules": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "bundle_price": {
+                    "type": "integer"
+                },
+                "buy_quantity": {
+                    "type": "integer"
+                },
+                "get_quantity": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BundleItem"
+                    }
+                },
+                "max_discount": {
+                    "type": "integer"
+                },
+                "min_amount": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "integer",
+                    "maximum": 100
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PromotionTier"
+                    }
+                }
+            }
+        },
+        "models.PromotionStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive"
+            ],
+            "x-enum-varnames": [
+                "PROMOTION_ACTIVE",
+                "PROMOTION_INACTIVE"
+            ]
+        },
+        "models.PromotionTier": {
+            "type": "object",
+            "required": [
+                "min_quantity",
+                "percent"
+            ],
+            "properties": {
+                "min_quantity": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "integer",
+                    "maximum": 100
+                }
+            }
+        },
+        "models.PromotionType": {
+            "type": "string",
+            "enum": [
+                "buy-x-get-y",
+                "spend-threshold",
+                "quantity-tier",
+                "bundle"
+            ],
+            "x-enum-varnames": [
+                "PROMOTION_BUY_X_GET_Y",
+                "PROMOTION_SPEND_THRESHOLD",
+                "PROMOTION_QUANTITY_TIER",
+                "PROMOTION_BUNDLE"
+            ]
+        },
+        "models.ReceiveReturnDto": {
+            "type": "object",
+            "properties": {
+                "restock": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.RefundItemDto": {
+            "type": "object",
+            "required": [
+                "order_record_id",
+                "quantity"
+            ],
+            "properties": {
+                "order_record_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ResponseObject": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {},
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReturnItemDto": {
+            "type": "object",
+            "required": [
+                "order_record_id",
+                "quantity",
+                "reason"
+            ],
+            "properties": {
+                "order_record_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "$ref": "#/definitions/models.ReturnReason"
+                }
+            }
+        },
+        "models.ReturnReason": {
+            "type": "string",
+            "enum": [
+                "damaged",
+                "defective",
+                "wrong-item",
+                "not-as-described",
+                "no-longer-needed",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "RETURN_DAMAGED",
+                "RETURN_DEFECTIVE",
+                "RETURN_WRONG_ITEM",
+                "RETURN_NOT_AS_DESCRIBED",
+                "RETURN_NO_LONGER_NEEDED",
+                "RETURN_OTHER"
+            ]
+        },
+        "models.ReviewReturnDto": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "models.SetExchangeRateDto": {
+            "type": "object",
+            "required": [
+                "base_currency",
+                "quote_currency",
+                "rate"
+            ],
+            "properties": {
+                "base_currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "quote_currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "rate": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SetTaxExemptionDto": {
+            "type": "object",
+            "properties": {
+                "tax_exempt": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ShipmentItemDto": {
+            "type": "object",
+            "required": [
+                "order_record_id",
+                "quantity"
+            ],
+            "properties": {
+                "order_record_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ShippingMethodType": {
+            "type": "string",
+            "enum": [
+                "flat",
+                "weight-banded",
+                "free-over-threshold"
+            ],
+            "x-enum-varnames": [
+                "SHIPPING_FLAT",
+                "SHIPPING_WEIGHT_BANDED",
+                "SHIPPING_FREE_OVER_THRESHOLD"
+            ]
+        },
+        "models.ShippingQuoteDto": {
+            "type": "object",
+            "required": [
+                "country",
+                "currency"
+            ],
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "currency": {
+                    "$ref": "#/definitions/models.Currency"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PlaceOrder"
+                    }
+                },
+                "region": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "models.ShippingRates": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "bands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WeightBand"
+                    }
+                },
+                "threshold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ShippingStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive"
+            ],
+            "x-enum-varnames": [
+                "SHIPPING_ACTIVE",
+                "SHIPPING_INACTIVE"
+            ]
+        },
+        "models.SignInDto": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "cart_token": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SignUpDto": {
+            "type": "object",
+            "required": [
+                "email",
+                "firstName",
+                "lastName",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string",
+                    "maxLength": 25,
+                    "minLength": 2
+                },
+                "lastName": {
+                    "type": "string",
+                    "maxLength": 25,
+                    "minLength": 2
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/models.UserRole"
+                }
+            }
+        },
+        "models.TaxClass": {
+            "type": "string",
+            "enum": [
+                "standard",
+                "reduced",
+                "zero"
+            ],
+            "x-enum-varnames": [
+                "TAX_CLASS_STANDARD",
+                "TAX_CLASS_REDUCED",
+                "TAX_CLASS_ZERO"
+            ]
+        },
+        "models.TaxRateStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive"
+            ],
+            "x-enum-varnames": [
+                "TAX_RATE_ACTIVE",
+                "TAX_RATE_INACTIVE"
+            ]
+        },
+        "models.UpdateAddressDto": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "country": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "is_default_billing": {
+                    "type": "boolean"
+                },
+                "is_default_shipping": {
+                    "type": "boolean"
+                },
+                "line1": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "line2": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "postal_code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "region": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "models.UpdateAttributeDto": {
+            "type": "object",
+            "required": [
+                "values"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.UpdateCartItemDto": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UpdateCategoryDto": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
                 },
-                "filter": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.UpdateCouponDto": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "ends_at": {
                     "type": "string"
                 },
-                "limit": {
+                "max_discount": {
                     "type": "integer"
                 },
-                "page": {
+                "min_order_amount": {
                     "type": "integer"
                 },
-                "select": {
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "product_ids": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "sort": {
+                "stackable": {
+                    "type": "boolean"
+                },
+                "starts_at": {
                     "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.CouponStatus"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
                 }
             }
         },
-        "models.CreateProductDto": {
+        "models.UpdateMediaDto": {
+            "type": "object",
+            "properties": {
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.UpdateOrderStatusDto": {
             "type": "object",
             "required": [
-                "currency",
-                "description",
-                "name",
-                "price",
-                "quantity"
+                "status"
             ],
             "properties": {
-                "currency": {
-                    "$ref": "#/definitions/models.Currency"
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                }
+            }
+        },
+        "models.UpdateProductDto": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "description": "replaces all the attributes of the product",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "brand": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "category_ids": {
+                    "description": "replaces all the categories of the product, its attributes must still fit them",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "description": {
                     "type": "string",
@@ -582,183 +6489,229 @@ const docTemplate = `{
                 "discount": {
                     "type": "integer"
                 },
+                "height": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "length": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 30,
                     "minLength": 4
                 },
+                "options": {
+                    "description": "replaces all the options of the product, its variants must still fit them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductOptionDto"
+                    }
+                },
                 "price": {
                     "type": "integer"
                 },
+                "prices": {
+                    "description": "replaces all the prices of the product in other currencies",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductPriceDto"
+                    }
+                },
                 "quantity": {
                     "type": "integer"
+                },
+                "quantity_reason": {
+                    "description": "why the quantity changed, kept in the inventory ledger",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "reorder_threshold": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "return_window_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "status": {
+                    "$ref": "#/definitions/models.ProductStatus"
+                },
+                "tax_class": {
+                    "$ref": "#/definitions/models.TaxClass"
+                },
+                "weight": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "width": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "models.Currency": {
-            "type": "string",
-            "enum": [
-                "NGN"
-            ],
-            "x-enum-varnames": [
-                "CURRENCY_NGN"
-            ]
-        },
-        "models.OrderStatus": {
-            "type": "string",
-            "enum": [
-                "pending",
-                "processing",
-                "delivered",
-                "cancelled",
-                "shipped"
-            ],
-            "x-enum-varnames": [
-                "PENDING",
-                "PROCESSING",
-                "DELIVERED",
-                "CANCELLED",
-                "SHIPPED"
-            ]
-        },
-        "models.PlaceOrder": {
+        "models.UpdatePromotionDto": {
             "type": "object",
-            "required": [
-                "product_id",
-                "quantity"
-            ],
             "properties": {
-                "product_id": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "ends_at": {
                     "type": "string"
                 },
-                "quantity": {
+                "exclusive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "priority": {
                     "type": "integer"
+                },
+                "rules": {
+                    "$ref": "#/definitions/models.PromotionRules"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.PromotionStatus"
                 }
             }
         },
-        "models.PlaceOrderDto": {
+        "models.UpdateShippingMethodDto": {
             "type": "object",
-            "required": [
-                "currency"
-            ],
             "properties": {
-                "currency": {
-                    "$ref": "#/definitions/models.Currency"
+                "max_delivery_days": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.PlaceOrder"
-                    }
+                "min_delivery_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "rates": {
+                    "$ref": "#/definitions/models.ShippingRates"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.ShippingStatus"
                 }
             }
         },
-        "models.ProductStatus": {
-            "type": "string",
-            "enum": [
-                "in-stock",
-                "not-in-stock",
-                "sold-out"
-            ],
-            "x-enum-varnames": [
-                "IN_STOCK",
-                "NOT_IN_STOCK",
-                "SOLD_OUT"
-            ]
-        },
-        "models.ResponseObject": {
+        "models.UpdateShippingZoneDto": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {},
-                "message": {
-                    "type": "string"
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "status": {
-                    "type": "string"
+                    "$ref": "#/definitions/models.ShippingStatus"
                 }
             }
         },
-        "models.SignInDto": {
+        "models.UpdateTaxRateDto": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "inclusive": {
+                    "type": "boolean"
                 },
-                "password": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 256
+                },
+                "rate": {
                     "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.TaxRateStatus"
                 }
             }
         },
-        "models.SignUpDto": {
+        "models.UpdateVariantDto": {
             "type": "object",
-            "required": [
-                "email",
-                "firstName",
-                "lastName",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "discount": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "firstName": {
-                    "type": "string",
-                    "maxLength": 25,
-                    "minLength": 2
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "lastName": {
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "quantity_reason": {
+                    "description": "why the quantity changed, kept in the inventory ledger",
                     "type": "string",
-                    "maxLength": 25,
-                    "minLength": 2
+                    "maxLength": 255
                 },
-                "password": {
-                    "type": "string"
+                "reset_price": {
+                    "description": "drops the price and discount of the variant so it sells at the product's",
+                    "type": "boolean"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
                 },
-                "role": {
-                    "$ref": "#/definitions/models.UserRole"
-                }
-            }
-        },
-        "models.UpdateOrderStatusDto": {
-            "type": "object",
-            "required": [
-                "status"
-            ],
-            "properties": {
                 "status": {
-                    "$ref": "#/definitions/models.OrderStatus"
+                    "$ref": "#/definitions/models.ProductStatus"
                 }
             }
         },
-        "models.UpdateProductDto": {
+        "models.UpdateWarehouseDto": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 4
+                "country": {
+                    "type": "string"
                 },
-                "discount": {
-                    "type": "integer"
+                "is_default": {
+                    "type": "boolean"
                 },
                 "name": {
                     "type": "string",
-                    "maxLength": 30,
-                    "minLength": 4
+                    "maxLength": 256
                 },
-                "price": {
-                    "type": "integer"
+                "priority": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "quantity": {
-                    "type": "integer"
+                "region": {
+                    "type": "string",
+                    "maxLength": 256
                 },
                 "status": {
-                    "$ref": "#/definitions/models.ProductStatus"
+                    "$ref": "#/definitions/models.WarehouseStatus"
                 }
             }
         },
@@ -772,6 +6725,31 @@ const docTemplate = `{
                 "USER_ROLE_USER",
                 "USER_ROLE_ADMIN"
             ]
+        },
+        "models.WarehouseStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "inactive"
+            ],
+            "x-enum-varnames": [
+                "WAREHOUSE_ACTIVE",
+                "WAREHOUSE_INACTIVE"
+            ]
+        },
+        "models.WeightBand": {
+            "type": "object",
+            "required": [
+                "max_weight"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "max_weight": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Address
+// @Summary Create Address
+// @Description Adds an address to the address book, the first address becomes the default shipping and billing address
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.CreateAddressDto   true  "data to create address with"
+// @Success 201 {string} {object} models.ResponseObject{data=models.Address} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /me/addresses [post]
+func (h *Handler) CreateAddress(c *gin.Context) {
+	var input models.CreateAddressDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.CreateAddress(c, &input, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Address
+// @Summary Get Addresses
+// @Description Gets the address book, default addresses first
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} {object} models.ResponseObject{data=[]models.Address} "desc"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /me/addresses [get]
+func (h *Handler) GetAddresses(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.GetAddresses(c, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Address
+// @Summary Get Single Address
+// @Description Gets an address from the address book by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Address Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Address} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /me/addresses/{id} [get]
+func (h *Handler) GetSingleAddress(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.GetSingleAddress(c, id, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Address
+// @Summary Update Address
+// @Description Updates an address in the address book with a given id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Address Id"
+// @Param   request   body     models.UpdateAddressDto   true  "data to update address with"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Address} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /me/addresses/{id} [put]
+func (h *Handler) UpdateAddress(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.UpdateAddressDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.UpdateAddress(c, &input, id, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Address
+// @Summary Delete Address
+// @Description Removes an address from the address book by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Address Id"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /me/addresses/{id} [delete]
+func (h *Handler) DeleteAddress(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.DeleteAddress(c, id, user)
+	c.JSON(result.Code, result)
+}
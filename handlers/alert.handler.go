@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/models"
+)
+
+// @Tags Product
+// @Summary Notify Me
+// @Description Asks to be notified when a sold out product is back in stock
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Product Id"
+// @Success 201 {string} {object} models.ResponseObject{data=models.StockSubscription} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/notify-me [post]
+func (h *Handler) SubscribeToProduct(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.SubscribeToProduct(c, id, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Product
+// @Summary Stop Notify Me
+// @Description Stops being notified when a product is back in stock
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Product Id"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/notify-me [delete]
+func (h *Handler) UnsubscribeFromProduct(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.UnsubscribeFromProduct(c, id, user)
+	c.JSON(result.Code, result)
+}
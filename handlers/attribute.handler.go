@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Attribute
+// @Summary Create Category Attribute
+// @Description Defines a text, number, enum or boolean attribute products of a category, and of its subcategories, can be given
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Category Id"
+// @Param   request   body     models.CreateAttributeDto   true  "data to create an attribute"
+// @Success 201 {string} {object} models.ResponseObject{data=models.CategoryAttribute} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories/{id}/attributes [post]
+func (h *Handler) CreateCategoryAttribute(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.CreateAttributeDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateCategoryAttribute(c, id, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Attribute
+// @Summary Get Category Attributes
+// @Description Gets the attributes products of a category can have, those of the categories above it included
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Category Id or Slug"
+// @Success 200 {string} {object} models.ResponseObject{data=[]models.CategoryAttribute} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories/{id}/attributes [get]
+func (h *Handler) GetCategoryAttributes(c *gin.Context) {
+	result := h.controller.GetCategoryAttributes(c, c.Param("id"))
+	c.JSON(result.Code, result)
+}
+
+// @Tags Attribute
+// @Summary Update Category Attribute
+// @Description Updates an attribute of a category, its key and type cannot change
+// @Accept  json
+// @Produce  json
+// @Param   id             path     string   true  "Category Id"
+// @Param   attribute_id   path     string   true  "Attribute Id"
+// @Param   request   body     models.UpdateAttributeDto   true  "data to update an attribute"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories/{id}/attributes/{attribute_id} [put]
+func (h *Handler) UpdateCategoryAttribute(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	attributeId, _ := uuid.Parse(c.Param("attribute_id"))
+	var input models.UpdateAttributeDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateCategoryAttribute(c, id, attributeId, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Attribute
+// @Summary Delete Category Attribute
+// @Description Deletes an attribute of a category, its values are dropped from the products of the category
+// @Accept  json
+// @Produce  json
+// @Param   id             path     string   true  "Category Id"
+// @Param   attribute_id   path     string   true  "Attribute Id"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories/{id}/attributes/{attribute_id} [delete]
+func (h *Handler) DeleteCategoryAttribute(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	attributeId, _ := uuid.Parse(c.Param("attribute_id"))
+	result := h.controller.DeleteCategoryAttribute(c, id, attributeId)
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+const cartTokenHeader = "X-Cart-Token"
+
+// @Tags Cart
+// @Summary Get Cart
+// @Description Gets the cart of the authenticated user, or the guest cart of the X-Cart-Token header
+// @Accept  json
+// @Produce  json
+// @Param   X-Cart-Token   header     string   false  "Guest cart token"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Cart} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Router /cart [get]
+func (h *Handler) GetCart(c *gin.Context) {
+	result := h.controller.GetCart(c, c.GetHeader(cartTokenHeader), getAuthUser(c))
+	c.JSON(result.Code, result)
+}
+
+// @Tags Cart
+// @Summary Add Cart Item
+// @Description Adds a product to the cart. Guests get a cart token back to send as X-Cart-Token
+// @Accept  json
+// @Produce  json
+// @Param   X-Cart-Token   header     string   false  "Guest cart token"
+// @Param   request   body     models.AddCartItemDto   true  "product to add to the cart"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Cart} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Router /cart/items [post]
+func (h *Handler) AddCartItem(c *gin.Context) {
+	var input models.AddCartItemDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.AddCartItem(c, &input, c.GetHeader(cartTokenHeader), getAuthUser(c))
+	c.JSON(result.Code, result)
+}
+
+// @Tags Cart
+// @Summary Update Cart Item
+// @Description Updates the quantity of a cart item
+// @Accept  json
+// @Produce  json
+// @Param   X-Cart-Token   header     string   false  "Guest cart token"
+// @Param   id   path     string   true  "Cart Item Id"
+// @Param   request   body     models.UpdateCartItemDto   true  "data to update cart item with"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Cart} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Router /cart/items/{id} [put]
+func (h *Handler) UpdateCartItem(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.UpdateCartItemDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateCartItem(c, id, &input, c.GetHeader(cartTokenHeader), getAuthUser(c))
+	c.JSON(result.Code, result)
+}
+
+// @Tags Cart
+// @Summary Remove Cart Item
+// @Description Removes an item from the cart
+// @Accept  json
+// @Produce  json
+// @Param   X-Cart-Token   header     string   false  "Guest cart token"
+// @Param   id   path     string   true  "Cart Item Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Cart} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Router /cart/items/{id} [delete]
+func (h *Handler) RemoveCartItem(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.RemoveCartItem(c, id, c.GetHeader(cartTokenHeader), getAuthUser(c))
+	c.JSON(result.Code, result)
+}
+
+// @Tags Cart
+// @Summary Checkout Cart
+// @Description Converts the authenticated user's cart into an order
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.CheckoutCartDto   true  "data to checkout cart with"
+// @Success 201 {string} {object} models.ResponseObject{data=models.Order} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /cart/checkout [post]
+func (h *Handler) CheckoutCart(c *gin.Context) {
+	var input models.CheckoutCartDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.CheckoutCart(c, &input, user)
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Category
+// @Summary Create Category
+// @Description Creates a category at the top level or under a parent
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.CreateCategoryDto   true  "data to create a category"
+// @Success 201 {string} {object} models.ResponseObject{data=models.Category} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories [post]
+func (h *Handler) CreateCategory(c *gin.Context) {
+	var input models.CreateCategoryDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateCategory(c, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Category
+// @Summary Get Category Tree
+// @Description Gets every category nested under its parent, siblings ordered by position
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} {object} models.ResponseObject{data=[]models.Category} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories [get]
+func (h *Handler) GetCategoryTree(c *gin.Context) {
+	result := h.controller.GetCategoryTree(c)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Category
+// @Summary Get Single Category
+// @Description Gets a category by id or slug
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Category Id or Slug"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Category} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories/{id} [get]
+func (h *Handler) GetSingleCategory(c *gin.Context) {
+	result := h.controller.GetSingleCategory(c, c.Param("id"))
+	c.JSON(result.Code, result)
+}
+
+// @Tags Category
+// @Summary Update Category
+// @Description Updates the name, description or position of a category
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Category Id"
+// @Param   request   body     models.UpdateCategoryDto   true  "data to update a category"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories/{id} [put]
+func (h *Handler) UpdateCategory(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.UpdateCategoryDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateCategory(c, &input, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Category
+// @Summary Move Category
+// @Description Moves a category and its subcategories under another category, or to the top level without a parent. A category cannot be moved under itself or one of its subcategories.
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Category Id"
+// @Param   request   body     models.MoveCategoryDto   true  "new parent of the category"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories/{id}/move [put]
+func (h *Handler) MoveCategory(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.MoveCategoryDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.MoveCategory(c, &input, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Category
+// @Summary Delete Category
+// @Description Deletes a category that has no subcategories and no products
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Category Id"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /categories/{id} [delete]
+func (h *Handler) DeleteCategory(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.DeleteCategory(c, id)
+	c.JSON(result.Code, result)
+}
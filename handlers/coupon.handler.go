@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Coupon
+// @Summary Create Coupon
+// @Description Creates a percentage or fixed amount coupon
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.CreateCouponDto   true  "data to create coupon with"
+// @Success 201 {string} {object} models.ResponseObject{data=models.Coupon} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /coupons [post]
+func (h *Handler) CreateCoupon(c *gin.Context) {
+	var input models.CreateCouponDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateCoupon(c, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Coupon
+// @Summary Get All Coupons
+// @Description Gets all coupons
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.APIPagingDto   true  "data to query for all "
+// @Success 200 {string} {object} models.ResponseObject{data=models.CouponsResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /coupons [get]
+func (h *Handler) GetAllCoupons(c *gin.Context) {
+	query := getPagingInfo(c)
+	result := h.controller.GetAllCoupons(c, query)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Coupon
+// @Summary Get Single Coupon
+// @Description Gets a single coupon by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Coupon Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Coupon} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /coupons/{id} [get]
+func (h *Handler) GetSingleCoupon(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.GetSingleCoupon(c, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Coupon
+// @Summary Update Coupon
+// @Description Updates a coupon with a given id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Coupon Id"
+// @Param   request   body     models.UpdateCouponDto   true  "data to update coupon with"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /coupons/{id} [put]
+func (h *Handler) UpdateCoupon(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.UpdateCouponDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateCoupon(c, &input, id)
+	c.JSON(result.Code, result)
+}
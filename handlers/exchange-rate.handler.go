@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags ExchangeRate
+// @Summary Set Exchange Rate
+// @Description Sets the rate between two currencies, replacing the current one
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.SetExchangeRateDto   true  "rate to set"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ExchangeRate} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /exchange-rates [put]
+func (h *Handler) SetExchangeRate(c *gin.Context) {
+	var input models.SetExchangeRateDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.SetExchangeRate(c, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags ExchangeRate
+// @Summary Get All Exchange Rates
+// @Description Gets all exchange rates
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.APIPagingDto   true  "data to query for all "
+// @Success 200 {string} {object} models.ResponseObject{data=models.ExchangeRatesResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /exchange-rates [get]
+func (h *Handler) GetAllExchangeRates(c *gin.Context) {
+	query := getPagingInfo(c)
+	result := h.controller.GetAllExchangeRates(c, query)
+	c.JSON(result.Code, result)
+}
+
+// @Tags ExchangeRate
+// @Summary Import Exchange Rates
+// @Description Sets exchange rates from a csv file of base_currency,quote_currency,rate rows
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param   file   formData     file   true  "csv file of rates"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ExchangeRateImportResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /exchange-rates/import [post]
+func (h *Handler) ImportExchangeRates(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	defer file.Close()
+
+	result := h.controller.ImportExchangeRates(c, file)
+	c.JSON(result.Code, result)
+}
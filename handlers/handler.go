@@ -19,10 +19,15 @@ type Handler struct {
 }
 
 type Operations interface {
+	// controller for the background workers
+	Controller() controllers.Operations
+
 	// middleware
 	AuthenticatedUserMiddleware() gin.HandlerFunc
+	OptionalAuthenticatedUserMiddleware() gin.HandlerFunc
 	UserPermissionMiddleware() gin.HandlerFunc
 	AdminPermissionMiddleware() gin.HandlerFunc
+	TrackingRateLimitMiddleware() gin.HandlerFunc
 
 	// product
 	CreateProduct(c *gin.Context)
@@ -30,16 +35,127 @@ type Operations interface {
 	GetSingleProduct(c *gin.Context)
 	UpdateProduct(c *gin.Context)
 	DeleteProduct(c *gin.Context)
+	CreateProductVariant(c *gin.Context)
+	UpdateProductVariant(c *gin.Context)
+	DeleteProductVariant(c *gin.Context)
+	SearchProducts(c *gin.Context)
+	AutocompleteProducts(c *gin.Context)
+	UploadProductMedia(c *gin.Context)
+	UpdateProductMedia(c *gin.Context)
+	DeleteProductMedia(c *gin.Context)
+	ImportProducts(c *gin.Context)
+	GetProductImport(c *gin.Context)
+	ExportProducts(c *gin.Context)
+	GetProductInventory(c *gin.Context)
+	AdjustProductInventory(c *gin.Context)
+	SubscribeToProduct(c *gin.Context)
+	UnsubscribeFromProduct(c *gin.Context)
+	// warehouse
+	CreateWarehouse(c *gin.Context)
+	GetAllWarehouses(c *gin.Context)
+	GetSingleWarehouse(c *gin.Context)
+	UpdateWarehouse(c *gin.Context)
+	// category
+	CreateCategory(c *gin.Context)
+	GetCategoryTree(c *gin.Context)
+	GetSingleCategory(c *gin.Context)
+	UpdateCategory(c *gin.Context)
+	MoveCategory(c *gin.Context)
+	DeleteCategory(c *gin.Context)
+	CreateCategoryAttribute(c *gin.Context)
+	GetCategoryAttributes(c *gin.Context)
+	UpdateCategoryAttribute(c *gin.Context)
+	DeleteCategoryAttribute(c *gin.Context)
 	// order
 	PlaceOrder(c *gin.Context)
+	PriceOrder(c *gin.Context)
 	GetAllOrders(c *gin.Context)
 	UpdateOrderStatus(c *gin.Context)
+	TrackOrder(c *gin.Context)
 	CancelOrder(c *gin.Context)
 	GetSingleOrder(c *gin.Context)
 
+	// address
+	CreateAddress(c *gin.Context)
+	GetAddresses(c *gin.Context)
+	GetSingleAddress(c *gin.Context)
+	UpdateAddress(c *gin.Context)
+	DeleteAddress(c *gin.Context)
+
 	// users
 	Login(c *gin.Context)
 	SignUp(c *gin.Context)
+
+	// cart
+	GetCart(c *gin.Context)
+	AddCartItem(c *gin.Context)
+	UpdateCartItem(c *gin.Context)
+	RemoveCartItem(c *gin.Context)
+	CheckoutCart(c *gin.Context)
+
+	// payment
+	InitializePayment(c *gin.Context)
+	VerifyPayment(c *gin.Context)
+	CompleteFakePayment(c *gin.Context)
+
+	// webhooks
+	PaymentWebhook(c *gin.Context)
+
+	// refund
+	CreateRefund(c *gin.Context)
+	GetOrderRefunds(c *gin.Context)
+
+	// coupon
+	CreateCoupon(c *gin.Context)
+	GetAllCoupons(c *gin.Context)
+	GetSingleCoupon(c *gin.Context)
+	UpdateCoupon(c *gin.Context)
+
+	// promotion
+	CreatePromotion(c *gin.Context)
+	GetAllPromotions(c *gin.Context)
+	GetSinglePromotion(c *gin.Context)
+	UpdatePromotion(c *gin.Context)
+	DeletePromotion(c *gin.Context)
+
+	// exchange rate
+	SetExchangeRate(c *gin.Context)
+	GetAllExchangeRates(c *gin.Context)
+	ImportExchangeRates(c *gin.Context)
+
+	// tax
+	CreateTaxRate(c *gin.Context)
+	GetAllTaxRates(c *gin.Context)
+	GetSingleTaxRate(c *gin.Context)
+	UpdateTaxRate(c *gin.Context)
+	SetTaxExemption(c *gin.Context)
+	GetTaxReport(c *gin.Context)
+
+	// return
+	CreateReturn(c *gin.Context)
+	GetOrderReturns(c *gin.Context)
+	GetAllReturns(c *gin.Context)
+	GetSingleReturn(c *gin.Context)
+	ApproveReturn(c *gin.Context)
+	RejectReturn(c *gin.Context)
+	ReceiveReturn(c *gin.Context)
+
+	// shipment
+	CreateShipment(c *gin.Context)
+	GetOrderShipments(c *gin.Context)
+	RefreshShipmentTracking(c *gin.Context)
+	ScanFakeShipment(c *gin.Context)
+
+	// shipping
+	CreateShippingZone(c *gin.Context)
+	GetAllShippingZones(c *gin.Context)
+	GetSingleShippingZone(c *gin.Context)
+	UpdateShippingZone(c *gin.Context)
+	DeleteShippingZone(c *gin.Context)
+	CreateShippingMethod(c *gin.Context)
+	UpdateShippingMethod(c *gin.Context)
+	DeleteShippingMethod(c *gin.Context)
+	QuoteShipping(c *gin.Context)
 }
 
 func NewHandler(config *config.ConfigType, db *db.Database) Operations {
@@ -48,11 +164,16 @@ func NewHandler(config *config.ConfigType, db *db.Database) Operations {
 		log.Logger.Fatal().Msg(fmt.Sprintf("Create middleware error : %s", err.Error()))
 	}
 	h := &Handler{
-		controller: *controllers.NewController(middleware, db),
+		controller: *controllers.NewController(middleware, config, db),
 	}
 	return Operations(h)
 }
 
+// Controller returns the controller the handlers call into
+func (h *Handler) Controller() controllers.Operations {
+	return h.controller
+}
+
 func getPagingInfo(c *gin.Context) *models.APIPagingDto {
 	var paging models.APIPagingDto
 
@@ -71,3 +192,12 @@ func getPagingInfo(c *gin.Context) *models.APIPagingDto {
 	paging.Page = page
 	return &paging
 }
+
+// getAuthUser gets the authenticated user if the request has one
+func getAuthUser(c *gin.Context) *models.User {
+	user, ok := c.Get("authUser")
+	if !ok {
+		return nil
+	}
+	return user.(*models.User)
+}
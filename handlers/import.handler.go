@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Import
+// @Summary Import Products
+// @Description Creates or updates products and variants from a csv or jsonl file in the background. Rows with a sku are variants matched by sku, other rows are products matched by slug. Progress and the errors of the rows are on the import job.
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param   file      formData     file     true   "csv or jsonl file of products"
+// @Param   format    query        string   false  "csv or jsonl, taken from the file extension when empty"
+// @Param   dry_run   query        bool     false  "only check the rows, nothing is saved"
+// @Success 202 {string} {object} models.ResponseObject{data=models.ImportJob} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/import [post]
+func (h *Handler) ImportProducts(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
+	var input models.ProductImportDto
+	// bind input
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err.Error(), Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	if input.Format == "" {
+		input.Format = models.ImportFormatOf(fileHeader.Filename)
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	defer file.Close()
+
+	result := h.controller.StartProductImport(c, file, &input, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Import
+// @Summary Get Product Import
+// @Description Gets the status and progress of a product import, with the errors of its rows
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Import Job Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ImportJob} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/imports/{id} [get]
+func (h *Handler) GetProductImport(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.GetProductImport(c, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Import
+// @Summary Export Products
+// @Description Streams every product followed by its variants as a csv or jsonl file that can be imported back
+// @Produce  text/csv
+// @Param   format   query    string   false  "csv or jsonl, csv when empty"
+// @Success 200 {file} file "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/export [get]
+func (h *Handler) ExportProducts(c *gin.Context) {
+	var input models.ProductExportDto
+	// bind input
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err.Error(), Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	if input.Format == "" {
+		input.Format = models.IMPORT_CSV
+	}
+
+	contentType := "text/csv"
+	if input.Format == models.IMPORT_JSONL {
+		contentType = "application/x-ndjson"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=products.%s", input.Format))
+	c.Status(http.StatusOK)
+	// the response is already streaming, an export that fails part way can only be cut short
+	if err := h.controller.ExportProducts(c, input.Format, c.Writer); err != nil {
+		log.Err(err).Msgf("ExportProducts: export failed: %v", err)
+		c.Abort()
+	}
+}
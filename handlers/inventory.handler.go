@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Inventory
+// @Summary Get Product Inventory
+// @Description Gets the stock of a product and its variants beside what the inventory ledger adds up to, with the history of its stock movements, latest first
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Product Id"
+// @Param   request   body     models.APIPagingDto   true  "data to query the movements"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ProductInventoryResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/inventory [get]
+func (h *Handler) GetProductInventory(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	query := getPagingInfo(c)
+	result := h.controller.GetProductInventory(c, id, query)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Inventory
+// @Summary Adjust Product Inventory
+// @Description Adds stock to, or takes stock from, a product or one of its variants, the reason is kept in the inventory ledger
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Product Id"
+// @Param   request   body     models.AdjustInventoryDto   true  "quantity to add, negative to take out, and why"
+// @Success 201 {string} {object} models.ResponseObject{data=models.InventoryMovement} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/inventory [post]
+func (h *Handler) AdjustProductInventory(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.AdjustInventoryDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.AdjustProductInventory(c, id, &input, user)
+	c.JSON(result.Code, result)
+}
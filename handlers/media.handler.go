@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Media
+// @Summary Upload Product Media
+// @Description Uploads a jpeg, png or gif image of a product, thumbnails are made in every size and the first image becomes the primary one
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param   id     path         string   true  "Product Id"
+// @Param   file   formData     file     true  "image of the product"
+// @Success 201 {string} {object} models.ResponseObject{data=models.ProductMedia} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/media [post]
+func (h *Handler) UploadProductMedia(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrMediaFileRequired.Error()})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	defer file.Close()
+
+	result := h.controller.UploadProductMedia(c, id, file)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Media
+// @Summary Update Product Media
+// @Description Moves an image of a product or makes it the primary one
+// @Accept  json
+// @Produce  json
+// @Param   id         path     string   true  "Product Id"
+// @Param   media_id   path     string   true  "Media Id"
+// @Param   request   body     models.UpdateMediaDto   true  "data to update an image"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/media/{media_id} [put]
+func (h *Handler) UpdateProductMedia(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	mediaId, _ := uuid.Parse(c.Param("media_id"))
+	var input models.UpdateMediaDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateProductMedia(c, id, mediaId, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Media
+// @Summary Delete Product Media
+// @Description Deletes an image of a product with its thumbnails, the next image becomes primary when the primary one is deleted
+// @Accept  json
+// @Produce  json
+// @Param   id         path     string   true  "Product Id"
+// @Param   media_id   path     string   true  "Media Id"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/media/{media_id} [delete]
+func (h *Handler) DeleteProductMedia(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	mediaId, _ := uuid.Parse(c.Param("media_id"))
+	result := h.controller.DeleteProductMedia(c, id, mediaId)
+	c.JSON(result.Code, result)
+}
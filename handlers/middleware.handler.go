@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
+	"strconv"
 
 	"e-commerce/common/messages"
 	"e-commerce/models"
@@ -24,6 +26,25 @@ func (h *Handler) AuthenticatedUserMiddleware() gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthenticatedUserMiddleware sets the authenticated user when a bearer token is sent
+// and lets guest requests through otherwise
+func (h *Handler) OptionalAuthenticatedUserMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		user, err := h.controller.Middleware().JwtUserAuth(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err.Error(), Status: "bad-request", Message: err.Error()})
+			c.Abort()
+		} else {
+			c.Set("authUser", user)
+		}
+		c.Next()
+	}
+}
+
 // UserPermissionMiddleware ensures a user has user role
 func (h *Handler) UserPermissionMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -47,3 +68,20 @@ func (h *Handler) AdminPermissionMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// TrackingRateLimitMiddleware limits how often a client can look up orders, and how often any
+// one tracking code can be tried, so emails cannot be guessed for a code
+func (h *Handler) TrackingRateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter := h.controller.Middleware().TrackingLimiter
+		for _, key := range []string{"ip:" + c.ClientIP(), "code:" + c.Param("code")} {
+			if ok, retryAfter := limiter.Allow(key); !ok {
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				c.JSON(http.StatusTooManyRequests, models.ResponseObject{Code: http.StatusTooManyRequests, Error: messages.ErrTooManyRequests, Status: "too-many-requests", Message: messages.ErrTooManyRequests.Error()})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -40,6 +40,33 @@ func (h *Handler) PlaceOrder(c *gin.Context) {
 	c.JSON(result.Code, result)
 }
 
+// @Tags Order
+// @Summary Price Order
+// @Schemes
+// @Description Prices an order with its promotions and coupon without placing it
+// @Param   request   body     models.PlaceOrderDto   true  "data of the order to price"
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.ResponseObject{data=models.Order} "desc"
+// @Router /orders/price [post]
+func (h *Handler) PriceOrder(c *gin.Context) {
+	var input models.PlaceOrderDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.PriceOrder(c, &input, user)
+	c.JSON(result.Code, result)
+}
+
 // @Tags Order
 // @Summary Get All Orders
 // @Description Gets All Orders
@@ -59,7 +86,7 @@ func (h *Handler) GetAllOrders(c *gin.Context) {
 
 // @Tags Order
 // @Summary Get Single Order
-// @Description Get Single Order by id
+// @Description Get Single Order by id, admins can get any order with its shipping address
 // @Accept  json
 // @Produce  json
 // @Param   id   path     string   true  "Order Id"
@@ -69,7 +96,8 @@ func (h *Handler) GetAllOrders(c *gin.Context) {
 // @Router /orders/{id} [get]
 func (h *Handler) GetSingleOrder(c *gin.Context) {
 	id, _ := uuid.Parse(c.Param("id"))
-	result := h.controller.GetSingleOrder(c, id)
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.GetSingleOrder(c, id, user)
 	c.JSON(result.Code, result)
 }
 
@@ -105,7 +133,7 @@ func (h *Handler) UpdateOrderStatus(c *gin.Context) {
 
 // @Tags Order
 // @Summary Cancel Order
-// @Description Cancel Order with a given Id
+// @Description Cancels an order of the user that is still waiting for payment
 // @Accept  json
 // @Produce  json
 // @Param   id   path     string   true  "Order Id"
@@ -119,3 +147,31 @@ func (h *Handler) CancelOrder(c *gin.Context) {
 	result := h.controller.CancelOrder(c, id, user)
 	c.JSON(result.Code, result)
 }
+
+// @Tags Order
+// @Summary Track Order
+// @Description Gets the status timeline of an order by its tracking code, with the customer's email as a second factor. Rate limited per client and per code.
+// @Accept  json
+// @Produce  json
+// @Param   code    path     string   true  "Tracking Code"
+// @Param   email   query    string   true  "Email of the customer who placed the order"
+// @Success 200 {string} {object} models.ResponseObject{data=models.OrderTracking} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 429 {object} map[string]interface{} "Too many requests"
+// @Router /track/{code} [get]
+func (h *Handler) TrackOrder(c *gin.Context) {
+	var input models.TrackOrderDto
+	// bind input
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err.Error(), Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.TrackOrder(c, c.Param("code"), &input)
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/payments"
+	"e-commerce/models"
+)
+
+// @Tags Payment
+// @Summary Initialize Payment
+// @Description Starts a payment for a pending order and returns the payment reference and url to pay at
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Order Id"
+// @Success 201 {string} {object} models.ResponseObject{data=models.Payment} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /orders/{id}/payments [post]
+func (h *Handler) InitializePayment(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.InitializePayment(c, id, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Payment
+// @Summary Verify Payment
+// @Description Verifies a payment with its provider and moves a paid order to processing
+// @Accept  json
+// @Produce  json
+// @Param   reference   path     string   true  "Payment Reference"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Payment} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /payments/{reference}/verify [get]
+func (h *Handler) VerifyPayment(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.VerifyPayment(c, c.Param("reference"), user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Payment
+// @Summary Complete Fake Payment
+// @Description Completes a charge of the fake payment provider, standing in for the provider's payment page. Only served in local and dev environments.
+// @Accept  json
+// @Produce  json
+// @Param   reference   path     string   true  "Provider Reference"
+// @Param   status   query     string   false  "success or failed, defaults to success"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Payment} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /payments/fake/{reference} [post]
+func (h *Handler) CompleteFakePayment(c *gin.Context) {
+	status := payments.ChargeStatus(c.Query("status"))
+	result := h.controller.CompleteFakePayment(c, c.Param("reference"), status)
+	c.JSON(result.Code, result)
+}
@@ -42,17 +42,40 @@ func (h *Handler) CreateProduct(c *gin.Context) {
 
 // @Tags Product
 // @Summary Get All Products
-// @Description Gets All products
+// @Description Gets All products, narrowed down by category, status, brand, price and option values. With facets set, the
+// @Description counts of products for each value of those are returned too, each applying every active filter but its own.
+// @Description The filter parameter takes {field}|{eq,ne,in,like,gt,lt}|{value} entries on name, slug, brand, price, discount,
+// @Description currency, tax_class, status, created_at and attributes.{key}; other fields and conditions are rejected.
 // @Accept  json
 // @Produce  json
 // @Param   request   body     models.APIPagingDto   true  "data to query for all "
+// @Param   category  query    string   false  "Id or slug of a category, products of its subcategories are included"
+// @Param   status    query    []string false  "Statuses of the products" collectionFormat(multi)
+// @Param   brand     query    []string false  "Brands of the products" collectionFormat(multi)
+// @Param   currency  query    string   false  "Currency of the products, needed to filter by price"
+// @Param   min_price query    int      false  "Lowest price after discount, in the minor unit of currency"
+// @Param   max_price query    int      false  "Highest price after discount, in the minor unit of currency"
+// @Param   option    query    []string false  "Option values as name:value" collectionFormat(multi)
+// @Param   facets    query    bool     false  "Return facet counts with the products"
 // @Success 200 {string} {object} models.ResponseObject{data=models.ProductsResponse} "desc"
 // @Failure 400 {object} map[string]interface{} "Invalid input"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Router /products [get]
 func (h *Handler) GetAllProducts(c *gin.Context) {
 	query := getPagingInfo(c)
-	result := h.controller.GetAllProducts(c, query)
+	var input models.ProductQueryDto
+	// bind input
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err.Error(), Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.GetAllProducts(c, query, &input)
 	c.JSON(result.Code, result)
 }
 
@@ -84,6 +107,7 @@ func (h *Handler) GetSingleProduct(c *gin.Context) {
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
 // @Router /products/{id} [put]
 func (h *Handler) UpdateProduct(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
 	id, _ := uuid.Parse(c.Param("id"))
 	var input models.UpdateProductDto
 	// bind input
@@ -98,13 +122,13 @@ func (h *Handler) UpdateProduct(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
 		return
 	}
-	result := h.controller.UpdateProduct(c, &input, id)
+	result := h.controller.UpdateProduct(c, &input, id, user)
 	c.JSON(result.Code, result)
 }
 
 // @Tags Product
 // @Summary Delete Product
-// @Description Delete product by id
+// @Description Delete product by id, products that were ordered cannot be deleted, their stock history is kept
 // @Accept  json
 // @Produce  json
 // @Param   id   path     string   true  "Product Id"
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Promotion
+// @Summary Create Promotion
+// @Description Creates an automatic promotion
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.CreatePromotionDto   true  "data to create promotion with"
+// @Success 201 {string} {object} models.ResponseObject{data=models.Promotion} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /promotions [post]
+func (h *Handler) CreatePromotion(c *gin.Context) {
+	var input models.CreatePromotionDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreatePromotion(c, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Promotion
+// @Summary Get All Promotions
+// @Description Gets all promotions
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.APIPagingDto   true  "data to query for all "
+// @Success 200 {string} {object} models.ResponseObject{data=models.PromotionsResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /promotions [get]
+func (h *Handler) GetAllPromotions(c *gin.Context) {
+	query := getPagingInfo(c)
+	result := h.controller.GetAllPromotions(c, query)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Promotion
+// @Summary Get Single Promotion
+// @Description Gets a single promotion by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Promotion Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Promotion} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /promotions/{id} [get]
+func (h *Handler) GetSinglePromotion(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.GetSinglePromotion(c, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Promotion
+// @Summary Update Promotion
+// @Description Updates a promotion with a given id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Promotion Id"
+// @Param   request   body     models.UpdatePromotionDto   true  "data to update promotion with"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /promotions/{id} [put]
+func (h *Handler) UpdatePromotion(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.UpdatePromotionDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdatePromotion(c, &input, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Promotion
+// @Summary Delete Promotion
+// @Description Deletes a promotion by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Promotion Id"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /promotions/{id} [delete]
+func (h *Handler) DeletePromotion(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.DeletePromotion(c, id)
+	c.JSON(result.Code, result)
+}
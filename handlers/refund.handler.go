@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Refund
+// @Summary Create Refund
+// @Description Refunds an order in full, by amount, or by order records and quantities
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Order Id"
+// @Param   request   body     models.CreateRefundDto   true  "data to refund order with"
+// @Success 201 {string} {object} models.ResponseObject{data=models.Refund} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /orders/{id}/refunds [post]
+func (h *Handler) CreateRefund(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.CreateRefundDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.CreateRefund(c, id, &input, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Refund
+// @Summary Get Order Refunds
+// @Description Gets all refunds issued on an order
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Order Id"
+// @Success 200 {string} {object} models.ResponseObject{data=[]models.Refund} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /orders/{id}/refunds [get]
+func (h *Handler) GetOrderRefunds(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.GetOrderRefunds(c, id)
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Return
+// @Summary Create Return
+// @Description Asks to return some of the records of a delivered order, each with a reason, within the products' return windows
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Order Id"
+// @Param   request   body     models.CreateReturnDto   true  "order records to return"
+// @Success 201 {string} {object} models.ResponseObject{data=models.ReturnRequest} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /orders/{id}/returns [post]
+func (h *Handler) CreateReturn(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	var input models.CreateReturnDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateReturn(c, id, &input, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Return
+// @Summary Get Order Returns
+// @Description Gets the returns of an order
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Order Id"
+// @Success 200 {string} {object} models.ResponseObject{data=[]models.ReturnRequest} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /orders/{id}/returns [get]
+func (h *Handler) GetOrderReturns(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.GetOrderReturns(c, id, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Return
+// @Summary Get All Returns
+// @Description Gets all returns
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.APIPagingDto   true  "data to query for all "
+// @Success 200 {string} {object} models.ResponseObject{data=models.ReturnRequestsResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /returns [get]
+func (h *Handler) GetAllReturns(c *gin.Context) {
+	query := getPagingInfo(c)
+	result := h.controller.GetAllReturns(c, query)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Return
+// @Summary Get Single Return
+// @Description Gets a single return by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Return Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ReturnRequest} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /returns/{id} [get]
+func (h *Handler) GetSingleReturn(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.GetSingleReturn(c, id, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Return
+// @Summary Approve Return
+// @Description Approves a requested return and works out its refundable amount
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Return Id"
+// @Param   request   body     models.ReviewReturnDto   true  "review of the return"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ReturnRequest} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /returns/{id}/approve [put]
+func (h *Handler) ApproveReturn(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	var input models.ReviewReturnDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.ApproveReturn(c, id, &input, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Return
+// @Summary Reject Return
+// @Description Rejects a requested return
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Return Id"
+// @Param   request   body     models.ReviewReturnDto   true  "review of the return"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ReturnRequest} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /returns/{id}/reject [put]
+func (h *Handler) RejectReturn(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	var input models.ReviewReturnDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.RejectReturn(c, id, &input, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Return
+// @Summary Receive Return
+// @Description Records the arrival of the items of an approved return, optionally restocking them
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Return Id"
+// @Param   request   body     models.ReceiveReturnDto   true  "whether to restock the items"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ReturnRequest} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /returns/{id}/receive [put]
+func (h *Handler) ReceiveReturn(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.ReceiveReturnDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.ReceiveReturn(c, id, &input, user)
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Search
+// @Summary Search Products
+// @Description Searches product names and descriptions, names weigh more and small typos in names are tolerated. Results come best match first with the matched words wrapped in <mark> tags
+// @Accept  json
+// @Produce  json
+// @Param   q         query    string   true   "Words to search for"
+// @Param   category  query    string   false  "Id or slug of a category, products of its subcategories are included"
+// @Param   page      query    int      false  "Page of results"
+// @Param   limit     query    int      false  "Results per page"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ProductSearchResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/search [get]
+func (h *Handler) SearchProducts(c *gin.Context) {
+	query := getPagingInfo(c)
+	var input models.ProductSearchDto
+	// bind input
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err.Error(), Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.SearchProducts(c, query, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Search
+// @Summary Autocomplete Products
+// @Description Suggests products for what has been typed in the search box, names starting with it come first
+// @Accept  json
+// @Produce  json
+// @Param   q      query    string   true   "What has been typed so far"
+// @Param   limit  query    int      false  "Number of suggestions, 8 when empty"
+// @Success 200 {string} {object} models.ResponseObject{data=[]models.ProductSuggestion} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/autocomplete [get]
+func (h *Handler) AutocompleteProducts(c *gin.Context) {
+	var input models.ProductAutocompleteDto
+	// bind input
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err.Error(), Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.AutocompleteProducts(c, &input)
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/carriers"
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Shipment
+// @Summary Create Shipment
+// @Description Books a parcel of some or all of an order's records with the carrier, everything not yet shipped when no items are given
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Order Id"
+// @Param   request   body     models.CreateShipmentDto   true  "order records to ship"
+// @Success 201 {string} {object} models.ResponseObject{data=models.Shipment} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /orders/{id}/shipments [post]
+func (h *Handler) CreateShipment(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	var input models.CreateShipmentDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateShipment(c, id, &input, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipment
+// @Summary Get Order Shipments
+// @Description Gets the shipments of an order with their tracking events
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Order Id"
+// @Success 200 {string} {object} models.ResponseObject{data=[]models.Shipment} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /orders/{id}/shipments [get]
+func (h *Handler) GetOrderShipments(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	user := c.MustGet("authUser").(*models.User) // auth user
+	result := h.controller.GetOrderShipments(c, id, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipment
+// @Summary Refresh Shipment Tracking
+// @Description Polls the carrier for the tracking events of a shipment
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Shipment Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Shipment} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipments/{id}/track [post]
+func (h *Handler) RefreshShipmentTracking(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.RefreshShipmentTracking(c, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipment
+// @Summary Scan Fake Shipment
+// @Description Scans a parcel of the fake carrier, standing in for the carrier moving it. Only served in local and dev environments.
+// @Accept  json
+// @Produce  json
+// @Param   tracking_number   path     string   true  "Tracking Number"
+// @Param   status   query     string   false  "in-transit, out-for-delivery, delivered or exception, defaults to in-transit"
+// @Param   location   query     string   false  "where the parcel was scanned"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Shipment} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipments/fake/{tracking_number} [post]
+func (h *Handler) ScanFakeShipment(c *gin.Context) {
+	status := carriers.TrackingStatus(c.Query("status"))
+	result := h.controller.ScanFakeShipment(c, c.Param("tracking_number"), status, c.Query("location"))
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Shipping
+// @Summary Create Shipping Zone
+// @Description Creates a shipping zone of countries, or regions of them
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.CreateShippingZoneDto   true  "data to create shipping zone with"
+// @Success 201 {string} {object} models.ResponseObject{data=models.ShippingZone} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipping/zones [post]
+func (h *Handler) CreateShippingZone(c *gin.Context) {
+	var input models.CreateShippingZoneDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateShippingZone(c, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipping
+// @Summary Get All Shipping Zones
+// @Description Gets all shipping zones with their methods
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.APIPagingDto   true  "data to query for all "
+// @Success 200 {string} {object} models.ResponseObject{data=models.ShippingZonesResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipping/zones [get]
+func (h *Handler) GetAllShippingZones(c *gin.Context) {
+	query := getPagingInfo(c)
+	result := h.controller.GetAllShippingZones(c, query)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipping
+// @Summary Get Single Shipping Zone
+// @Description Gets a single shipping zone by id with its methods
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Shipping Zone Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.ShippingZone} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipping/zones/{id} [get]
+func (h *Handler) GetSingleShippingZone(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.GetSingleShippingZone(c, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipping
+// @Summary Update Shipping Zone
+// @Description Updates a shipping zone with a given id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Shipping Zone Id"
+// @Param   request   body     models.UpdateShippingZoneDto   true  "data to update shipping zone with"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipping/zones/{id} [put]
+func (h *Handler) UpdateShippingZone(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.UpdateShippingZoneDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateShippingZone(c, &input, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipping
+// @Summary Delete Shipping Zone
+// @Description Deletes a shipping zone and its methods by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Shipping Zone Id"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipping/zones/{id} [delete]
+func (h *Handler) DeleteShippingZone(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.DeleteShippingZone(c, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipping
+// @Summary Create Shipping Method
+// @Description Adds a shipping method with its rate table to a shipping zone
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Shipping Zone Id"
+// @Param   request   body     models.CreateShippingMethodDto   true  "data to create shipping method with"
+// @Success 201 {string} {object} models.ResponseObject{data=models.ShippingMethod} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipping/zones/{id}/methods [post]
+func (h *Handler) CreateShippingMethod(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.CreateShippingMethodDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateShippingMethod(c, &input, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipping
+// @Summary Update Shipping Method
+// @Description Updates a shipping method with a given id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Shipping Method Id"
+// @Param   request   body     models.UpdateShippingMethodDto   true  "data to update shipping method with"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipping/methods/{id} [put]
+func (h *Handler) UpdateShippingMethod(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.UpdateShippingMethodDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateShippingMethod(c, &input, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipping
+// @Summary Delete Shipping Method
+// @Description Deletes a shipping method by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Shipping Method Id"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipping/methods/{id} [delete]
+func (h *Handler) DeleteShippingMethod(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.DeleteShippingMethod(c, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Shipping
+// @Summary Quote Shipping
+// @Description Gets the shipping methods available for products to a destination and their fees, cheapest first
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.ShippingQuoteDto   true  "products and destination to quote shipping for"
+// @Success 200 {string} {object} models.ResponseObject{data=[]models.ShippingQuote} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /shipping/quote [post]
+func (h *Handler) QuoteShipping(c *gin.Context) {
+	var input models.ShippingQuoteDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.QuoteShipping(c, &input)
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Tax
+// @Summary Create Tax Rate
+// @Description Creates a tax rate for a tax class of products in a country or region
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.CreateTaxRateDto   true  "data to create tax rate with"
+// @Success 201 {string} {object} models.ResponseObject{data=models.TaxRate} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /taxes/rates [post]
+func (h *Handler) CreateTaxRate(c *gin.Context) {
+	var input models.CreateTaxRateDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateTaxRate(c, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Tax
+// @Summary Get All Tax Rates
+// @Description Gets all tax rates
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.APIPagingDto   true  "data to query for all "
+// @Success 200 {string} {object} models.ResponseObject{data=models.TaxRatesResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /taxes/rates [get]
+func (h *Handler) GetAllTaxRates(c *gin.Context) {
+	query := getPagingInfo(c)
+	result := h.controller.GetAllTaxRates(c, query)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Tax
+// @Summary Get Single Tax Rate
+// @Description Gets a single tax rate by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Tax Rate Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.TaxRate} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /taxes/rates/{id} [get]
+func (h *Handler) GetSingleTaxRate(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.GetSingleTaxRate(c, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Tax
+// @Summary Update Tax Rate
+// @Description Updates a tax rate with a given id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Tax Rate Id"
+// @Param   request   body     models.UpdateTaxRateDto   true  "data to update tax rate with"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /taxes/rates/{id} [put]
+func (h *Handler) UpdateTaxRate(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.UpdateTaxRateDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateTaxRate(c, &input, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Tax
+// @Summary Set Tax Exemption
+// @Description Exempts a customer from tax on their orders, or removes the exemption
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "User Id"
+// @Param   request   body     models.SetTaxExemptionDto   true  "tax exemption to set"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /users/{id}/tax-exemption [put]
+func (h *Handler) SetTaxExemption(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.SetTaxExemptionDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.SetTaxExemption(c, id, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Tax
+// @Summary Get Tax Report
+// @Description Sums the tax collected on paid orders between two dates by period and tax rate
+// @Accept  json
+// @Produce  json
+// @Param   from       query    string   true   "First day of the report, YYYY-MM-DD"
+// @Param   to         query    string   true   "Last day of the report, YYYY-MM-DD"
+// @Param   period     query    string   false  "day, week or month, month by default"
+// @Param   currency   query    string   false  "Only include orders in a currency"
+// @Success 200 {string} {object} models.ResponseObject{data=models.TaxReport} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /taxes/report [get]
+func (h *Handler) GetTaxReport(c *gin.Context) {
+	var input models.TaxReportDto
+	// bind input
+	err := c.ShouldBindQuery(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err.Error(), Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.GetTaxReport(c, &input)
+	c.JSON(result.Code, result)
+}
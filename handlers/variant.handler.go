@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Variant
+// @Summary Create Product Variant
+// @Description Adds a variant to a product with its own SKU, stock and optionally price, with a value for each of the product's options
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Product Id"
+// @Param   request   body     models.CreateVariantDto   true  "data to create a variant"
+// @Success 201 {string} {object} models.ResponseObject{data=models.ProductVariant} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/variants [post]
+func (h *Handler) CreateProductVariant(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.CreateVariantDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateProductVariant(c, id, &input, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Variant
+// @Summary Update Product Variant
+// @Description Updates a variant of a product, reset_price makes it sell at the product's price again
+// @Accept  json
+// @Produce  json
+// @Param   id           path     string   true  "Product Id"
+// @Param   variant_id   path     string   true  "Variant Id"
+// @Param   request   body     models.UpdateVariantDto   true  "data to update a variant"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/variants/{variant_id} [put]
+func (h *Handler) UpdateProductVariant(c *gin.Context) {
+	user := c.MustGet("authUser").(*models.User) // auth user
+	id, _ := uuid.Parse(c.Param("id"))
+	variantId, _ := uuid.Parse(c.Param("variant_id"))
+	var input models.UpdateVariantDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateProductVariant(c, id, variantId, &input, user)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Variant
+// @Summary Delete Product Variant
+// @Description Deletes a variant of a product, variants that were ordered cannot be deleted, their stock history is kept
+// @Accept  json
+// @Produce  json
+// @Param   id           path     string   true  "Product Id"
+// @Param   variant_id   path     string   true  "Variant Id"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /products/{id}/variants/{variant_id} [delete]
+func (h *Handler) DeleteProductVariant(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	variantId, _ := uuid.Parse(c.Param("variant_id"))
+	result := h.controller.DeleteProductVariant(c, id, variantId)
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+	"e-commerce/helpers"
+	"e-commerce/models"
+)
+
+// @Tags Warehouse
+// @Summary Create Warehouse
+// @Description Creates a warehouse stock is held in and orders are fulfilled from
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.CreateWarehouseDto   true  "data to create warehouse with"
+// @Success 201 {string} {object} models.ResponseObject{data=models.Warehouse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /warehouses [post]
+func (h *Handler) CreateWarehouse(c *gin.Context) {
+	var input models.CreateWarehouseDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.CreateWarehouse(c, &input)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Warehouse
+// @Summary Get All Warehouses
+// @Description Gets all warehouses
+// @Accept  json
+// @Produce  json
+// @Param   request   body     models.APIPagingDto   true  "data to query for all "
+// @Success 200 {string} {object} models.ResponseObject{data=models.WarehousesResponse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /warehouses [get]
+func (h *Handler) GetAllWarehouses(c *gin.Context) {
+	query := getPagingInfo(c)
+	result := h.controller.GetAllWarehouses(c, query)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Warehouse
+// @Summary Get Single Warehouse
+// @Description Gets a single warehouse by id
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Warehouse Id"
+// @Success 200 {string} {object} models.ResponseObject{data=models.Warehouse} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /warehouses/{id} [get]
+func (h *Handler) GetSingleWarehouse(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	result := h.controller.GetSingleWarehouse(c, id)
+	c.JSON(result.Code, result)
+}
+
+// @Tags Warehouse
+// @Summary Update Warehouse
+// @Description Updates a warehouse with a given id, making it the default takes over from the current default
+// @Accept  json
+// @Produce  json
+// @Param   id   path     string   true  "Warehouse Id"
+// @Param   request   body     models.UpdateWarehouseDto   true  "data to update warehouse with"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /warehouses/{id} [put]
+func (h *Handler) UpdateWarehouse(c *gin.Context) {
+	id, _ := uuid.Parse(c.Param("id"))
+	var input models.UpdateWarehouseDto
+	// bind input
+	err := c.BindJSON(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: inputErrors, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.UpdateWarehouse(c, &input, id)
+	c.JSON(result.Code, result)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"e-commerce/common/messages"
+	"e-commerce/models"
+)
+
+const webhookSignatureHeader = "X-Webhook-Signature"
+
+// @Tags Webhook
+// @Summary Payment Webhook
+// @Description Receives charge notifications from a payment provider, signed with HMAC-SHA256 of the body
+// @Accept  json
+// @Produce  json
+// @Param   provider   path     string   true  "Payment Provider"
+// @Param   X-Webhook-Signature   header     string   true  "Hex encoded HMAC-SHA256 signature of the body"
+// @Success 200 {string} {object} models.ResponseObject{} "desc"
+// @Failure 400 {object} map[string]interface{} "Invalid input"
+// @Failure 401 {object} map[string]interface{} "Invalid signature"
+// @Router /webhooks/payments/{provider} [post]
+func (h *Handler) PaymentWebhook(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ResponseObject{Code: http.StatusBadRequest, Error: err, Status: "bad-request", Message: messages.ErrInvalidInput.Error()})
+		return
+	}
+	result := h.controller.HandlePaymentWebhook(c, c.Param("provider"), payload, c.GetHeader(webhookSignatureHeader))
+	c.JSON(result.Code, result)
+}
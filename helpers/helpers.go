@@ -43,6 +43,8 @@ func ValidateInput(input interface{}) []string {
 			switch e.ActualTag() {
 			case "required":
 				errors = append(errors, fmt.Sprintf("%s field is required", e.Field()))
+			case "required_without":
+				errors = append(errors, fmt.Sprintf("%s field is required when %s is not given", e.Field(), e.Param()))
 			case "email":
 				errors = append(errors, fmt.Sprintf("%s must be a valid email", e.Field()))
 			case "url":
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -12,11 +14,13 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	"e-commerce/common/storage"
 	"e-commerce/config"
 	"e-commerce/db"
 	"e-commerce/docs"
 	"e-commerce/handlers"
 	"e-commerce/routes"
+	"e-commerce/workers"
 )
 
 func main() {
@@ -33,12 +37,26 @@ func main() {
 	//load configurations
 	configVariables := config.GetConfig()
 
+	// the client address is only taken from forwarding headers set by trusted proxies, none by default
+	trustedProxies := []string{}
+	for _, proxy := range strings.Split(configVariables.TrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+	if err := server.SetTrustedProxies(trustedProxies); err != nil {
+		zlog.Fatal().Msgf("trusted proxies: %s", err)
+	}
+
 	db := db.ConnectDB(*configVariables)
 
 	handler := handlers.NewHandler(configVariables, &db)
 
+	// start background jobs
+	workers.Start(context.Background(), handler.Controller(), configVariables)
+
 	// register routes
-	r := routes.NewRoutes(handler)
+	r := routes.NewRoutes(handler, configVariables)
 
 	// programmatically set swagger info
 	docs.SwaggerInfo.Title = "Task App APIs"
@@ -57,6 +75,11 @@ func main() {
 	})
 
 	r.RegisterRoutes(server, handler)
+
+	// product media kept on disk is served by the app itself
+	if configVariables.Storage == storage.STORAGE_LOCAL {
+		server.Static("/media", configVariables.StoragePath)
+	}
 	//run server
 	if err := server.Run(fmt.Sprintf("%s:%s", configVariables.AppHost, configVariables.Port)); err != nil && err != http.ErrServerClosed {
 		zlog.Fatal().Msgf("listen: %s", err)
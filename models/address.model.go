@@ -0,0 +1,140 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+)
+
+// Address is an address in a user's address book
+type Address struct {
+	Id         uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	UserId     uuid.UUID `json:"user_id"`
+	FullName   string    `json:"full_name"`
+	Phone      string    `json:"phone"`
+	Line1      string    `json:"line1"`
+	Line2      string    `json:"line2"`
+	City       string    `json:"city"`
+	Region     string    `json:"region"`
+	PostalCode string    `json:"postal_code"`
+	Country    string    `json:"country"`
+	// a user has at most one default shipping and one default billing address
+	IsDefaultShipping bool      `json:"is_default_shipping"`
+	IsDefaultBilling  bool      `json:"is_default_billing"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// OrderAddress is the copy of an address kept on an order, so later changes to the
+// address book do not change where an order went
+type OrderAddress struct {
+	FullName   string `json:"full_name"`
+	Phone      string `json:"phone"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country"`
+}
+
+// AddressRule is what an address needs in a country
+type AddressRule struct {
+	RequireRegion     bool
+	RequirePostalCode bool
+	// postal codes are checked against the pattern when given
+	PostalCode *regexp.Regexp
+}
+
+// addressRules are the countries with rules beyond the fields every address needs
+var addressRules = map[string]AddressRule{
+	"NG": {RequireRegion: true, PostalCode: regexp.MustCompile(`^\d{6}$`)},
+	"GH": {RequireRegion: true},
+	"KE": {PostalCode: regexp.MustCompile(`^\d{5}$`)},
+	"ZA": {RequirePostalCode: true, PostalCode: regexp.MustCompile(`^\d{4}$`)},
+	"US": {RequireRegion: true, RequirePostalCode: true, PostalCode: regexp.MustCompile(`^\d{5}(-\d{4})?$`)},
+	"CA": {RequireRegion: true, RequirePostalCode: true, PostalCode: regexp.MustCompile(`^[A-Za-z]\d[A-Za-z] ?\d[A-Za-z]\d$`)},
+	"GB": {RequirePostalCode: true, PostalCode: regexp.MustCompile(`^[A-Za-z]{1,2}\d[A-Za-z\d]? ?\d[A-Za-z]{2}$`)},
+	"DE": {RequirePostalCode: true, PostalCode: regexp.MustCompile(`^\d{5}$`)},
+	"FR": {RequirePostalCode: true, PostalCode: regexp.MustCompile(`^\d{5}$`)},
+}
+
+// CreateAddressDto is the data transfer object to add an address to the address book
+type CreateAddressDto struct {
+	FullName          string `json:"full_name" validate:"required,max=256"`
+	Phone             string `json:"phone" validate:"required,max=50"`
+	Line1             string `json:"line1" validate:"required,max=256"`
+	Line2             string `json:"line2" validate:"omitempty,max=256"`
+	City              string `json:"city" validate:"required,max=256"`
+	Region            string `json:"region" validate:"omitempty,max=256"`
+	PostalCode        string `json:"postal_code" validate:"omitempty,max=20"`
+	Country           string `json:"country" validate:"required,len=2,alpha"`
+	IsDefaultShipping bool   `json:"is_default_shipping"`
+	IsDefaultBilling  bool   `json:"is_default_billing"`
+}
+
+// UpdateAddressDto is the data transfer object to update an address in the address book
+type UpdateAddressDto struct {
+	FullName          *string `json:"full_name" validate:"omitempty,max=256"`
+	Phone             *string `json:"phone" validate:"omitempty,max=50"`
+	Line1             *string `json:"line1" validate:"omitempty,max=256"`
+	Line2             *string `json:"line2" validate:"omitempty,max=256"`
+	City              *string `json:"city" validate:"omitempty,max=256"`
+	Region            *string `json:"region" validate:"omitempty,max=256"`
+	PostalCode        *string `json:"postal_code" validate:"omitempty,max=20"`
+	Country           *string `json:"country" validate:"omitempty,len=2,alpha"`
+	IsDefaultShipping *bool   `json:"is_default_shipping"`
+	IsDefaultBilling  *bool   `json:"is_default_billing"`
+}
+
+// Validate checks that an address has the fields its country needs
+func (a *Address) Validate() error {
+	if strings.TrimSpace(a.FullName) == "" || strings.TrimSpace(a.Line1) == "" || strings.TrimSpace(a.City) == "" {
+		return messages.ErrInvalidAddress
+	}
+	rule := addressRules[a.Country]
+	if rule.RequireRegion && strings.TrimSpace(a.Region) == "" {
+		return messages.ErrAddressRegionRequired
+	}
+	if rule.RequirePostalCode && strings.TrimSpace(a.PostalCode) == "" {
+		return messages.ErrAddressPostalCodeRequired
+	}
+	if a.PostalCode != "" && rule.PostalCode != nil && !rule.PostalCode.MatchString(a.PostalCode) {
+		return messages.ErrInvalidPostalCode
+	}
+	return nil
+}
+
+// Snapshot copies an address to keep on an order
+func (a *Address) Snapshot() *OrderAddress {
+	return &OrderAddress{
+		FullName:   a.FullName,
+		Phone:      a.Phone,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+	}
+}
+
+func (a OrderAddress) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+func (a *OrderAddress) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &a)
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockSubscriptionStatus string
+
+const (
+	SUBSCRIPTION_PENDING  StockSubscriptionStatus = "pending"
+	SUBSCRIPTION_NOTIFIED StockSubscriptionStatus = "notified"
+)
+
+// StockSubscription is a customer asking to be told when a sold out product can be ordered again.
+// Subscribers are notified once, a customer can subscribe again after.
+type StockSubscription struct {
+	Id        uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ProductId uuid.UUID `json:"product_id"`
+	UserId    uuid.UUID `json:"user_id"`
+	// email the notification is sent to, the customer's when they subscribed
+	Email      string     `json:"email"`
+	Status     string     `json:"status"`
+	NotifiedAt *time.Time `json:"notified_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// LowStockItem is a product, or one of its variants, whose stock is at or below the reorder threshold of the product
+type LowStockItem struct {
+	ProductId         uuid.UUID  `json:"product_id"`
+	VariantId         *uuid.UUID `json:"variant_id"`
+	Name              string     `json:"name"`
+	Sku               string     `json:"sku"`
+	AvailableQuantity int64      `json:"available_quantity"`
+	ReorderThreshold  int64      `json:"reorder_threshold"`
+}
+
+// IsValid checks if stock subscription status is valid
+func (s StockSubscriptionStatus) IsValid() bool {
+	switch s {
+	case SUBSCRIPTION_PENDING, SUBSCRIPTION_NOTIFIED:
+		return true
+	}
+	return false
+}
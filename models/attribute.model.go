@@ -0,0 +1,141 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+)
+
+type AttributeType string
+
+const (
+	ATTRIBUTE_TEXT    AttributeType = "text"
+	ATTRIBUTE_NUMBER  AttributeType = "number"
+	ATTRIBUTE_ENUM    AttributeType = "enum"
+	ATTRIBUTE_BOOLEAN AttributeType = "boolean"
+)
+
+// CategoryAttribute is a spec products of a category, and of its subcategories, can be given
+type CategoryAttribute struct {
+	Id         uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	CategoryId uuid.UUID `json:"category_id"`
+	// name of the attribute in the product attributes and in listing filters
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// values an enum attribute can take
+	Values    StringList `json:"values"`
+	Required  bool       `json:"required"`
+	Position  int64      `json:"position"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ProductAttributes is the value of each attribute of a product, stored as a json object
+type ProductAttributes map[string]interface{}
+
+// CreateAttributeDto is the data transfer object to add an attribute to a category
+type CreateAttributeDto struct {
+	// lowercase letters, digits and underscores, starting with a letter
+	Key  string        `json:"key" validate:"required,max=50"`
+	Name string        `json:"name" validate:"required,max=100"`
+	Type AttributeType `json:"type" validate:"required,is_enum"`
+	// needed for enum attributes
+	Values   []string `json:"values" validate:"omitempty,dive,required,max=100"`
+	Required bool     `json:"required"`
+	Position int64    `json:"position" validate:"min=0"`
+}
+
+// UpdateAttributeDto is the data transfer object to update an attribute, its key and type cannot change.
+// Products are checked against the new definition the next time their attributes are set.
+type UpdateAttributeDto struct {
+	Name     *string   `json:"name" validate:"omitempty,max=100"`
+	Values   *[]string `json:"values" validate:"omitempty,dive,required,max=100"`
+	Required *bool     `json:"required"`
+	Position *int64    `json:"position" validate:"omitempty,min=0"`
+}
+
+// IsValid checks if attribute type is valid
+func (t AttributeType) IsValid() bool {
+	switch t {
+	case ATTRIBUTE_TEXT, ATTRIBUTE_NUMBER, ATTRIBUTE_ENUM, ATTRIBUTE_BOOLEAN:
+		return true
+	}
+	return false
+}
+
+// Fits checks that a value has the type of the attribute, and is one of its values for enums
+func (a *CategoryAttribute) Fits(value interface{}) bool {
+	switch AttributeType(a.Type) {
+	case ATTRIBUTE_TEXT:
+		_, ok := value.(string)
+		return ok
+	case ATTRIBUTE_NUMBER:
+		_, ok := value.(float64)
+		return ok
+	case ATTRIBUTE_BOOLEAN:
+		_, ok := value.(bool)
+		return ok
+	case ATTRIBUTE_ENUM:
+		text, ok := value.(string)
+		return ok && a.Values.Contains(text)
+	}
+	return false
+}
+
+// Validate checks the attributes of a product against the attributes of its categories. An attribute
+// defined by more than one of them must fit every definition.
+func (p ProductAttributes) Validate(schema []*CategoryAttribute) error {
+	defined := map[string]bool{}
+	for _, attribute := range schema {
+		defined[attribute.Key] = true
+		value, ok := p[attribute.Key]
+		if !ok || value == nil {
+			if attribute.Required {
+				return messages.ErrAttributeRequired
+			}
+			continue
+		}
+		if !attribute.Fits(value) {
+			return messages.ErrInvalidAttributeValue
+		}
+	}
+	for key, value := range p {
+		if !defined[key] && value != nil {
+			return messages.ErrUnknownAttribute
+		}
+	}
+	return nil
+}
+
+// Compact drops the attributes without a value
+func (p ProductAttributes) Compact() ProductAttributes {
+	attributes := ProductAttributes{}
+	for key, value := range p {
+		if value != nil {
+			attributes[key] = value
+		}
+	}
+	return attributes
+}
+
+func (p ProductAttributes) Value() (driver.Value, error) {
+	if p == nil {
+		p = ProductAttributes{}
+	}
+	return json.Marshal(p)
+}
+
+func (p *ProductAttributes) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &p)
+}
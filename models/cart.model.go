@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CartStatus string
+
+const (
+	CART_ACTIVE      CartStatus = "active"
+	CART_MERGED      CartStatus = "merged"
+	CART_CHECKED_OUT CartStatus = "checked-out"
+)
+
+// Cart is the shopping cart object, owned by a user or a guest cart token
+type Cart struct {
+	Id        uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	UserId    *uuid.UUID `json:"user_id"`
+	Token     string     `json:"token"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// one total per currency of the items
+	TotalAmount []Money     `json:"total_amount" gorm:"-"`
+	CartItems   []*CartItem `json:"cart_items" gorm:"foreignkey:CartId"`
+}
+
+// CartItem is a single product line in a cart
+type CartItem struct {
+	Id        uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	CartId    uuid.UUID  `json:"cart_id"`
+	ProductId uuid.UUID  `json:"product_id"`
+	VariantId *uuid.UUID `json:"variant_id"`
+	Quantity  int64      `json:"quantity"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	// live values loaded from the product on every read
+	Amount      Money  `json:"amount" gorm:"-"`
+	IsAvailable bool   `json:"is_available" gorm:"-"`
+	Note        string `json:"note,omitempty" gorm:"-"`
+}
+
+// AddCartItemDto is the data transfer object to add a product to the cart
+type AddCartItemDto struct {
+	ProductId string `json:"product_id" validate:"required,is_uuid"`
+	// required for products sold through variants
+	VariantId string `json:"variant_id" validate:"omitempty,is_uuid"`
+	Quantity  int64  `json:"quantity" validate:"required,is_amount"`
+}
+
+// UpdateCartItemDto is the data transfer object to update a cart item
+type UpdateCartItemDto struct {
+	Quantity int64 `json:"quantity" validate:"required,is_amount"`
+}
+
+// CheckoutCartDto is the data transfer object to convert a cart into an order
+type CheckoutCartDto struct {
+	Currency          Currency `json:"currency" validate:"required,is_enum"`
+	CouponCode        string   `json:"coupon_code" validate:"omitempty,max=50"`
+	ShippingAddressId string   `json:"shipping_address_id" validate:"omitempty,is_uuid"`
+	BillingAddressId  string   `json:"billing_address_id" validate:"omitempty,is_uuid"`
+	ShippingMethodId  string   `json:"shipping_method_id" validate:"omitempty,is_uuid"`
+}
+
+// GetTotalAmount gets total amount of the available items in a cart, for each currency
+func (c *Cart) GetTotalAmount() ([]Money, error) {
+	totals := []Money{}
+	for _, item := range c.CartItems {
+		if !item.IsAvailable {
+			continue
+		}
+		amount, err := item.Amount.Mul(item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+
+		added := false
+		for i, total := range totals {
+			if total.Currency == amount.Currency {
+				if totals[i], err = total.Add(amount); err != nil {
+					return nil, err
+				}
+				added = true
+			}
+		}
+		if !added {
+			totals = append(totals, amount)
+		}
+	}
+	return totals, nil
+}
+
+// IsValid checks if status is valid
+func (c CartStatus) IsValid() bool {
+	switch c {
+	case CART_ACTIVE, CART_MERGED, CART_CHECKED_OUT:
+		return true
+	}
+	return false
+}
+
+// IsFor checks if a cart item holds a product, or the given variant of it
+func (c *CartItem) IsFor(productId uuid.UUID, variantId *uuid.UUID) bool {
+	if c.ProductId != productId {
+		return false
+	}
+	if c.VariantId == nil || variantId == nil {
+		return c.VariantId == nil && variantId == nil
+	}
+	return *c.VariantId == *variantId
+}
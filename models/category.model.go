@@ -0,0 +1,90 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category is a node of the product taxonomy. Categories without a parent are at the top of the tree.
+type Category struct {
+	Id          uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ParentId    *uuid.UUID `json:"parent_id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	Description string     `json:"description"`
+	// order of the category among its siblings, lowest first
+	Position  int64     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Children []*Category `json:"children,omitempty" gorm:"-"`
+}
+
+// ProductCategory assigns a product to a category
+type ProductCategory struct {
+	ProductId  uuid.UUID `json:"product_id" gorm:"primaryKey"`
+	CategoryId uuid.UUID `json:"category_id" gorm:"primaryKey"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateCategoryDto is the data transfer object to create a category
+type CreateCategoryDto struct {
+	Name        string `json:"name" validate:"required,max=256"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+	// top level when empty
+	ParentId string `json:"parent_id" validate:"omitempty,is_uuid"`
+	Position int64  `json:"position" validate:"min=0"`
+}
+
+// UpdateCategoryDto is the data transfer object to update a category, MoveCategoryDto changes its parent
+type UpdateCategoryDto struct {
+	Name        *string `json:"name" validate:"omitempty,max=256"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+	Position    *int64  `json:"position" validate:"omitempty,min=0"`
+}
+
+// MoveCategoryDto is the data transfer object to move a category, with its subcategories, under another
+type MoveCategoryDto struct {
+	// moves the category to the top level when empty
+	ParentId string `json:"parent_id" validate:"omitempty,is_uuid"`
+	Position *int64 `json:"position" validate:"omitempty,min=0"`
+}
+
+// BuildCategoryTree nests categories under their parents, siblings ordered by position then name.
+// Categories whose parent is not in the list are placed at the top.
+func BuildCategoryTree(categories []*Category) []*Category {
+	byId := map[uuid.UUID]*Category{}
+	for _, category := range categories {
+		category.Children = nil
+		byId[category.Id] = category
+	}
+
+	roots := []*Category{}
+	for _, category := range categories {
+		if category.ParentId != nil {
+			if parent, ok := byId[*category.ParentId]; ok {
+				parent.Children = append(parent.Children, category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+
+	sortCategories(roots)
+	return roots
+}
+
+// sortCategories orders sibling categories, and theirs below them, by position then name
+func sortCategories(categories []*Category) {
+	sort.SliceStable(categories, func(i, j int) bool {
+		if categories[i].Position != categories[j].Position {
+			return categories[i].Position < categories[j].Position
+		}
+		return categories[i].Name < categories[j].Name
+	})
+	for _, category := range categories {
+		sortCategories(category.Children)
+	}
+}
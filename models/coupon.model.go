@@ -0,0 +1,180 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CouponType string
+type CouponStatus string
+
+const (
+	COUPON_PERCENTAGE CouponType = "percentage"
+	COUPON_FIXED      CouponType = "fixed"
+
+	COUPON_ACTIVE   CouponStatus = "active"
+	COUPON_INACTIVE CouponStatus = "inactive"
+)
+
+// Coupon is a promo code customers can apply to an order
+type Coupon struct {
+	Id             uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	Code           string     `json:"code"`
+	Description    string     `json:"description"`
+	Type           string     `json:"type"`
+	Value          int64      `json:"value"`
+	MaxDiscount    int64      `json:"max_discount"`
+	Currency       string     `json:"currency"`
+	MinOrderAmount int64      `json:"min_order_amount"`
+	ProductIds     UUIDList   `json:"product_ids"`
+	UsageLimit     int64      `json:"usage_limit"`
+	PerUserLimit   int64      `json:"per_user_limit"`
+	UsedCount      int64      `json:"used_count"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	// Stackable coupons also discount items already discounted on the product
+	Stackable bool      `json:"stackable"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CouponRedemption records a coupon used on an order
+type CouponRedemption struct {
+	Id        uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	CouponId  uuid.UUID `json:"coupon_id"`
+	UserId    uuid.UUID `json:"user_id"`
+	OrderId   uuid.UUID `json:"order_id"`
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UUIDList is a list of ids stored as a json array
+type UUIDList []uuid.UUID
+
+// CreateCouponDto is the data transfer object to create a coupon.
+// Percentage values are whole percents. Fixed values, maximum discounts and minimum order amounts are
+// in the coupon currency and converted to the currency of the order.
+type CreateCouponDto struct {
+	Code           string     `json:"code" validate:"required,min=3,max=50"`
+	Description    string     `json:"description" validate:"omitempty,max=256"`
+	Type           CouponType `json:"type" validate:"required,is_enum"`
+	Value          int64      `json:"value" validate:"required,is_amount"`
+	MaxDiscount    int64      `json:"max_discount" validate:"omitempty,is_amount"`
+	Currency       *Currency  `json:"currency" validate:"omitempty,is_enum"`
+	MinOrderAmount int64      `json:"min_order_amount" validate:"omitempty,is_amount"`
+	ProductIds     []string   `json:"product_ids" validate:"omitempty,dive,is_uuid"`
+	UsageLimit     int64      `json:"usage_limit" validate:"omitempty,is_amount"`
+	PerUserLimit   int64      `json:"per_user_limit" validate:"omitempty,is_amount"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	Stackable      bool       `json:"stackable"`
+}
+
+// UpdateCouponDto is the data transfer object to update a coupon
+type UpdateCouponDto struct {
+	Description    *string       `json:"description" validate:"omitempty,max=256"`
+	Value          *int64        `json:"value" validate:"omitempty,is_amount"`
+	MaxDiscount    *int64        `json:"max_discount" validate:"omitempty,is_amount"`
+	MinOrderAmount *int64        `json:"min_order_amount" validate:"omitempty,is_amount"`
+	ProductIds     *[]string     `json:"product_ids" validate:"omitempty,dive,is_uuid"`
+	UsageLimit     *int64        `json:"usage_limit" validate:"omitempty,is_amount"`
+	PerUserLimit   *int64        `json:"per_user_limit" validate:"omitempty,is_amount"`
+	StartsAt       *time.Time    `json:"starts_at"`
+	EndsAt         *time.Time    `json:"ends_at"`
+	Stackable      *bool         `json:"stackable"`
+	Status         *CouponStatus `json:"status" validate:"omitempty,is_enum"`
+}
+
+// CouponsResponse is the coupons data with pagination info
+type CouponsResponse struct {
+	Coupons    []*Coupon   `json:"coupons"`
+	PagingInfo *PagingInfo `json:"paging_info"`
+}
+
+// IsActiveAt checks if a coupon can be used at a given time
+func (c *Coupon) IsActiveAt(t time.Time) bool {
+	if c.Status != string(COUPON_ACTIVE) {
+		return false
+	}
+	if c.StartsAt != nil && t.Before(*c.StartsAt) {
+		return false
+	}
+	if c.EndsAt != nil && t.After(*c.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// AppliesTo checks if a product is eligible for the coupon
+func (c *Coupon) AppliesTo(productId uuid.UUID) bool {
+	if len(c.ProductIds) == 0 {
+		return true
+	}
+	for _, id := range c.ProductIds {
+		if id == productId {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDiscount gets the discount of the coupon on an eligible amount, with its maximum discount in the
+// same currency. Percentages are rounded half to even so many small orders do not drift in either direction.
+func (c *Coupon) GetDiscount(eligibleAmount Money, maxDiscount Money) (Money, error) {
+	var discount Money
+	switch CouponType(c.Type) {
+	case COUPON_PERCENTAGE:
+		var err error
+		if discount, err = eligibleAmount.Percent(c.Value, ROUND_HALF_EVEN); err != nil {
+			return Money{}, err
+		}
+		if maxDiscount.IsPositive() {
+			if discount, err = discount.Min(maxDiscount); err != nil {
+				return Money{}, err
+			}
+		}
+	case COUPON_FIXED:
+		discount = NewMoney(c.Value, eligibleAmount.Currency)
+	}
+	return discount.Min(eligibleAmount)
+}
+
+// IsValid checks if type is valid
+func (c CouponType) IsValid() bool {
+	switch c {
+	case COUPON_PERCENTAGE, COUPON_FIXED:
+		return true
+	}
+	return false
+}
+
+// IsValid checks if status is valid
+func (c CouponStatus) IsValid() bool {
+	switch c {
+	case COUPON_ACTIVE, COUPON_INACTIVE:
+		return true
+	}
+	return false
+}
+
+func (u UUIDList) Value() (driver.Value, error) {
+	if u == nil {
+		u = UUIDList{}
+	}
+	return json.Marshal(u)
+}
+
+func (u *UUIDList) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &u)
+}
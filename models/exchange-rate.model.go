@@ -0,0 +1,113 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+)
+
+// EXCHANGE_RATE_PRECISION is the number of decimals kept on stored and snapshotted rates
+const EXCHANGE_RATE_PRECISION = 12
+
+// ExchangeRate is the number of quote currency units one base currency unit buys
+type ExchangeRate struct {
+	Id            uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          string    `json:"rate"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ExchangeRateSnapshot keeps the rate used to convert each currency into the order currency
+type ExchangeRateSnapshot map[string]string
+
+// SetExchangeRateDto is the data transfer object to set the rate between two currencies
+type SetExchangeRateDto struct {
+	BaseCurrency  Currency `json:"base_currency" validate:"required,is_enum"`
+	QuoteCurrency Currency `json:"quote_currency" validate:"required,is_enum,nefield=BaseCurrency"`
+	Rate          string   `json:"rate" validate:"required,numeric"`
+}
+
+// ExchangeRatesResponse is the exchange rates data with pagination info
+type ExchangeRatesResponse struct {
+	ExchangeRates []*ExchangeRate `json:"exchange_rates"`
+	PagingInfo    *PagingInfo     `json:"paging_info"`
+}
+
+// ExchangeRateImportResponse is the result of an exchange rate import
+type ExchangeRateImportResponse struct {
+	Imported int      `json:"imported"`
+	Errors   []string `json:"errors"`
+}
+
+// ParseExchangeRate parses a rate, which must be a positive decimal
+func ParseExchangeRate(rate string) (*big.Rat, bool) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() <= 0 {
+		return nil, false
+	}
+	return r, true
+}
+
+// Inverse gets the rate for converting the other way
+func (e *ExchangeRate) Inverse() (*ExchangeRate, error) {
+	r, ok := ParseExchangeRate(e.Rate)
+	if !ok {
+		return nil, errors.New("invalid exchange rate")
+	}
+	return &ExchangeRate{
+		Id:            e.Id,
+		BaseCurrency:  e.QuoteCurrency,
+		QuoteCurrency: e.BaseCurrency,
+		Rate:          new(big.Rat).Inv(r).FloatString(EXCHANGE_RATE_PRECISION),
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}, nil
+}
+
+// Convert converts money in the base currency into the quote currency, rounding half up
+func (e *ExchangeRate) Convert(money Money) (Money, error) {
+	if money.Currency != Currency(e.BaseCurrency) {
+		return Money{}, messages.ErrCurrencyMismatch
+	}
+	r, ok := ParseExchangeRate(e.Rate)
+	if !ok {
+		return Money{}, errors.New("invalid exchange rate")
+	}
+
+	// the rate is between major units, scale it for the minor units of both currencies
+	quoteCurrency := Currency(e.QuoteCurrency)
+	scale := new(big.Rat).SetFrac(
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(quoteCurrency.Exponent())), nil),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(money.Currency.Exponent())), nil),
+	)
+	converted, err := money.MulRat(new(big.Rat).Mul(r, scale), ROUND_HALF_UP)
+	if err != nil {
+		return Money{}, err
+	}
+	converted.Currency = quoteCurrency
+	return converted, nil
+}
+
+func (s ExchangeRateSnapshot) Value() (driver.Value, error) {
+	if s == nil {
+		s = ExchangeRateSnapshot{}
+	}
+	return json.Marshal(s)
+}
+
+func (s *ExchangeRateSnapshot) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &s)
+}
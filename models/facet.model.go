@@ -0,0 +1,44 @@
+package models
+
+import "github.com/google/uuid"
+
+// ProductFacets are the counts of products found for each value they can be narrowed down by. The counts of
+// a facet apply every active filter but its own, so other values of the same facet can still be picked.
+type ProductFacets struct {
+	Categories []*CategoryFacet `json:"categories"`
+	Statuses   []*FacetValue    `json:"statuses"`
+	Brands     []*FacetValue    `json:"brands"`
+	Prices     []*PriceFacet    `json:"prices"`
+	Options    []*OptionFacet   `json:"options"`
+	// text, enum and boolean attributes only
+	Attributes []*OptionFacet `json:"attributes"`
+}
+
+// FacetValue is the number of products with a value
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// CategoryFacet is the number of products in a category, those of its subcategories included
+type CategoryFacet struct {
+	Id       uuid.UUID  `json:"id"`
+	ParentId *uuid.UUID `json:"parent_id"`
+	Name     string     `json:"name"`
+	Slug     string     `json:"slug"`
+	Count    int64      `json:"count"`
+}
+
+// PriceFacet is the number of products selling for between From and To, both included, in the minor unit of Currency
+type PriceFacet struct {
+	Currency string `json:"currency"`
+	From     int64  `json:"from"`
+	To       int64  `json:"to"`
+	Count    int64  `json:"count"`
+}
+
+// OptionFacet is the number of products with a variant of each value of an option, or with each value of an attribute
+type OptionFacet struct {
+	Name   string        `json:"name"`
+	Values []*FacetValue `json:"values"`
+}
@@ -0,0 +1,312 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"e-commerce/common/messages"
+)
+
+type ImportFormat string
+type ImportStatus string
+
+const (
+	IMPORT_CSV   ImportFormat = "csv"
+	IMPORT_JSONL ImportFormat = "jsonl"
+
+	IMPORT_PENDING   ImportStatus = "pending"
+	IMPORT_RUNNING   ImportStatus = "running"
+	IMPORT_COMPLETED ImportStatus = "completed"
+	IMPORT_FAILED    ImportStatus = "failed"
+)
+
+// PRODUCT_IMPORT_COLUMNS are the columns of product csv files, in the order they are exported.
+// Categories are ids or slugs separated by |, attributes and options are json objects.
+var PRODUCT_IMPORT_COLUMNS = []string{
+	"slug", "sku", "name", "description", "brand", "currency", "price", "discount", "quantity", "status",
+	"tax_class", "weight", "length", "width", "height", "return_window_days", "categories", "attributes",
+	"options", "position",
+}
+
+// ImportJob is a bulk import of products running in the background, with its progress and the errors of its rows
+type ImportJob struct {
+	Id     uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	Format string    `json:"format"`
+	// rows are only checked, nothing is saved
+	DryRun        bool   `json:"dry_run"`
+	Status        string `json:"status"`
+	TotalRows     int64  `json:"total_rows"`
+	ProcessedRows int64  `json:"processed_rows"`
+	// rows that created or updated a product or variant, or would have on a dry run
+	CreatedRows int64        `json:"created_rows"`
+	UpdatedRows int64        `json:"updated_rows"`
+	FailedRows  int64        `json:"failed_rows"`
+	Errors      ImportErrors `json:"errors"`
+	// why the whole import failed
+	Error      string     `json:"error"`
+	CreatedBy  *uuid.UUID `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
+
+// ImportError is why a row of an import failed. Rows are counted from 1, a csv header is not counted.
+type ImportError struct {
+	Row int64 `json:"row"`
+	// slug or sku the row is for
+	Key    string   `json:"key"`
+	Errors []string `json:"errors"`
+}
+
+// ImportErrors are the row errors of an import, stored as a json list
+type ImportErrors []ImportError
+
+// ProductImportRow is a row of a product import or export. Rows with a sku are variants of the product with
+// the slug, other rows are products matched by slug, or by the slug of their name, and created under it.
+// Products keep their slug when renamed by an import. Empty fields are left unchanged on products and
+// variants that already exist.
+type ProductImportRow struct {
+	Slug             string                  `json:"slug,omitempty"`
+	Sku              string                  `json:"sku,omitempty"`
+	Name             *string                 `json:"name,omitempty"`
+	Description      *string                 `json:"description,omitempty"`
+	Brand            *string                 `json:"brand,omitempty"`
+	Currency         *string                 `json:"currency,omitempty"`
+	Price            *int64                  `json:"price,omitempty"`
+	Discount         *int64                  `json:"discount,omitempty"`
+	Quantity         *int64                  `json:"quantity,omitempty"`
+	Status           *string                 `json:"status,omitempty"`
+	TaxClass         *string                 `json:"tax_class,omitempty"`
+	Weight           *int64                  `json:"weight,omitempty"`
+	Length           *int64                  `json:"length,omitempty"`
+	Width            *int64                  `json:"width,omitempty"`
+	Height           *int64                  `json:"height,omitempty"`
+	ReturnWindowDays *int64                  `json:"return_window_days,omitempty"`
+	Categories       *[]string               `json:"categories,omitempty"`
+	Attributes       *map[string]interface{} `json:"attributes,omitempty"`
+	Options          *map[string]string      `json:"options,omitempty"`
+	Position         *int64                  `json:"position,omitempty"`
+}
+
+// ProductImportDto is the data transfer object to import products
+type ProductImportDto struct {
+	// taken from the file extension when empty
+	Format ImportFormat `form:"format" validate:"omitempty,is_enum"`
+	DryRun bool         `form:"dry_run"`
+}
+
+// ProductExportDto is the data transfer object to export products
+type ProductExportDto struct {
+	// csv when empty
+	Format ImportFormat `form:"format" validate:"omitempty,is_enum"`
+}
+
+// IsValid checks if import format is valid
+func (f ImportFormat) IsValid() bool {
+	switch f {
+	case IMPORT_CSV, IMPORT_JSONL:
+		return true
+	}
+	return false
+}
+
+// ImportFormatOf gets the format of a file from its extension, empty when it is not known
+func ImportFormatOf(filename string) ImportFormat {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return IMPORT_CSV
+	case ".jsonl", ".ndjson", ".json":
+		return IMPORT_JSONL
+	}
+	return ""
+}
+
+// Key is the sku or slug a row is for
+func (r *ProductImportRow) Key() string {
+	if r.Sku != "" {
+		return r.Sku
+	}
+	return r.Slug
+}
+
+func (e ImportErrors) Value() (driver.Value, error) {
+	if e == nil {
+		e = ImportErrors{}
+	}
+	return json.Marshal(e)
+}
+
+func (e *ImportErrors) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &e)
+}
+
+// ParseProductImportRecord reads a csv record of a product import into a row, empty fields are left nil
+func ParseProductImportRecord(header []string, record []string) (*ProductImportRow, error) {
+	row := &ProductImportRow{}
+	for i, column := range header {
+		if i >= len(record) {
+			break
+		}
+		value := strings.TrimSpace(record[i])
+		if value == "" {
+			continue
+		}
+
+		var err error
+		switch column {
+		case "slug":
+			row.Slug = value
+		case "sku":
+			row.Sku = value
+		case "name":
+			row.Name = &value
+		case "description":
+			row.Description = &value
+		case "brand":
+			row.Brand = &value
+		case "currency":
+			row.Currency = &value
+		case "status":
+			row.Status = &value
+		case "tax_class":
+			row.TaxClass = &value
+		case "price":
+			row.Price, err = parseImportNumber(value)
+		case "discount":
+			row.Discount, err = parseImportNumber(value)
+		case "quantity":
+			row.Quantity, err = parseImportNumber(value)
+		case "weight":
+			row.Weight, err = parseImportNumber(value)
+		case "length":
+			row.Length, err = parseImportNumber(value)
+		case "width":
+			row.Width, err = parseImportNumber(value)
+		case "height":
+			row.Height, err = parseImportNumber(value)
+		case "return_window_days":
+			row.ReturnWindowDays, err = parseImportNumber(value)
+		case "position":
+			row.Position, err = parseImportNumber(value)
+		case "categories":
+			categories := []string{}
+			for _, category := range strings.Split(value, "|") {
+				if category = strings.TrimSpace(category); category != "" {
+					categories = append(categories, category)
+				}
+			}
+			row.Categories = &categories
+		case "attributes":
+			attributes := map[string]interface{}{}
+			err = json.Unmarshal([]byte(value), &attributes)
+			row.Attributes = &attributes
+		case "options":
+			options := map[string]string{}
+			err = json.Unmarshal([]byte(value), &options)
+			row.Options = &options
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", column, err.Error())
+		}
+	}
+	return row, nil
+}
+
+// CSVRecord writes a row as a csv record with PRODUCT_IMPORT_COLUMNS
+func (r *ProductImportRow) CSVRecord() ([]string, error) {
+	record := make([]string, len(PRODUCT_IMPORT_COLUMNS))
+	for i, column := range PRODUCT_IMPORT_COLUMNS {
+		switch column {
+		case "slug":
+			record[i] = r.Slug
+		case "sku":
+			record[i] = r.Sku
+		case "name":
+			record[i] = formatImportText(r.Name)
+		case "description":
+			record[i] = formatImportText(r.Description)
+		case "brand":
+			record[i] = formatImportText(r.Brand)
+		case "currency":
+			record[i] = formatImportText(r.Currency)
+		case "status":
+			record[i] = formatImportText(r.Status)
+		case "tax_class":
+			record[i] = formatImportText(r.TaxClass)
+		case "price":
+			record[i] = formatImportNumber(r.Price)
+		case "discount":
+			record[i] = formatImportNumber(r.Discount)
+		case "quantity":
+			record[i] = formatImportNumber(r.Quantity)
+		case "weight":
+			record[i] = formatImportNumber(r.Weight)
+		case "length":
+			record[i] = formatImportNumber(r.Length)
+		case "width":
+			record[i] = formatImportNumber(r.Width)
+		case "height":
+			record[i] = formatImportNumber(r.Height)
+		case "return_window_days":
+			record[i] = formatImportNumber(r.ReturnWindowDays)
+		case "position":
+			record[i] = formatImportNumber(r.Position)
+		case "categories":
+			if r.Categories != nil {
+				record[i] = strings.Join(*r.Categories, "|")
+			}
+		case "attributes":
+			if r.Attributes != nil {
+				value, err := json.Marshal(*r.Attributes)
+				if err != nil {
+					return nil, err
+				}
+				record[i] = string(value)
+			}
+		case "options":
+			if r.Options != nil {
+				value, err := json.Marshal(*r.Options)
+				if err != nil {
+					return nil, err
+				}
+				record[i] = string(value)
+			}
+		}
+	}
+	return record, nil
+}
+
+func parseImportNumber(value string) (*int64, error) {
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, messages.ErrImportInvalidNumber
+	}
+	return &number, nil
+}
+
+func formatImportNumber(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(*value, 10)
+}
+
+func formatImportText(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InventoryMovementType string
+
+const (
+	// INVENTORY_INITIAL is the stock a product or variant is created with
+	INVENTORY_INITIAL      InventoryMovementType = "initial"
+	INVENTORY_SALE         InventoryMovementType = "sale"
+	INVENTORY_CANCELLATION InventoryMovementType = "cancellation"
+	INVENTORY_RETURN       InventoryMovementType = "return"
+	INVENTORY_REFUND       InventoryMovementType = "refund"
+	INVENTORY_ADJUSTMENT   InventoryMovementType = "adjustment"
+	INVENTORY_IMPORT       InventoryMovementType = "import"
+)
+
+// InventoryMovement is an entry of the append-only inventory ledger, a change of the available quantity
+// of a product, or of one of its variants, and why it happened
+type InventoryMovement struct {
+	Id        uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ProductId uuid.UUID  `json:"product_id"`
+	VariantId *uuid.UUID `json:"variant_id"`
+	// warehouse the stock moved in or out of
+	WarehouseId *uuid.UUID `json:"warehouse_id"`
+	Type        string     `json:"type"`
+	// quantity added, negative when stock was taken out
+	Quantity int64 `json:"quantity"`
+	// available quantity of the product or variant after the movement
+	Balance int64  `json:"balance"`
+	Reason  string `json:"reason"`
+	// order, return, refund or import job the movement was made for
+	ReferenceId *uuid.UUID `json:"reference_id"`
+	// user who made the change, empty for changes made by the system
+	ActorId   *uuid.UUID `json:"actor_id"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// InventoryMovementsResponse is a page of the inventory ledger
+type InventoryMovementsResponse struct {
+	Movements  []*InventoryMovement `json:"movements"`
+	PagingInfo *PagingInfo          `json:"paging_info"`
+}
+
+// StockLevel is the available quantity of a product or variant beside the quantity its ledger adds up to,
+// the two differ when stock was changed without being recorded
+type StockLevel struct {
+	VariantId         *uuid.UUID `json:"variant_id"`
+	Sku               string     `json:"sku,omitempty"`
+	AvailableQuantity int64      `json:"available_quantity"`
+	LedgerQuantity    int64      `json:"ledger_quantity"`
+	Reconciled        bool       `json:"reconciled"`
+	// stock in each warehouse, it adds up to the available quantity
+	Warehouses []*WarehouseStock `json:"warehouses"`
+}
+
+// ProductInventoryResponse is the stock of a product and its variants with the history of its movements
+type ProductInventoryResponse struct {
+	ProductId  uuid.UUID            `json:"product_id"`
+	Stock      []*StockLevel        `json:"stock"`
+	Movements  []*InventoryMovement `json:"movements"`
+	PagingInfo *PagingInfo          `json:"paging_info"`
+}
+
+// AdjustInventoryDto is the data transfer object to add stock to, or take stock from, a product or one of its variants
+type AdjustInventoryDto struct {
+	VariantId string `json:"variant_id" validate:"omitempty,is_uuid"`
+	// the default warehouse when empty
+	WarehouseId string `json:"warehouse_id" validate:"omitempty,is_uuid"`
+	// quantity to add, negative to take stock out
+	Quantity int64  `json:"quantity" validate:"required"`
+	Reason   string `json:"reason" validate:"required,max=255"`
+}
+
+// IsValid checks if inventory movement type is valid
+func (t InventoryMovementType) IsValid() bool {
+	switch t {
+	case INVENTORY_INITIAL, INVENTORY_SALE, INVENTORY_CANCELLATION, INVENTORY_RETURN, INVENTORY_REFUND, INVENTORY_ADJUSTMENT, INVENTORY_IMPORT:
+		return true
+	}
+	return false
+}
+
+// For gets a movement of the same kind, by the same actor and for the same reference, of the stock
+// of a product or variant in the same warehouse
+func (m *InventoryMovement) For(productId uuid.UUID, variantId *uuid.UUID, quantity int64) *InventoryMovement {
+	movement := *m
+	movement.Id = uuid.New()
+	movement.ProductId = productId
+	movement.VariantId = variantId
+	movement.Quantity = quantity
+	return &movement
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductMedia is an image of a product kept in storage, with its thumbnails
+type ProductMedia struct {
+	Id          uuid.UUID       `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ProductId   uuid.UUID       `json:"product_id"`
+	Key         string          `json:"key"`
+	ContentType string          `json:"content_type"`
+	Size        int64           `json:"size"`
+	Width       int64           `json:"width"`
+	Height      int64           `json:"height"`
+	Thumbnails  MediaThumbnails `json:"-"`
+	// order of the image among the product's, lowest first
+	Position int64 `json:"position"`
+	// the image shown for the product in listings
+	IsPrimary bool      `json:"is_primary"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// links to the image and its thumbnails, set from the storage on every read
+	Url           string            `json:"url" gorm:"-"`
+	ThumbnailUrls map[string]string `json:"thumbnail_urls" gorm:"-"`
+}
+
+// MediaThumbnails is the storage key of each thumbnail size of an image, stored as a json object
+type MediaThumbnails map[string]string
+
+// UpdateMediaDto is the data transfer object to reorder a product image or make it the primary one
+type UpdateMediaDto struct {
+	Position  *int64 `json:"position" validate:"omitempty,min=0"`
+	IsPrimary *bool  `json:"is_primary"`
+}
+
+// GetKeys gets the storage keys of an image and its thumbnails
+func (m *ProductMedia) GetKeys() []string {
+	keys := []string{m.Key}
+	for _, key := range m.Thumbnails {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (t MediaThumbnails) Value() (driver.Value, error) {
+	if t == nil {
+		t = MediaThumbnails{}
+	}
+	return json.Marshal(t)
+}
+
+func (t *MediaThumbnails) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &t)
+}
@@ -0,0 +1,239 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"e-commerce/common/messages"
+)
+
+type RoundingMode int
+
+const (
+	// ROUND_HALF_UP rounds halves away from zero
+	ROUND_HALF_UP RoundingMode = iota
+	// ROUND_HALF_EVEN rounds halves to the nearest even minor unit (banker's rounding)
+	ROUND_HALF_EVEN
+)
+
+// currencyExponents is the number of minor unit digits of each currency, from ISO 4217
+var currencyExponents = map[Currency]int{
+	CURRENCY_NGN: 2,
+	CURRENCY_USD: 2,
+	CURRENCY_EUR: 2,
+	CURRENCY_GBP: 2,
+	CURRENCY_GHS: 2,
+	CURRENCY_KES: 2,
+	CURRENCY_ZAR: 2,
+}
+
+var currencySymbols = map[Currency]string{
+	CURRENCY_NGN: "₦",
+	CURRENCY_USD: "$",
+	CURRENCY_EUR: "€",
+	CURRENCY_GBP: "£",
+	CURRENCY_GHS: "GH₵",
+	CURRENCY_KES: "KSh",
+	CURRENCY_ZAR: "R",
+}
+
+// Money is an amount in the minor unit of its currency, e.g. kobo for NGN.
+// It is stored as a bigint column, the currency comes from the currency column of its row.
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+// moneyJSON is how money is sent to and read from clients
+type moneyJSON struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+	Display  string   `json:"display,omitempty"`
+}
+
+// NewMoney creates money of an amount in minor units
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Exponent gets the number of minor unit digits of a currency
+func (c Currency) Exponent() int {
+	if exponent, ok := currencyExponents[c]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// Add adds money of the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, messages.ErrCurrencyMismatch
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, messages.ErrMoneyOverflow
+	}
+	return NewMoney(sum, m.Currency), nil
+}
+
+// Sub subtracts money of the same currency, the result cannot go below zero
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, messages.ErrCurrencyMismatch
+	}
+	if other.Amount > m.Amount {
+		return Money{}, messages.ErrNegativeMoney
+	}
+	return NewMoney(m.Amount-other.Amount, m.Currency), nil
+}
+
+// Mul multiplies money by a quantity
+func (m Money) Mul(quantity int64) (Money, error) {
+	if quantity != 0 && (m.Amount > math.MaxInt64/abs(quantity) || m.Amount < math.MinInt64/abs(quantity)) {
+		return Money{}, messages.ErrMoneyOverflow
+	}
+	return NewMoney(m.Amount*quantity, m.Currency), nil
+}
+
+// MulRat multiplies money by a ratio, rounding the result to a minor unit
+func (m Money) MulRat(ratio *big.Rat, mode RoundingMode) (Money, error) {
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), ratio)
+	amount, err := round(product, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(amount, m.Currency), nil
+}
+
+// Percent gets a whole percent of money
+func (m Money) Percent(percent int64, mode RoundingMode) (Money, error) {
+	return m.MulRat(big.NewRat(percent, 100), mode)
+}
+
+// Min gets the smaller of two amounts of the same currency
+func (m Money) Min(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, messages.ErrCurrencyMismatch
+	}
+	if other.Amount < m.Amount {
+		return other, nil
+	}
+	return m, nil
+}
+
+// IsZero checks if there is no money
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsPositive checks if there is more than no money
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// String formats money in major units with its currency symbol, e.g. ₦1,500.00
+func (m Money) String() string {
+	exponent := m.Currency.Exponent()
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(uint64(abs(amount)), 10)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	major, minor := digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+
+	var grouped strings.Builder
+	for i, digit := range major {
+		if i > 0 && (len(major)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	symbol, ok := currencySymbols[m.Currency]
+	if !ok {
+		symbol = string(m.Currency) + " "
+	}
+	if exponent == 0 {
+		return sign + symbol + grouped.String()
+	}
+	return fmt.Sprintf("%s%s%s.%s", sign, symbol, grouped.String(), minor)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Amount, Currency: m.Currency, Display: m.String()})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var value moneyJSON
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	m.Amount, m.Currency = value.Amount, value.Currency
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.Amount, nil
+}
+
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case int64:
+		m.Amount = v
+	case []byte:
+		amount, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return err
+		}
+		m.Amount = amount
+	case nil:
+		m.Amount = 0
+	default:
+		return errors.New("type assertion to int64 failed")
+	}
+	return nil
+}
+
+// round rounds a ratio of minor units to a whole minor unit
+func round(value *big.Rat, mode RoundingMode) (int64, error) {
+	num, denom := value.Num(), value.Denom()
+	quotient, remainder := new(big.Int).QuoRem(num, denom, new(big.Int))
+
+	// compare twice the remainder with the denominator to find halves
+	switch new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(denom) {
+	case 1:
+		quotient = awayFromZero(quotient, num.Sign())
+	case 0:
+		if mode == ROUND_HALF_UP || quotient.Bit(0) == 1 {
+			quotient = awayFromZero(quotient, num.Sign())
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return 0, messages.ErrMoneyOverflow
+	}
+	return quotient.Int64(), nil
+}
+
+func awayFromZero(value *big.Int, sign int) *big.Int {
+	if sign < 0 {
+		return value.Sub(value, big.NewInt(1))
+	}
+	return value.Add(value, big.NewInt(1))
+}
+
+func abs(value int64) int64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"e-commerce/common/messages"
+)
+
+func TestMoneyMulRat(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		ratio  *big.Rat
+		mode   RoundingMode
+		want   int64
+		err    error
+	}{
+		{name: "half up rounds halves away from zero", amount: 5, ratio: big.NewRat(1, 2), mode: ROUND_HALF_UP, want: 3},
+		{name: "half even rounds halves down to even", amount: 5, ratio: big.NewRat(1, 2), mode: ROUND_HALF_EVEN, want: 2},
+		{name: "half even rounds halves up to even", amount: 15, ratio: big.NewRat(1, 2), mode: ROUND_HALF_EVEN, want: 8},
+		{name: "half up on negative halves", amount: -5, ratio: big.NewRat(1, 2), mode: ROUND_HALF_UP, want: -3},
+		{name: "half even on negative halves", amount: -5, ratio: big.NewRat(1, 2), mode: ROUND_HALF_EVEN, want: -2},
+		{name: "below half rounds down", amount: 10, ratio: big.NewRat(1, 3), mode: ROUND_HALF_UP, want: 3},
+		{name: "above half rounds up", amount: 20, ratio: big.NewRat(1, 3), mode: ROUND_HALF_EVEN, want: 7},
+		{name: "whole results are kept", amount: 300, ratio: big.NewRat(2, 3), mode: ROUND_HALF_UP, want: 200},
+		{name: "overflow", amount: math.MaxInt64, ratio: big.NewRat(2, 1), mode: ROUND_HALF_UP, err: messages.ErrMoneyOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewMoney(tt.amount, CURRENCY_USD).MulRat(tt.ratio, tt.mode)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if got.Amount != tt.want {
+				t.Errorf("amount = %d, want %d", got.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyPercent(t *testing.T) {
+	tests := []struct {
+		amount  int64
+		percent int64
+		mode    RoundingMode
+		want    int64
+	}{
+		{amount: 1999, percent: 15, mode: ROUND_HALF_UP, want: 300},
+		{amount: 10, percent: 25, mode: ROUND_HALF_UP, want: 3},
+		{amount: 10, percent: 25, mode: ROUND_HALF_EVEN, want: 2},
+		{amount: 10000, percent: 100, mode: ROUND_HALF_EVEN, want: 10000},
+	}
+	for _, tt := range tests {
+		got, err := NewMoney(tt.amount, CURRENCY_NGN).Percent(tt.percent, tt.mode)
+		if err != nil {
+			t.Fatalf("Percent(%d) of %d: %v", tt.percent, tt.amount, err)
+		}
+		if got.Amount != tt.want {
+			t.Errorf("Percent(%d) of %d = %d, want %d", tt.percent, tt.amount, got.Amount, tt.want)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	usd, ngn := NewMoney(100, CURRENCY_USD), NewMoney(100, CURRENCY_NGN)
+	tests := []struct {
+		name string
+		do   func() (Money, error)
+		want int64
+		err  error
+	}{
+		{name: "add", do: func() (Money, error) { return usd.Add(NewMoney(50, CURRENCY_USD)) }, want: 150},
+		{name: "add other currency", do: func() (Money, error) { return usd.Add(ngn) }, err: messages.ErrCurrencyMismatch},
+		{name: "add overflow", do: func() (Money, error) { return NewMoney(math.MaxInt64, CURRENCY_USD).Add(usd) }, err: messages.ErrMoneyOverflow},
+		{name: "sub", do: func() (Money, error) { return usd.Sub(NewMoney(40, CURRENCY_USD)) }, want: 60},
+		{name: "sub to zero", do: func() (Money, error) { return usd.Sub(usd) }, want: 0},
+		{name: "sub below zero", do: func() (Money, error) { return usd.Sub(NewMoney(101, CURRENCY_USD)) }, err: messages.ErrNegativeMoney},
+		{name: "sub other currency", do: func() (Money, error) { return usd.Sub(ngn) }, err: messages.ErrCurrencyMismatch},
+		{name: "mul", do: func() (Money, error) { return usd.Mul(3) }, want: 300},
+		{name: "min", do: func() (Money, error) { return usd.Min(NewMoney(40, CURRENCY_USD)) }, want: 40},
+		{name: "min of the larger", do: func() (Money, error) { return usd.Min(NewMoney(400, CURRENCY_USD)) }, want: 100},
+		{name: "min other currency", do: func() (Money, error) { return usd.Min(ngn) }, err: messages.ErrCurrencyMismatch},
+		{name: "mul overflow", do: func() (Money, error) { return usd.Mul(math.MaxInt64 / 10) }, err: messages.ErrMoneyOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.do()
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if got.Amount != tt.want {
+				t.Errorf("amount = %d, want %d", got.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: NewMoney(150000, CURRENCY_NGN), want: "₦1,500.00"},
+		{money: NewMoney(5, CURRENCY_USD), want: "$0.05"},
+		{money: NewMoney(0, CURRENCY_EUR), want: "€0.00"},
+		{money: NewMoney(-123456789, CURRENCY_GBP), want: "-£1,234,567.89"},
+		{money: NewMoney(100000, CURRENCY_KES), want: "KSh1,000.00"},
+		{money: NewMoney(250, Currency("XYZ")), want: "XYZ 2.50"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("String() of %d %s = %q, want %q", tt.money.Amount, tt.money.Currency, got, tt.want)
+		}
+	}
+}
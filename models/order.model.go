@@ -4,9 +4,11 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"math/big"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type OrderStatus string
@@ -18,34 +20,73 @@ const (
 	CANCELLED  OrderStatus = "cancelled"
 	SHIPPED    OrderStatus = "shipped"
 
-	ORDER_FEE int64 = 100000 // 1,000 per order
+	REFUNDED OrderStatus = "refunded"
+	// PARTIALLY_REFUNDED is the refund status of an order with some of its payment refunded, the order
+	// keeps its fulfilment status
+	PARTIALLY_REFUNDED OrderStatus = "partially-refunded"
 )
 
+// PAID_ORDER_STATUSES are the statuses of orders that have been paid for and not fully refunded
+var PAID_ORDER_STATUSES = []string{string(PROCESSING), string(SHIPPED), string(DELIVERED)}
+
 // Order is the order object
 type Order struct {
-	Id           uuid.UUID        `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
-	UserId       uuid.UUID        `json:"user_id"`
-	TrackingCode string           `json:"tracking_code"`
-	Status       string           `json:"status"`
-	Currency     string           `json:"currency"`
-	Fee          int64            `json:"fee"`
-	History      OrderHistoryData `json:"history"`
-	CreatedAt    time.Time        `json:"created_at"`
-	UpdatedAt    time.Time        `json:"updated_at"`
+	Id           uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	UserId       uuid.UUID `json:"user_id"`
+	TrackingCode string    `json:"tracking_code"`
+	Status       string    `json:"status"`
+	Currency     string    `json:"currency"`
+	// rates used to convert prices and the fee into the order currency
+	ExchangeRates ExchangeRateSnapshot `json:"exchange_rates"`
+	// the shipping method chosen and its fee in the order currency
+	ShippingMethodId *uuid.UUID `json:"shipping_method_id"`
+	ShippingMethod   string     `json:"shipping_method"`
+	Fee              Money      `json:"fee"`
+	CouponCode       string     `json:"coupon_code"`
+	Discount         Money      `json:"discount"`
+	RefundedAmount   Money      `json:"refunded_amount"`
+	// partially-refunded or refunded once some of the payment is refunded, empty before
+	RefundStatus    string        `json:"refund_status"`
+	ShippingAddress *OrderAddress `json:"shipping_address"`
+	BillingAddress  *OrderAddress `json:"billing_address"`
+	// where the order is taxed, from the shipping address
+	Country string `json:"country"`
+	Region  string `json:"region"`
+	// tax of all the order records, inclusive and exclusive
+	Tax       Money            `json:"tax"`
+	TaxExempt bool             `json:"tax_exempt"`
+	History   OrderHistoryData `json:"history"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
 
-	TotalAmount  int64          `json:"total_amount" gorm:"-"`
-	OrderRecords []*OrderRecord `json:"order_records" gorm:"foreignkey:OrderId"`
+	TotalAmount  Money            `json:"total_amount" gorm:"-"`
+	OrderRecords []*OrderRecord   `json:"order_records" gorm:"foreignkey:OrderId"`
+	Discounts    []*OrderDiscount `json:"discounts" gorm:"foreignkey:OrderId"`
 }
 
 // OrderRecord keeps the record for each product ordered
 type OrderRecord struct {
 	Id        uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
 	ProductId uuid.UUID `json:"product_id"`
-	Quantity  int64     `json:"quantity"`
-	OrderId   uuid.UUID `json:"order_id"`
-	Amount    int64     `json:"amount"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	// the variant bought and its SKU at the time, for products sold through variants
+	VariantId        *uuid.UUID `json:"variant_id"`
+	Sku              string     `json:"sku"`
+	Quantity         int64      `json:"quantity"`
+	OrderId          uuid.UUID  `json:"order_id"`
+	Amount           Money      `json:"amount"`
+	Currency         string     `json:"currency"`
+	RefundedQuantity int64      `json:"refunded_quantity"`
+	ShippedQuantity  int64      `json:"shipped_quantity"`
+	ReturnedQuantity int64      `json:"returned_quantity"`
+	// units that were never shipped put back in stock by refunds or the order being cancelled
+	RestockedQuantity int64     `json:"restocked_quantity"`
+	Tax               Money     `json:"tax"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+
+	TaxLines []*OrderTax `json:"tax_lines" gorm:"foreignkey:OrderRecordId"`
+	// warehouses the record is fulfilled from
+	Allocations []*OrderAllocation `json:"allocations" gorm:"foreignkey:OrderRecordId"`
 }
 
 // OrderHistory is the order history object
@@ -62,13 +103,23 @@ type OrderHistoryData struct {
 
 // PlaceOrderDto is the place order transfer object
 type PlaceOrderDto struct {
-	Data     []PlaceOrder `json:"data" validate:"gt=0,dive"`
-	Currency Currency     `json:"currency" validate:"required,is_enum"`
+	Data       []PlaceOrder `json:"data" validate:"gt=0,dive"`
+	Currency   Currency     `json:"currency" validate:"required,is_enum"`
+	CouponCode string       `json:"coupon_code" validate:"omitempty,max=50"`
+	// the default shipping address of the user when empty
+	ShippingAddressId string `json:"shipping_address_id" validate:"omitempty,is_uuid"`
+	// the default billing address of the user when empty, else the shipping address
+	BillingAddressId string `json:"billing_address_id" validate:"omitempty,is_uuid"`
+	// the cheapest shipping method to the shipping address when empty
+	ShippingMethodId string `json:"shipping_method_id" validate:"omitempty,is_uuid"`
 }
 
 // PlaceOrder is the place order object
 type PlaceOrder struct {
-	ProductId string `json:"product_id" validate:"required,is_uuid"`
+	// can be left out when a variant is given
+	ProductId string `json:"product_id" validate:"required_without=VariantId,omitempty,is_uuid"`
+	// required for products sold through variants
+	VariantId string `json:"variant_id" validate:"omitempty,is_uuid"`
 	Quantity  int64  `json:"quantity" validate:"required,is_amount"`
 }
 
@@ -83,24 +134,155 @@ type UpdateOrderStatusDto struct {
 	Status OrderStatus `json:"status" validate:"required,is_enum"`
 }
 
-// GetTotalAmount gets total amount of an order
-func (o *Order) GetTotalAmount() int64 {
-	var totalAmount int64
+// GetTotalAmount gets total amount of an order, less its discounts
+func (o *Order) GetTotalAmount() (Money, error) {
+	subTotal, err := o.GetSubTotal()
+	if err != nil {
+		return Money{}, err
+	}
+	discountTotal, err := o.GetDiscountTotal()
+	if err != nil {
+		return Money{}, err
+	}
+	return subTotal.Sub(discountTotal)
+}
+
+// GetSubTotal gets the amount of all order records before discounts
+func (o *Order) GetSubTotal() (Money, error) {
+	subTotal := NewMoney(0, Currency(o.Currency))
+	for _, orderRecord := range o.OrderRecords {
+		total, err := orderRecord.GetTotal()
+		if err != nil {
+			return Money{}, err
+		}
+		if subTotal, err = subTotal.Add(total); err != nil {
+			return Money{}, err
+		}
+	}
+	return subTotal, nil
+}
+
+// GetDiscountTotal gets the sum of the discount lines of an order
+func (o *Order) GetDiscountTotal() (Money, error) {
+	total := NewMoney(0, Currency(o.Currency))
+	for _, discount := range o.Discounts {
+		var err error
+		if total, err = total.Add(discount.Amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// GetTaxTotal gets the tax of all order records
+func (o *Order) GetTaxTotal() (Money, error) {
+	total := NewMoney(0, Currency(o.Currency))
+	for _, orderRecord := range o.OrderRecords {
+		var err error
+		if total, err = total.Add(orderRecord.Tax); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// GetAmountPayable gets the amount to be paid for an order, exclusive tax and shipping fee included
+func (o *Order) GetAmountPayable() (Money, error) {
+	amountPayable, err := o.GetTotalAmount()
+	if err != nil {
+		return Money{}, err
+	}
 	for _, orderRecord := range o.OrderRecords {
-		totalAmount += (orderRecord.Amount * orderRecord.Quantity)
+		exclusiveTax, err := orderRecord.GetExclusiveTax()
+		if err != nil {
+			return Money{}, err
+		}
+		if amountPayable, err = amountPayable.Add(exclusiveTax); err != nil {
+			return Money{}, err
+		}
 	}
-	return totalAmount
+	return amountPayable.Add(o.Fee)
+}
+
+// GetTotal gets the amount of an order record for all its quantity
+func (o *OrderRecord) GetTotal() (Money, error) {
+	return o.Amount.Mul(o.Quantity)
+}
+
+// GetExclusiveTax gets the tax charged on top of the amount of an order record
+func (o *OrderRecord) GetExclusiveTax() (Money, error) {
+	total := NewMoney(0, Currency(o.Currency))
+	for _, taxLine := range o.TaxLines {
+		if taxLine.Inclusive {
+			continue
+		}
+		var err error
+		if total, err = total.Add(taxLine.Amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+// GetRefundAmount gets the amount to refund for some of an order record's quantity: its share of
+// netAmount, the amount of the record after its discounts, and of the tax charged on top
+func (o *OrderRecord) GetRefundAmount(netAmount Money, quantity int64) (Money, error) {
+	share := big.NewRat(quantity, o.Quantity)
+	amount, err := netAmount.MulRat(share, ROUND_HALF_UP)
+	if err != nil {
+		return Money{}, err
+	}
+	exclusiveTax, err := o.GetExclusiveTax()
+	if err != nil || !exclusiveTax.IsPositive() {
+		return amount, err
+	}
+	taxShare, err := exclusiveTax.MulRat(share, ROUND_HALF_UP)
+	if err != nil {
+		return Money{}, err
+	}
+	return amount.Add(taxShare)
+}
+
+// AfterFind sets the currency of the order amounts
+func (o *Order) AfterFind(tx *gorm.DB) error {
+	currency := Currency(o.Currency)
+	o.Fee.Currency = currency
+	o.Discount.Currency = currency
+	o.RefundedAmount.Currency = currency
+	o.Tax.Currency = currency
+	o.TotalAmount.Currency = currency
+	return nil
+}
+
+// AfterFind sets the currency of the order record amount
+func (o *OrderRecord) AfterFind(tx *gorm.DB) error {
+	o.Amount.Currency = Currency(o.Currency)
+	o.Tax.Currency = Currency(o.Currency)
+	return nil
 }
 
 // IsValid checks if status is valid
 func (o OrderStatus) IsValid() bool {
 	switch o {
-	case PENDING, PROCESSING, DELIVERED, CANCELLED, SHIPPED:
+	case PENDING, PROCESSING, DELIVERED, CANCELLED, SHIPPED, REFUNDED, PARTIALLY_REFUNDED:
 		return true
 	}
 	return false
 }
 
+// fulfilmentSteps orders the statuses an order moves forward through as it is fulfilled
+var fulfilmentSteps = map[OrderStatus]int{PENDING: 1, PROCESSING: 2, SHIPPED: 3, DELIVERED: 4}
+
+// CanMoveTo checks that an order in status o can be moved to status. Orders only move forward as they
+// are fulfilled and can only be cancelled before they are shipped.
+func (o OrderStatus) CanMoveTo(status OrderStatus) bool {
+	if status == CANCELLED {
+		return o == PENDING || o == PROCESSING
+	}
+	from, to := fulfilmentSteps[o], fulfilmentSteps[status]
+	return from > 0 && to > from
+}
+
 func (t OrderHistoryData) Value() (driver.Value, error) {
 	return json.Marshal(t)
 }
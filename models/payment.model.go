@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentStatus string
+
+const (
+	PAYMENT_PENDING PaymentStatus = "pending"
+	PAYMENT_SUCCESS PaymentStatus = "success"
+	PAYMENT_FAILED  PaymentStatus = "failed"
+	// PAYMENT_REFUND_DUE is a charge that went through after its order stopped waiting for payment,
+	// it is not used for the order and has to be refunded
+	PAYMENT_REFUND_DUE PaymentStatus = "refund-due"
+
+	PAYMENT_REFUNDED           PaymentStatus = "refunded"
+	PAYMENT_PARTIALLY_REFUNDED PaymentStatus = "partially-refunded"
+)
+
+// Payment is a charge made against an order through a payment provider
+type Payment struct {
+	Id                uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	OrderId           uuid.UUID  `json:"order_id"`
+	UserId            uuid.UUID  `json:"user_id"`
+	Provider          string     `json:"provider"`
+	Reference         string     `json:"reference"`
+	ProviderReference string     `json:"provider_reference"`
+	AuthorizationUrl  string     `json:"authorization_url"`
+	Amount            int64      `json:"amount"`
+	RefundedAmount    int64      `json:"refunded_amount"`
+	Currency          string     `json:"currency"`
+	Status            string     `json:"status"`
+	PaidAt            *time.Time `json:"paid_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// IsValid checks if status is valid
+func (p PaymentStatus) IsValid() bool {
+	switch p {
+	case PAYMENT_PENDING, PAYMENT_SUCCESS, PAYMENT_FAILED, PAYMENT_REFUND_DUE, PAYMENT_REFUNDED, PAYMENT_PARTIALLY_REFUNDED:
+		return true
+	}
+	return false
+}
+
+type PaymentEventStatus string
+
+const (
+	PAYMENT_EVENT_PROCESSED PaymentEventStatus = "processed"
+	PAYMENT_EVENT_IGNORED   PaymentEventStatus = "ignored"
+)
+
+// PaymentEvent is a webhook event received from a payment provider, kept to deduplicate deliveries
+type PaymentEvent struct {
+	Id                uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	Provider          string    `json:"provider"`
+	EventId           string    `json:"event_id"`
+	EventType         string    `json:"event_type"`
+	ProviderReference string    `json:"provider_reference"`
+	Status            string    `json:"status"`
+	Note              string    `json:"note"`
+	Payload           string    `json:"payload"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
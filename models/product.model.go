@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type ProductStatus string
@@ -15,48 +16,169 @@ const (
 	SOLD_OUT     ProductStatus = "sold-out"
 
 	CURRENCY_NGN Currency = "NGN"
+	CURRENCY_USD Currency = "USD"
+	CURRENCY_EUR Currency = "EUR"
+	CURRENCY_GBP Currency = "GBP"
+	CURRENCY_GHS Currency = "GHS"
+	CURRENCY_KES Currency = "KES"
+	CURRENCY_ZAR Currency = "ZAR"
 )
 
 // Product is the product model
 type Product struct {
-	Id                uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
-	Slug              string     `json:"slug"`
-	Name              string     `json:"name"`
-	Description       string     `json:"description"`
-	Price             int64      `json:"price"`
-	Currency          string     `json:"currency"`
-	Discount          int64      `json:"discount"`
-	Status            string     `json:"status"`
-	AvailableQuantity int64      `json:"available_quantity"`
-	CreatedAt         time.Time  `json:"created_at"`
-	UpdatedAt         time.Time  `json:"updated_at"`
-	DeletedAt         *time.Time `json:"deleted_at"`
+	Id          uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	Slug        string    `json:"slug"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Brand       string    `json:"brand"`
+	Price       Money     `json:"price"`
+	Currency    string    `json:"currency"`
+	Discount    Money     `json:"discount"`
+	TaxClass    string    `json:"tax_class"`
+	// weight in grams and dimensions in centimetres, used to work out shipping
+	Weight int64 `json:"weight"`
+	Length int64 `json:"length"`
+	Width  int64 `json:"width"`
+	Height int64 `json:"height"`
+	// days after delivery the product can be returned in, it cannot be returned when 0
+	ReturnWindowDays int64 `json:"return_window_days"`
+	// values of the attributes defined by the product's categories
+	Attributes        ProductAttributes `json:"attributes"`
+	Status            string            `json:"status"`
+	AvailableQuantity int64             `json:"available_quantity"`
+	// quantity held for pending orders, it cannot be sold to other orders
+	ReservedQuantity int64 `json:"reserved_quantity"`
+	// admins are alerted when the stock of the product, or of one of its variants, falls to it, 0 for no alerts
+	ReorderThreshold int64      `json:"reorder_threshold"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	DeletedAt        *time.Time `json:"deleted_at"`
+
+	// prices set for other currencies, used instead of converting Price
+	Prices []*ProductPrice `json:"prices" gorm:"foreignkey:ProductId"`
+	// categories the product is assigned to, it also shows under their parents
+	Categories []*Category `json:"categories" gorm:"many2many:product_categories"`
+	// options the product comes in and the variants it is sold as, a product with variants is
+	// priced and stocked through them
+	Options  []*ProductOption  `json:"options" gorm:"foreignkey:ProductId"`
+	Variants []*ProductVariant `json:"variants" gorm:"foreignkey:ProductId"`
+	Media    []*ProductMedia   `json:"media" gorm:"foreignkey:ProductId"`
+	// stock of the product and its variants in each warehouse, AvailableQuantity adds it up
+	Stock []*WarehouseStock `json:"stock" gorm:"foreignkey:ProductId"`
+
+	// quantity that can still be ordered, the available quantity less what is reserved
+	SellableQuantity int64 `json:"sellable_quantity" gorm:"-"`
+}
+
+// ProductPrice is the price of a product in a currency other than its own
+type ProductPrice struct {
+	Id        uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ProductId uuid.UUID `json:"product_id"`
+	Currency  string    `json:"currency"`
+	Price     Money     `json:"price"`
+	Discount  Money     `json:"discount"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // CreateProductDto is the data transfer object to create new product
 type CreateProductDto struct {
 	Name        string   `json:"name" validate:"required,min=4,max=30"`
 	Description string   `json:"description" validate:"required,min=4,max=100"`
+	Brand       string   `json:"brand" validate:"omitempty,max=50"`
 	Quantity    int64    `json:"quantity" validate:"required,is_amount"`
 	Price       int64    `json:"price" validate:"required,is_amount"`
 	Discount    int64    `json:"discount" validate:"omitempty,is_amount"`
 	Currency    Currency `json:"currency" validate:"required,is_enum"`
+	// standard when empty
+	TaxClass TaxClass `json:"tax_class" validate:"omitempty,is_enum"`
+	// weight in grams and dimensions in centimetres
+	Weight int64 `json:"weight" validate:"min=0"`
+	Length int64 `json:"length" validate:"min=0"`
+	Width  int64 `json:"width" validate:"min=0"`
+	Height int64 `json:"height" validate:"min=0"`
+	// DEFAULT_RETURN_WINDOW_DAYS when empty, 0 for products that cannot be returned
+	ReturnWindowDays *int64 `json:"return_window_days" validate:"omitempty,min=0"`
+	// admins are alerted when the stock falls to it, 0 for no alerts
+	ReorderThreshold int64 `json:"reorder_threshold" validate:"min=0"`
+
+	// values of the attributes defined by the categories of the product
+	Attributes map[string]interface{} `json:"attributes" validate:"omitempty"`
+
+	Prices      []ProductPriceDto  `json:"prices" validate:"omitempty,dive"`
+	CategoryIds []string           `json:"category_ids" validate:"omitempty,dive,is_uuid"`
+	Options     []ProductOptionDto `json:"options" validate:"omitempty,dive"`
+	Variants    []CreateVariantDto `json:"variants" validate:"omitempty,dive"`
+}
+
+// ProductPriceDto is the data transfer object for the price of a product in another currency
+type ProductPriceDto struct {
+	Currency Currency `json:"currency" validate:"required,is_enum"`
+	Price    int64    `json:"price" validate:"required,is_amount"`
+	Discount int64    `json:"discount" validate:"omitempty,is_amount"`
 }
 
 // UpdateProductDto is the data transfer object to update an existing product
 type UpdateProductDto struct {
-	Name        *string        `json:"name" validate:"omitempty,min=4,max=30"`
-	Description *string        `json:"description" validate:"omitempty,min=4,max=100"`
-	Quantity    *int64         `json:"quantity" validate:"omitempty,is_amount"`
-	Status      *ProductStatus `json:"status" validate:"omitempty,is_enum"`
-	Price       *int64         `json:"price" validate:"omitempty,is_amount"`
-	Discount    *int64         `json:"discount" validate:"omitempty,is_amount"`
+	Name        *string `json:"name" validate:"omitempty,min=4,max=30"`
+	Description *string `json:"description" validate:"omitempty,min=4,max=100"`
+	Brand       *string `json:"brand" validate:"omitempty,max=50"`
+	Quantity    *int64  `json:"quantity" validate:"omitempty,is_amount"`
+	// why the quantity changed, kept in the inventory ledger
+	QuantityReason   string         `json:"quantity_reason" validate:"omitempty,max=255"`
+	Status           *ProductStatus `json:"status" validate:"omitempty,is_enum"`
+	Price            *int64         `json:"price" validate:"omitempty,is_amount"`
+	Discount         *int64         `json:"discount" validate:"omitempty,is_amount"`
+	TaxClass         *TaxClass      `json:"tax_class" validate:"omitempty,is_enum"`
+	Weight           *int64         `json:"weight" validate:"omitempty,min=0"`
+	Length           *int64         `json:"length" validate:"omitempty,min=0"`
+	Width            *int64         `json:"width" validate:"omitempty,min=0"`
+	Height           *int64         `json:"height" validate:"omitempty,min=0"`
+	ReturnWindowDays *int64         `json:"return_window_days" validate:"omitempty,min=0"`
+	ReorderThreshold *int64         `json:"reorder_threshold" validate:"omitempty,min=0"`
+	// replaces all the prices of the product in other currencies
+	Prices *[]ProductPriceDto `json:"prices" validate:"omitempty,dive"`
+	// replaces all the categories of the product, its attributes must still fit them
+	CategoryIds *[]string `json:"category_ids" validate:"omitempty,dive,is_uuid"`
+	// replaces all the attributes of the product
+	Attributes *map[string]interface{} `json:"attributes" validate:"omitempty"`
+	// replaces all the options of the product, its variants must still fit them
+	Options *[]ProductOptionDto `json:"options" validate:"omitempty,dive"`
+}
+
+// ProductQueryDto is the data transfer object to narrow down the products listing
+type ProductQueryDto struct {
+	// id or slug of a category, products of its subcategories are included
+	Category string          `form:"category" validate:"omitempty,max=256"`
+	Status   []ProductStatus `form:"status" validate:"omitempty,dive,is_enum"`
+	Brand    []string        `form:"brand" validate:"omitempty,dive,max=50"`
+	Currency Currency        `form:"currency" validate:"omitempty,is_enum"`
+	// range of what products sell for after their discount, in the minor unit of Currency
+	MinPrice *int64 `form:"min_price" validate:"omitempty,min=0"`
+	MaxPrice *int64 `form:"max_price" validate:"omitempty,min=0"`
+	// option values as name:value, a product matches an option when it has a variant with any of its values
+	Options []string `form:"option" validate:"omitempty,dive,max=101"`
+	// adds the facet counts of the products found to the response
+	Facets bool `form:"facets"`
+}
+
+// ProductFilter is what the products listing is narrowed down to
+type ProductFilter struct {
+	CategoryIds []uuid.UUID
+	Statuses    []string
+	Brands      []string
+	Currency    string
+	MinPrice    *int64
+	MaxPrice    *int64
+	// values allowed for each option name
+	Options map[string][]string
 }
 
 // ProductsResponse is the products data with pagination info
 type ProductsResponse struct {
-	Products   []*Product  `json:"products"`
-	PagingInfo *PagingInfo `json:"paging_info"`
+	Products   []*Product     `json:"products"`
+	PagingInfo *PagingInfo    `json:"paging_info"`
+	Facets     *ProductFacets `json:"facets,omitempty"`
 }
 
 // IsValid checks if status is valid
@@ -68,10 +190,38 @@ func (p ProductStatus) IsValid() bool {
 	return false
 }
 
+// PriceIn gets the price and discount of a product in a currency without any conversion
+func (p *Product) PriceIn(currency string) (price Money, discount Money, ok bool) {
+	if p.Currency == currency {
+		return p.Price, p.Discount, true
+	}
+	for _, productPrice := range p.Prices {
+		if productPrice.Currency == currency {
+			return productPrice.Price, productPrice.Discount, true
+		}
+	}
+	return Money{}, Money{}, false
+}
+
+// AfterFind sets the currency of the product amounts and the quantity that can still be ordered
+func (p *Product) AfterFind(tx *gorm.DB) error {
+	p.Price.Currency = Currency(p.Currency)
+	p.Discount.Currency = Currency(p.Currency)
+	p.SellableQuantity = p.AvailableQuantity - p.ReservedQuantity
+	return nil
+}
+
+// AfterFind sets the currency of the price amounts
+func (p *ProductPrice) AfterFind(tx *gorm.DB) error {
+	p.Price.Currency = Currency(p.Currency)
+	p.Discount.Currency = Currency(p.Currency)
+	return nil
+}
+
 // IsValid checks if status is valid
 func (c Currency) IsValid() bool {
 	switch c {
-	case CURRENCY_NGN:
+	case CURRENCY_NGN, CURRENCY_USD, CURRENCY_EUR, CURRENCY_GBP, CURRENCY_GHS, CURRENCY_KES, CURRENCY_ZAR:
 		return true
 	}
 	return false
@@ -0,0 +1,192 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PromotionType string
+type PromotionStatus string
+
+const (
+	PROMOTION_BUY_X_GET_Y     PromotionType = "buy-x-get-y"
+	PROMOTION_SPEND_THRESHOLD PromotionType = "spend-threshold"
+	PROMOTION_QUANTITY_TIER   PromotionType = "quantity-tier"
+	PROMOTION_BUNDLE          PromotionType = "bundle"
+
+	PROMOTION_ACTIVE   PromotionStatus = "active"
+	PROMOTION_INACTIVE PromotionStatus = "inactive"
+)
+
+// Promotion is a discount applied automatically to every order that matches its rules
+type Promotion struct {
+	Id          uuid.UUID      `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Type        string         `json:"type"`
+	Rules       PromotionRules `json:"rules"`
+	Currency    string         `json:"currency"`
+	// promotions with a higher priority are evaluated first
+	Priority int64 `json:"priority"`
+	// an exclusive promotion stops lower priority promotions once it applies
+	Exclusive bool       `json:"exclusive"`
+	StartsAt  *time.Time `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// PromotionRules holds the settings of a promotion, the fields used depend on its type.
+//
+//   - buy-x-get-y: buy_quantity, get_quantity and percent off the cheapest free items (100 when empty)
+//   - spend-threshold: min_amount with either percent (capped by max_discount) or amount
+//   - quantity-tier: tiers of min_quantity and percent per order record
+//   - bundle: items bought together for bundle_price
+//
+// product_ids limits buy-x-get-y, spend-threshold and quantity-tier promotions to some products.
+type PromotionRules struct {
+	ProductIds  UUIDList        `json:"product_ids,omitempty"`
+	BuyQuantity int64           `json:"buy_quantity,omitempty" validate:"omitempty,is_amount"`
+	GetQuantity int64           `json:"get_quantity,omitempty" validate:"omitempty,is_amount"`
+	Percent     int64           `json:"percent,omitempty" validate:"omitempty,is_amount,max=100"`
+	MinAmount   int64           `json:"min_amount,omitempty" validate:"omitempty,is_amount"`
+	Amount      int64           `json:"amount,omitempty" validate:"omitempty,is_amount"`
+	MaxDiscount int64           `json:"max_discount,omitempty" validate:"omitempty,is_amount"`
+	Tiers       []PromotionTier `json:"tiers,omitempty" validate:"omitempty,dive"`
+	Items       []BundleItem    `json:"items,omitempty" validate:"omitempty,dive"`
+	BundlePrice int64           `json:"bundle_price,omitempty" validate:"omitempty,is_amount"`
+}
+
+// PromotionTier is a quantity tier of a quantity-tier promotion
+type PromotionTier struct {
+	MinQuantity int64 `json:"min_quantity" validate:"required,is_amount"`
+	Percent     int64 `json:"percent" validate:"required,is_amount,max=100"`
+}
+
+// BundleItem is a product and quantity that makes up a bundle
+type BundleItem struct {
+	ProductId uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int64     `json:"quantity" validate:"required,is_amount"`
+}
+
+// OrderDiscount is an itemised discount line on an order, from a promotion or a coupon.
+// Lines without an order record apply to the order as a whole.
+type OrderDiscount struct {
+	Id            uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	OrderId       uuid.UUID  `json:"order_id"`
+	OrderRecordId *uuid.UUID `json:"order_record_id"`
+	PromotionId   *uuid.UUID `json:"promotion_id"`
+	CouponId      *uuid.UUID `json:"coupon_id"`
+	Description   string     `json:"description"`
+	Amount        Money      `json:"amount"`
+	Currency      string     `json:"currency"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// CreatePromotionDto is the data transfer object to create a promotion
+type CreatePromotionDto struct {
+	Name        string         `json:"name" validate:"required,max=256"`
+	Description string         `json:"description" validate:"omitempty,max=256"`
+	Type        PromotionType  `json:"type" validate:"required,is_enum"`
+	Rules       PromotionRules `json:"rules"`
+	Currency    *Currency      `json:"currency" validate:"omitempty,is_enum"`
+	Priority    int64          `json:"priority"`
+	Exclusive   bool           `json:"exclusive"`
+	StartsAt    *time.Time     `json:"starts_at"`
+	EndsAt      *time.Time     `json:"ends_at"`
+}
+
+// UpdatePromotionDto is the data transfer object to update a promotion
+type UpdatePromotionDto struct {
+	Name        *string          `json:"name" validate:"omitempty,max=256"`
+	Description *string          `json:"description" validate:"omitempty,max=256"`
+	Rules       *PromotionRules  `json:"rules"`
+	Priority    *int64           `json:"priority"`
+	Exclusive   *bool            `json:"exclusive"`
+	StartsAt    *time.Time       `json:"starts_at"`
+	EndsAt      *time.Time       `json:"ends_at"`
+	Status      *PromotionStatus `json:"status" validate:"omitempty,is_enum"`
+}
+
+// PromotionsResponse is the promotions data with pagination info
+type PromotionsResponse struct {
+	Promotions []*Promotion `json:"promotions"`
+	PagingInfo *PagingInfo  `json:"paging_info"`
+}
+
+// AppliesTo checks if a product is eligible for the promotion
+func (p *Promotion) AppliesTo(productId uuid.UUID) bool {
+	if len(p.Rules.ProductIds) == 0 {
+		return true
+	}
+	for _, id := range p.Rules.ProductIds {
+		if id == productId {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidFor checks that the rules carry the settings a promotion type needs
+func (r *PromotionRules) IsValidFor(promotionType PromotionType) bool {
+	switch promotionType {
+	case PROMOTION_BUY_X_GET_Y:
+		return r.BuyQuantity > 0 && r.GetQuantity > 0
+	case PROMOTION_SPEND_THRESHOLD:
+		return r.MinAmount > 0 && (r.Percent > 0) != (r.Amount > 0)
+	case PROMOTION_QUANTITY_TIER:
+		return len(r.Tiers) > 0
+	case PROMOTION_BUNDLE:
+		return len(r.Items) > 0 && r.BundlePrice > 0
+	}
+	return false
+}
+
+// NeedsCurrency checks if the rules hold amounts, which only make sense in one currency
+func (r *PromotionRules) NeedsCurrency(promotionType PromotionType) bool {
+	return promotionType == PROMOTION_SPEND_THRESHOLD || promotionType == PROMOTION_BUNDLE
+}
+
+// AfterFind sets the currency of the discount amount
+func (o *OrderDiscount) AfterFind(tx *gorm.DB) error {
+	o.Amount.Currency = Currency(o.Currency)
+	return nil
+}
+
+// IsValid checks if type is valid
+func (p PromotionType) IsValid() bool {
+	switch p {
+	case PROMOTION_BUY_X_GET_Y, PROMOTION_SPEND_THRESHOLD, PROMOTION_QUANTITY_TIER, PROMOTION_BUNDLE:
+		return true
+	}
+	return false
+}
+
+// IsValid checks if status is valid
+func (p PromotionStatus) IsValid() bool {
+	switch p {
+	case PROMOTION_ACTIVE, PROMOTION_INACTIVE:
+		return true
+	}
+	return false
+}
+
+func (r PromotionRules) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *PromotionRules) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &r)
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefundStatus string
+
+const (
+	REFUND_PENDING   RefundStatus = "pending"
+	REFUND_SUCCEEDED RefundStatus = "succeeded"
+	REFUND_FAILED    RefundStatus = "failed"
+)
+
+// Refund is money returned on an order's payment, in full or in part
+type Refund struct {
+	Id                uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	OrderId           uuid.UUID `json:"order_id"`
+	PaymentId         uuid.UUID `json:"payment_id"`
+	Reference         string    `json:"reference"`
+	ProviderReference string    `json:"provider_reference"`
+	Amount            int64     `json:"amount"`
+	Currency          string    `json:"currency"`
+	Reason            string    `json:"reason"`
+	Status            string    `json:"status"`
+	Restock           bool      `json:"restock"`
+	CreatedBy         uuid.UUID `json:"created_by"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+
+	RefundItems []*RefundItem `json:"refund_items" gorm:"foreignkey:RefundId"`
+}
+
+// RefundItem is the quantity of an order record covered by a refund
+type RefundItem struct {
+	Id            uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	RefundId      uuid.UUID  `json:"refund_id"`
+	OrderRecordId uuid.UUID  `json:"order_record_id"`
+	ProductId     uuid.UUID  `json:"product_id"`
+	VariantId     *uuid.UUID `json:"variant_id"`
+	Quantity      int64      `json:"quantity"`
+	Amount        int64      `json:"amount"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// CreateRefundDto is the data transfer object to refund an order.
+// Send an amount or a list of items; with neither, the remaining paid amount is refunded.
+type CreateRefundDto struct {
+	// the payment the order was paid with when empty, else a payment that is due a refund
+	PaymentId string          `json:"payment_id" validate:"omitempty,is_uuid"`
+	Amount    int64           `json:"amount" validate:"omitempty,is_amount"`
+	Items     []RefundItemDto `json:"items" validate:"omitempty,dive"`
+	Reason    string          `json:"reason" validate:"required,min=4,max=256"`
+	// puts back the items that were not shipped, shipped ones are restocked when their return is received
+	Restock bool `json:"restock"`
+}
+
+// RefundItemDto is the quantity of an order record to refund
+type RefundItemDto struct {
+	OrderRecordId string `json:"order_record_id" validate:"required,is_uuid"`
+	Quantity      int64  `json:"quantity" validate:"required,is_amount"`
+}
+
+// IsValid checks if status is valid
+func (r RefundStatus) IsValid() bool {
+	switch r {
+	case REFUND_PENDING, REFUND_SUCCEEDED, REFUND_FAILED:
+		return true
+	}
+	return false
+}
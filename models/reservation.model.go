@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationStatus string
+
+const (
+	RESERVATION_ACTIVE ReservationStatus = "active"
+	// RESERVATION_RELEASED is a reservation that expired or whose order was cancelled, its stock can be sold again
+	RESERVATION_RELEASED ReservationStatus = "released"
+	// RESERVATION_CONVERTED is a reservation whose order was paid for, its stock was taken out
+	RESERVATION_CONVERTED ReservationStatus = "converted"
+)
+
+// StockReservation holds stock of a product, or of one of its variants, in a warehouse for a pending order
+// until it is paid for, cancelled or the reservation expires. Reserved stock cannot be sold to other orders.
+type StockReservation struct {
+	Id            uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	OrderId       uuid.UUID  `json:"order_id"`
+	OrderRecordId uuid.UUID  `json:"order_record_id"`
+	ProductId     uuid.UUID  `json:"product_id"`
+	VariantId     *uuid.UUID `json:"variant_id"`
+	WarehouseId   uuid.UUID  `json:"warehouse_id"`
+	Quantity      int64      `json:"quantity"`
+	Status        string     `json:"status"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// IsValid checks if reservation status is valid
+func (s ReservationStatus) IsValid() bool {
+	switch s {
+	case RESERVATION_ACTIVE, RESERVATION_RELEASED, RESERVATION_CONVERTED:
+		return true
+	}
+	return false
+}
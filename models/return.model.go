@@ -0,0 +1,168 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReturnStatus string
+type ReturnReason string
+
+const (
+	RETURN_REQUESTED ReturnStatus = "requested"
+	RETURN_APPROVED  ReturnStatus = "approved"
+	RETURN_REJECTED  ReturnStatus = "rejected"
+	RETURN_RECEIVED  ReturnStatus = "received"
+
+	RETURN_DAMAGED          ReturnReason = "damaged"
+	RETURN_DEFECTIVE        ReturnReason = "defective"
+	RETURN_WRONG_ITEM       ReturnReason = "wrong-item"
+	RETURN_NOT_AS_DESCRIBED ReturnReason = "not-as-described"
+	RETURN_NO_LONGER_NEEDED ReturnReason = "no-longer-needed"
+	RETURN_OTHER            ReturnReason = "other"
+)
+
+// DEFAULT_RETURN_WINDOW_DAYS is the return window of products created without one
+const DEFAULT_RETURN_WINDOW_DAYS = 30
+
+// returnTransitions are the statuses a return can move to from each status
+var returnTransitions = map[ReturnStatus][]ReturnStatus{
+	RETURN_REQUESTED: {RETURN_APPROVED, RETURN_REJECTED},
+	RETURN_APPROVED:  {RETURN_RECEIVED},
+}
+
+// ReturnRequest is a customer's request to send back some of the records of a delivered order
+type ReturnRequest struct {
+	Id      uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	OrderId uuid.UUID `json:"order_id"`
+	UserId  uuid.UUID `json:"user_id"`
+	Status  string    `json:"status"`
+	Comment string    `json:"comment"`
+	// set when the return is approved, the share of the order paid for the returned items
+	RefundableAmount Money      `json:"refundable_amount"`
+	Currency         string     `json:"currency"`
+	Restocked        bool       `json:"restocked"`
+	ReviewNote       string     `json:"review_note"`
+	ReviewedBy       *uuid.UUID `json:"reviewed_by"`
+	ReviewedAt       *time.Time `json:"reviewed_at"`
+	ReceivedAt       *time.Time `json:"received_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	Items []*ReturnItem `json:"items" gorm:"foreignkey:ReturnRequestId"`
+}
+
+// ReturnItem is the quantity of an order record being returned and why
+type ReturnItem struct {
+	Id               uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ReturnRequestId  uuid.UUID  `json:"return_request_id"`
+	OrderRecordId    uuid.UUID  `json:"order_record_id"`
+	ProductId        uuid.UUID  `json:"product_id"`
+	VariantId        *uuid.UUID `json:"variant_id"`
+	Quantity         int64      `json:"quantity"`
+	Reason           string     `json:"reason"`
+	RefundableAmount Money      `json:"refundable_amount"`
+	Currency         string     `json:"currency"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// CreateReturnDto is the data transfer object to ask to return order records
+type CreateReturnDto struct {
+	Items   []ReturnItemDto `json:"items" validate:"gt=0,dive"`
+	Comment string          `json:"comment" validate:"omitempty,max=1000"`
+}
+
+// ReturnItemDto is the quantity of an order record to return and why
+type ReturnItemDto struct {
+	OrderRecordId string       `json:"order_record_id" validate:"required,is_uuid"`
+	Quantity      int64        `json:"quantity" validate:"required,is_amount"`
+	Reason        ReturnReason `json:"reason" validate:"required,is_enum"`
+}
+
+// ReviewReturnDto is the data transfer object to approve or reject a return
+type ReviewReturnDto struct {
+	Note string `json:"note" validate:"omitempty,max=1000"`
+}
+
+// ReceiveReturnDto is the data transfer object to record the arrival of returned items
+type ReceiveReturnDto struct {
+	Restock bool `json:"restock"`
+}
+
+// ReturnRequestsResponse is the return requests data with pagination info
+type ReturnRequestsResponse struct {
+	ReturnRequests []*ReturnRequest `json:"return_requests"`
+	PagingInfo     *PagingInfo      `json:"paging_info"`
+}
+
+// GetDeliveredAt gets when an order was last delivered from its history
+func (o *Order) GetDeliveredAt() *time.Time {
+	for i := len(o.History.Data) - 1; i >= 0; i-- {
+		if o.History.Data[i].Status == string(DELIVERED) {
+			return &o.History.Data[i].CreatedAt
+		}
+	}
+	return nil
+}
+
+// GetReturnableQuantity gets the quantity of an order record that has not been refunded or asked to be returned
+func (o *OrderRecord) GetReturnableQuantity() int64 {
+	return o.Quantity - o.RefundedQuantity - o.ReturnedQuantity
+}
+
+// GetUnshippedQuantity gets the units of an order record that were neither shipped nor put back in stock.
+// Refunds and cancellations put back these, shipped units come back through returns.
+func (o *OrderRecord) GetUnshippedQuantity() int64 {
+	return max(o.Quantity-o.ShippedQuantity-o.RestockedQuantity, 0)
+}
+
+// IsReturnableAt checks if a product delivered at a time can still be returned
+func (p *Product) IsReturnableAt(deliveredAt time.Time, now time.Time) bool {
+	if p.ReturnWindowDays <= 0 {
+		return false
+	}
+	return now.Before(deliveredAt.AddDate(0, 0, int(p.ReturnWindowDays)))
+}
+
+// CanMoveTo checks if a return can move from its status to another
+func (r *ReturnRequest) CanMoveTo(status ReturnStatus) bool {
+	for _, next := range returnTransitions[ReturnStatus(r.Status)] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// AfterFind sets the currency of the return's refundable amount
+func (r *ReturnRequest) AfterFind(tx *gorm.DB) error {
+	r.RefundableAmount.Currency = Currency(r.Currency)
+	return nil
+}
+
+// AfterFind sets the currency of the return item's refundable amount
+func (r *ReturnItem) AfterFind(tx *gorm.DB) error {
+	r.RefundableAmount.Currency = Currency(r.Currency)
+	return nil
+}
+
+// IsValid checks if status is valid
+func (r ReturnStatus) IsValid() bool {
+	switch r {
+	case RETURN_REQUESTED, RETURN_APPROVED, RETURN_REJECTED, RETURN_RECEIVED:
+		return true
+	}
+	return false
+}
+
+// IsValid checks if reason is valid
+func (r ReturnReason) IsValid() bool {
+	switch r {
+	case RETURN_DAMAGED, RETURN_DEFECTIVE, RETURN_WRONG_ITEM, RETURN_NOT_AS_DESCRIBED, RETURN_NO_LONGER_NEEDED, RETURN_OTHER:
+		return true
+	}
+	return false
+}
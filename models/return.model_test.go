@@ -0,0 +1,43 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGetUnshippedQuantity(t *testing.T) {
+	// each step restocks up to quantity units, a refund some of them and a cancellation all
+	tests := []struct {
+		name    string
+		shipped int64
+		steps   []int64
+		// the units each step puts back in stock
+		want []int64
+	}{
+		{name: "cancelled", steps: []int64{5}, want: []int64{5}},
+		{name: "cancelled after shipping some", shipped: 2, steps: []int64{5}, want: []int64{3}},
+		{name: "refunded then cancelled", steps: []int64{2, 5}, want: []int64{2, 3}},
+		{name: "refunded in full then cancelled", steps: []int64{5, 5}, want: []int64{5, 0}},
+		{name: "refunded after shipping some then cancelled", shipped: 2, steps: []int64{2, 5}, want: []int64{2, 1}},
+		{name: "refunded more than is left unshipped", shipped: 2, steps: []int64{4, 5}, want: []int64{3, 0}},
+		{name: "shipped units are left to their return", shipped: 5, steps: []int64{2}, want: []int64{0}},
+		{name: "cancelled twice", steps: []int64{5, 5}, want: []int64{5, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderRecord := &OrderRecord{Quantity: 5, ShippedQuantity: tt.shipped}
+			got := []int64{}
+			for _, quantity := range tt.steps {
+				quantity = min(quantity, orderRecord.GetUnshippedQuantity())
+				orderRecord.RestockedQuantity += quantity
+				got = append(got, quantity)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("restocked = %v, want %v", got, tt.want)
+			}
+			if orderRecord.ShippedQuantity+orderRecord.RestockedQuantity > orderRecord.Quantity {
+				t.Errorf("shipped %d and restocked %d of %d units", orderRecord.ShippedQuantity, orderRecord.RestockedQuantity, orderRecord.Quantity)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import "github.com/google/uuid"
+
+// ProductSearchDto is the data transfer object to search products
+type ProductSearchDto struct {
+	// words to look for in product names and descriptions, small typos in names are tolerated
+	Query string `form:"q" validate:"required,min=2,max=100"`
+	// id or slug of a category, products of its subcategories are included
+	Category string `form:"category" validate:"omitempty,max=256"`
+}
+
+// ProductAutocompleteDto is the data transfer object to suggest products while a search is typed
+type ProductAutocompleteDto struct {
+	Query string `form:"q" validate:"required,min=1,max=100"`
+	// DEFAULT_AUTOCOMPLETE_LIMIT when empty
+	Limit int `form:"limit" validate:"omitempty,min=1,max=20"`
+}
+
+// ProductSearchResult is a product found by a search, with the matched words highlighted
+type ProductSearchResult struct {
+	Product *Product `json:"product"`
+	// how well the product matches, results come highest first
+	Rank float64 `json:"rank"`
+	// name and an excerpt of the description with the matched words wrapped in <mark> tags
+	NameHighlight        string `json:"name_highlight"`
+	DescriptionHighlight string `json:"description_highlight"`
+}
+
+// ProductSearchResponse is the search results with pagination info
+type ProductSearchResponse struct {
+	Results    []*ProductSearchResult `json:"results"`
+	PagingInfo *PagingInfo            `json:"paging_info"`
+}
+
+// ProductSuggestion is a product suggested for what has been typed in the search box
+type ProductSuggestion struct {
+	Id   uuid.UUID `json:"id"`
+	Slug string    `json:"slug"`
+	Name string    `json:"name"`
+}
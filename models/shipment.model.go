@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShipmentStatus string
+
+const (
+	SHIPMENT_PENDING          ShipmentStatus = "pending"
+	SHIPMENT_FAILED           ShipmentStatus = "failed"
+	SHIPMENT_LABEL_CREATED    ShipmentStatus = "label-created"
+	SHIPMENT_IN_TRANSIT       ShipmentStatus = "in-transit"
+	SHIPMENT_OUT_FOR_DELIVERY ShipmentStatus = "out-for-delivery"
+	SHIPMENT_DELIVERED        ShipmentStatus = "delivered"
+	SHIPMENT_EXCEPTION        ShipmentStatus = "exception"
+)
+
+// TRACKED_SHIPMENT_STATUSES are the statuses of shipments the carrier still has to deliver
+var TRACKED_SHIPMENT_STATUSES = []string{
+	string(SHIPMENT_LABEL_CREATED),
+	string(SHIPMENT_IN_TRANSIT),
+	string(SHIPMENT_OUT_FOR_DELIVERY),
+	string(SHIPMENT_EXCEPTION),
+}
+
+// Shipment is a parcel of some or all of an order's records sent with a carrier
+type Shipment struct {
+	Id             uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	OrderId        uuid.UUID  `json:"order_id"`
+	Carrier        string     `json:"carrier"`
+	TrackingNumber string     `json:"tracking_number"`
+	LabelUrl       string     `json:"label_url"`
+	Weight         int64      `json:"weight"`
+	Status         string     `json:"status"`
+	ShippedAt      *time.Time `json:"shipped_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedBy      uuid.UUID  `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Items  []*ShipmentItem  `json:"items" gorm:"foreignkey:ShipmentId"`
+	Events []*ShipmentEvent `json:"events" gorm:"foreignkey:ShipmentId"`
+}
+
+// ShipmentItem is the quantity of an order record in a shipment
+type ShipmentItem struct {
+	Id            uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ShipmentId    uuid.UUID `json:"shipment_id"`
+	OrderRecordId uuid.UUID `json:"order_record_id"`
+	ProductId     uuid.UUID `json:"product_id"`
+	Quantity      int64     `json:"quantity"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ShipmentEvent is a tracking event of a shipment reported by its carrier
+type ShipmentEvent struct {
+	Id          uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ShipmentId  uuid.UUID `json:"shipment_id"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CreateShipmentDto is the data transfer object to ship an order.
+// Without items, everything not yet shipped goes in the shipment.
+type CreateShipmentDto struct {
+	Items []ShipmentItemDto `json:"items" validate:"omitempty,dive"`
+}
+
+// ShipmentItemDto is the quantity of an order record to ship
+type ShipmentItemDto struct {
+	OrderRecordId string `json:"order_record_id" validate:"required,is_uuid"`
+	Quantity      int64  `json:"quantity" validate:"required,is_amount"`
+}
+
+// HasLeft checks if the carrier has picked up a shipment
+func (s *Shipment) HasLeft() bool {
+	return s.ShippedAt != nil
+}
+
+// IsDelivered checks if a shipment has reached its destination
+func (s *Shipment) IsDelivered() bool {
+	return s.DeliveredAt != nil
+}
+
+// GetShippableQuantity gets the quantity of an order record still to be shipped
+func (o *OrderRecord) GetShippableQuantity() int64 {
+	if quantity := o.Quantity - o.RefundedQuantity - o.ShippedQuantity; quantity > 0 {
+		return quantity
+	}
+	return 0
+}
+
+// IsValid checks if status is valid
+func (s ShipmentStatus) IsValid() bool {
+	switch s {
+	case SHIPMENT_PENDING, SHIPMENT_FAILED, SHIPMENT_LABEL_CREATED, SHIPMENT_IN_TRANSIT,
+		SHIPMENT_OUT_FOR_DELIVERY, SHIPMENT_DELIVERED, SHIPMENT_EXCEPTION:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShippingMethodType string
+type ShippingStatus string
+
+const (
+	SHIPPING_FLAT                ShippingMethodType = "flat"
+	SHIPPING_WEIGHT_BANDED       ShippingMethodType = "weight-banded"
+	SHIPPING_FREE_OVER_THRESHOLD ShippingMethodType = "free-over-threshold"
+
+	SHIPPING_ACTIVE   ShippingStatus = "active"
+	SHIPPING_INACTIVE ShippingStatus = "inactive"
+)
+
+// VOLUMETRIC_DIVISOR is the cubic centimetres charged as one kilogram when a parcel is bulky for its weight
+const VOLUMETRIC_DIVISOR = 5000
+
+// ShippingZone is a set of destinations that share shipping methods. A zone without regions
+// covers the whole of its countries.
+type ShippingZone struct {
+	Id        uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	Name      string     `json:"name"`
+	Countries StringList `json:"countries"`
+	Regions   StringList `json:"regions"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	Methods []*ShippingMethod `json:"methods" gorm:"foreignkey:ZoneId"`
+}
+
+// ShippingMethod is a way of shipping to a zone and how much it costs
+type ShippingMethod struct {
+	Id              uuid.UUID     `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ZoneId          uuid.UUID     `json:"zone_id"`
+	Name            string        `json:"name"`
+	Type            string        `json:"type"`
+	Rates           ShippingRates `json:"rates"`
+	Currency        string        `json:"currency"`
+	MinDeliveryDays int64         `json:"min_delivery_days"`
+	MaxDeliveryDays int64         `json:"max_delivery_days"`
+	Status          string        `json:"status"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+// ShippingRates is the rate table of a shipping method, in minor units of its currency.
+//
+//   - flat: amount for every order
+//   - weight-banded: bands of max_weight in grams and amount, orders heavier than the last band cannot use the method
+//   - free-over-threshold: amount for orders below threshold, free from threshold up
+type ShippingRates struct {
+	Amount    int64        `json:"amount,omitempty" validate:"omitempty,is_amount"`
+	Bands     []WeightBand `json:"bands,omitempty" validate:"omitempty,dive"`
+	Threshold int64        `json:"threshold,omitempty" validate:"omitempty,is_amount"`
+}
+
+// WeightBand is the amount charged up to a weight
+type WeightBand struct {
+	MaxWeight int64 `json:"max_weight" validate:"required,is_amount"`
+	Amount    int64 `json:"amount"`
+}
+
+// StringList is a list of strings stored as a json array
+type StringList []string
+
+// CreateShippingZoneDto is the data transfer object to create a shipping zone
+type CreateShippingZoneDto struct {
+	Name      string   `json:"name" validate:"required,max=256"`
+	Countries []string `json:"countries" validate:"gt=0,dive,len=2,alpha"`
+	Regions   []string `json:"regions" validate:"omitempty,dive,max=256"`
+}
+
+// UpdateShippingZoneDto is the data transfer object to update a shipping zone
+type UpdateShippingZoneDto struct {
+	Name      *string         `json:"name" validate:"omitempty,max=256"`
+	Countries *[]string       `json:"countries" validate:"omitempty,gt=0,dive,len=2,alpha"`
+	Regions   *[]string       `json:"regions" validate:"omitempty,dive,max=256"`
+	Status    *ShippingStatus `json:"status" validate:"omitempty,is_enum"`
+}
+
+// CreateShippingMethodDto is the data transfer object to add a shipping method to a zone
+type CreateShippingMethodDto struct {
+	Name            string             `json:"name" validate:"required,max=256"`
+	Type            ShippingMethodType `json:"type" validate:"required,is_enum"`
+	Rates           ShippingRates      `json:"rates"`
+	Currency        Currency           `json:"currency" validate:"required,is_enum"`
+	MinDeliveryDays int64              `json:"min_delivery_days" validate:"min=0"`
+	MaxDeliveryDays int64              `json:"max_delivery_days" validate:"gtefield=MinDeliveryDays"`
+}
+
+// UpdateShippingMethodDto is the data transfer object to update a shipping method
+type UpdateShippingMethodDto struct {
+	Name            *string         `json:"name" validate:"omitempty,max=256"`
+	Rates           *ShippingRates  `json:"rates"`
+	MinDeliveryDays *int64          `json:"min_delivery_days" validate:"omitempty,min=0"`
+	MaxDeliveryDays *int64          `json:"max_delivery_days" validate:"omitempty,min=0"`
+	Status          *ShippingStatus `json:"status" validate:"omitempty,is_enum"`
+}
+
+// ShippingZonesResponse is the shipping zones data with pagination info
+type ShippingZonesResponse struct {
+	ShippingZones []*ShippingZone `json:"shipping_zones"`
+	PagingInfo    *PagingInfo     `json:"paging_info"`
+}
+
+// ShippingQuoteDto is the data transfer object to quote shipping for products to a destination
+type ShippingQuoteDto struct {
+	Items    []PlaceOrder `json:"items" validate:"gt=0,dive"`
+	Country  string       `json:"country" validate:"required,len=2,alpha"`
+	Region   string       `json:"region" validate:"omitempty,max=256"`
+	Currency Currency     `json:"currency" validate:"required,is_enum"`
+}
+
+// ShippingQuote is a shipping method available for an order and its fee
+type ShippingQuote struct {
+	ShippingMethodId uuid.UUID `json:"shipping_method_id"`
+	Name             string    `json:"name"`
+	Type             string    `json:"type"`
+	Fee              Money     `json:"fee"`
+	MinDeliveryDays  int64     `json:"min_delivery_days"`
+	MaxDeliveryDays  int64     `json:"max_delivery_days"`
+}
+
+// GetBillableWeight gets the weight in grams a product is charged for, its volumetric weight when that is more
+func (p *Product) GetBillableWeight() int64 {
+	volumetricWeight := p.Length * p.Width * p.Height * 1000 / VOLUMETRIC_DIVISOR
+	if volumetricWeight > p.Weight {
+		return volumetricWeight
+	}
+	return p.Weight
+}
+
+// Covers checks if a destination is in the zone
+func (z *ShippingZone) Covers(country, region string) bool {
+	if !z.Countries.Contains(country) {
+		return false
+	}
+	return len(z.Regions) == 0 || z.Regions.Contains(region)
+}
+
+// IsValidFor checks that the rates carry the settings a shipping method type needs
+func (r *ShippingRates) IsValidFor(methodType ShippingMethodType) bool {
+	switch methodType {
+	case SHIPPING_FLAT:
+		return len(r.Bands) == 0 && r.Threshold == 0
+	case SHIPPING_WEIGHT_BANDED:
+		if len(r.Bands) == 0 {
+			return false
+		}
+		for i := 1; i < len(r.Bands); i++ {
+			if r.Bands[i].MaxWeight <= r.Bands[i-1].MaxWeight {
+				return false
+			}
+		}
+		return true
+	case SHIPPING_FREE_OVER_THRESHOLD:
+		return r.Threshold > 0 && len(r.Bands) == 0
+	}
+	return false
+}
+
+// BandFor gets the amount of the lightest band a weight fits in
+func (r *ShippingRates) BandFor(weight int64) (int64, bool) {
+	for _, band := range r.Bands {
+		if weight <= band.MaxWeight {
+			return band.Amount, true
+		}
+	}
+	return 0, false
+}
+
+// Contains checks if a value is in the list
+func (s StringList) Contains(value string) bool {
+	for _, v := range s {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid checks if type is valid
+func (s ShippingMethodType) IsValid() bool {
+	switch s {
+	case SHIPPING_FLAT, SHIPPING_WEIGHT_BANDED, SHIPPING_FREE_OVER_THRESHOLD:
+		return true
+	}
+	return false
+}
+
+// IsValid checks if status is valid
+func (s ShippingStatus) IsValid() bool {
+	switch s {
+	case SHIPPING_ACTIVE, SHIPPING_INACTIVE:
+		return true
+	}
+	return false
+}
+
+func (r ShippingRates) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *ShippingRates) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &r)
+}
+
+func (s StringList) Value() (driver.Value, error) {
+	if s == nil {
+		s = StringList{}
+	}
+	return json.Marshal(s)
+}
+
+func (s *StringList) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &s)
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TaxClass string
+type TaxRateStatus string
+type TaxReportPeriod string
+
+const (
+	TAX_CLASS_STANDARD TaxClass = "standard"
+	TAX_CLASS_REDUCED  TaxClass = "reduced"
+	TAX_CLASS_ZERO     TaxClass = "zero"
+
+	TAX_RATE_ACTIVE   TaxRateStatus = "active"
+	TAX_RATE_INACTIVE TaxRateStatus = "inactive"
+
+	TAX_REPORT_DAY   TaxReportPeriod = "day"
+	TAX_REPORT_WEEK  TaxReportPeriod = "week"
+	TAX_REPORT_MONTH TaxReportPeriod = "month"
+)
+
+// TAX_RATE_PRECISION is the number of decimals kept on stored and snapshotted tax rates
+const TAX_RATE_PRECISION = 4
+
+// TaxRate is the percent of tax charged on a tax class of products in a country, or in a
+// region of it. A rate with an empty region covers the regions without their own rate.
+type TaxRate struct {
+	Id       uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	Name     string    `json:"name"`
+	Country  string    `json:"country"`
+	Region   string    `json:"region"`
+	TaxClass string    `json:"tax_class"`
+	Rate     string    `json:"rate"`
+	// inclusive rates are already part of the prices, exclusive rates are added on top
+	Inclusive bool      `json:"inclusive"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrderTax is a tax line of an order record, with the rate used at the time of the order
+type OrderTax struct {
+	Id            uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	OrderId       uuid.UUID `json:"order_id"`
+	OrderRecordId uuid.UUID `json:"order_record_id"`
+	TaxRateId     uuid.UUID `json:"tax_rate_id"`
+	Name          string    `json:"name"`
+	Rate          string    `json:"rate"`
+	Inclusive     bool      `json:"inclusive"`
+	// the amount the tax was worked out on, after discounts
+	TaxableAmount Money     `json:"taxable_amount"`
+	Amount        Money     `json:"amount"`
+	Currency      string    `json:"currency"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CreateTaxRateDto is the data transfer object to create a tax rate
+type CreateTaxRateDto struct {
+	Name      string   `json:"name" validate:"required,max=256"`
+	Country   string   `json:"country" validate:"required,len=2,alpha"`
+	Region    string   `json:"region" validate:"omitempty,max=256"`
+	TaxClass  TaxClass `json:"tax_class" validate:"required,is_enum"`
+	Rate      string   `json:"rate" validate:"required,numeric"`
+	Inclusive bool     `json:"inclusive"`
+}
+
+// UpdateTaxRateDto is the data transfer object to update a tax rate
+type UpdateTaxRateDto struct {
+	Name      *string        `json:"name" validate:"omitempty,max=256"`
+	Rate      *string        `json:"rate" validate:"omitempty,numeric"`
+	Inclusive *bool          `json:"inclusive"`
+	Status    *TaxRateStatus `json:"status" validate:"omitempty,is_enum"`
+}
+
+// TaxRatesResponse is the tax rates data with pagination info
+type TaxRatesResponse struct {
+	TaxRates   []*TaxRate  `json:"tax_rates"`
+	PagingInfo *PagingInfo `json:"paging_info"`
+}
+
+// SetTaxExemptionDto is the data transfer object to exempt a customer from tax
+type SetTaxExemptionDto struct {
+	TaxExempt bool `json:"tax_exempt"`
+}
+
+// TaxReportDto is the query of a tax report
+type TaxReportDto struct {
+	From     time.Time       `form:"from" time_format:"2006-01-02" validate:"required"`
+	To       time.Time       `form:"to" time_format:"2006-01-02" validate:"required,gtefield=From"`
+	Period   TaxReportPeriod `form:"period" validate:"omitempty,is_enum"`
+	Currency Currency        `form:"currency" validate:"omitempty,is_enum"`
+}
+
+// TaxReport is the tax collected between two dates, by period and tax rate
+type TaxReport struct {
+	From   time.Time       `json:"from"`
+	To     time.Time       `json:"to"`
+	Period TaxReportPeriod `json:"period"`
+	Rows   []*TaxReportRow `json:"rows"`
+}
+
+// TaxReportRow is the tax collected at a rate over a period
+type TaxReportRow struct {
+	Period        time.Time `json:"period"`
+	TaxRateId     uuid.UUID `json:"tax_rate_id"`
+	Name          string    `json:"name"`
+	Rate          string    `json:"rate"`
+	Inclusive     bool      `json:"inclusive"`
+	Orders        int64     `json:"orders"`
+	TaxableAmount Money     `json:"taxable_amount"`
+	Amount        Money     `json:"amount"`
+}
+
+// ParseTaxRate parses a tax rate, which must be a percent from 0 to 100
+func ParseTaxRate(rate string) (*big.Rat, bool) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok || r.Sign() < 0 || r.Cmp(big.NewRat(100, 1)) > 0 {
+		return nil, false
+	}
+	return r, true
+}
+
+// Matches checks if the rate covers a tax class of products in a region
+func (t *TaxRate) Matches(region string, taxClass string) bool {
+	return t.TaxClass == taxClass && (t.Region == "" || t.Region == region)
+}
+
+// TaxOn works out the tax on an amount at the rate, extracting it from inclusive amounts
+func (t *TaxRate) TaxOn(amount Money) (Money, error) {
+	r, ok := ParseTaxRate(t.Rate)
+	if !ok {
+		return Money{}, errors.New("invalid tax rate")
+	}
+	ratio := new(big.Rat).Quo(r, big.NewRat(100, 1))
+	if t.Inclusive {
+		ratio = new(big.Rat).Quo(r, new(big.Rat).Add(r, big.NewRat(100, 1)))
+	}
+	return amount.MulRat(ratio, ROUND_HALF_UP)
+}
+
+// AfterFind sets the currency of the tax line amounts
+func (o *OrderTax) AfterFind(tx *gorm.DB) error {
+	o.TaxableAmount.Currency = Currency(o.Currency)
+	o.Amount.Currency = Currency(o.Currency)
+	return nil
+}
+
+// IsValid checks if tax class is valid
+func (t TaxClass) IsValid() bool {
+	switch t {
+	case TAX_CLASS_STANDARD, TAX_CLASS_REDUCED, TAX_CLASS_ZERO:
+		return true
+	}
+	return false
+}
+
+// IsValid checks if status is valid
+func (t TaxRateStatus) IsValid() bool {
+	switch t {
+	case TAX_RATE_ACTIVE, TAX_RATE_INACTIVE:
+		return true
+	}
+	return false
+}
+
+// IsValid checks if period is valid
+func (t TaxReportPeriod) IsValid() bool {
+	switch t {
+	case TAX_REPORT_DAY, TAX_REPORT_WEEK, TAX_REPORT_MONTH:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+// trackingDescriptions are what customers are told about each order status, in place of the
+// notes kept in the order history
+var trackingDescriptions = map[OrderStatus]string{
+	PENDING:            "order placed",
+	PROCESSING:         "payment confirmed, order being prepared",
+	SHIPPED:            "order shipped",
+	DELIVERED:          "order delivered",
+	CANCELLED:          "order cancelled",
+	REFUNDED:           "order refunded",
+	PARTIALLY_REFUNDED: "order partially refunded",
+}
+
+// TrackOrderDto is the data transfer object to track an order by its tracking code
+type TrackOrderDto struct {
+	Email string `form:"email" validate:"required,email"`
+}
+
+// OrderTracking is what anyone with an order's tracking code and customer email can see of it
+type OrderTracking struct {
+	TrackingCode string           `json:"tracking_code"`
+	Status       string           `json:"status"`
+	RefundStatus string           `json:"refund_status,omitempty"`
+	PlacedAt     time.Time        `json:"placed_at"`
+	Timeline     []TrackingUpdate `json:"timeline"`
+}
+
+// TrackingUpdate is a change of status on an order's tracking timeline
+type TrackingUpdate struct {
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Tracking redacts an order to its status timeline, leaving out the history notes, amounts,
+// addresses and everything else about the customer
+func (o *Order) Tracking() *OrderTracking {
+	tracking := &OrderTracking{
+		TrackingCode: o.TrackingCode,
+		Status:       o.Status,
+		RefundStatus: o.RefundStatus,
+		PlacedAt:     o.CreatedAt,
+		Timeline:     []TrackingUpdate{},
+	}
+	for _, history := range o.History.Data {
+		// repeated statuses add nothing a customer can see
+		if n := len(tracking.Timeline); n > 0 && tracking.Timeline[n-1].Status == history.Status {
+			continue
+		}
+		tracking.Timeline = append(tracking.Timeline, TrackingUpdate{
+			Status:      history.Status,
+			Description: trackingDescriptions[OrderStatus(history.Status)],
+			CreatedAt:   history.CreatedAt,
+		})
+	}
+	return tracking
+}
@@ -21,8 +21,10 @@ type User struct {
 	LastName     string    `json:"last_name"`
 	FirstName    string    `json:"first_name"`
 	Role         string    `json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	// tax exempt customers are not charged tax on their orders
+	TaxExempt bool      `json:"tax_exempt"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // SignUpDto the sign up data transfer object
@@ -36,8 +38,9 @@ type SignUpDto struct {
 
 // SignInDto the sign in data transfer object
 type SignInDto struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,is_password"`
+	Email     string  `json:"email" validate:"required,email"`
+	Password  string  `json:"password" validate:"required,is_password"`
+	CartToken *string `json:"cart_token"`
 }
 
 // AuthenticatedUser the authenticated user object
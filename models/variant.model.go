@@ -0,0 +1,170 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductOption is a way a product comes in, like size or colour, and the values it comes in
+type ProductOption struct {
+	Id        uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ProductId uuid.UUID  `json:"product_id"`
+	Name      string     `json:"name"`
+	Values    StringList `json:"values"`
+	Position  int64      `json:"position"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// ProductVariant is one combination of a product's option values, sold under its own SKU and stock
+type ProductVariant struct {
+	Id        uuid.UUID      `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	ProductId uuid.UUID      `json:"product_id"`
+	Sku       string         `json:"sku"`
+	Options   VariantOptions `json:"options"`
+	// the product's price and discount are used when empty
+	Price             *Money `json:"price"`
+	Discount          *Money `json:"discount"`
+	Currency          string `json:"currency"`
+	AvailableQuantity int64  `json:"available_quantity"`
+	// quantity held for pending orders, it cannot be sold to other orders
+	ReservedQuantity int64     `json:"reserved_quantity"`
+	Status           string    `json:"status"`
+	Position         int64     `json:"position"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// quantity that can still be ordered, the available quantity less what is reserved
+	SellableQuantity int64 `json:"sellable_quantity" gorm:"-"`
+	// whether the variant can be bought right now
+	IsAvailable bool `json:"is_available" gorm:"-"`
+}
+
+// VariantOptions is the value of each option of a product for a variant, stored as a json object
+type VariantOptions map[string]string
+
+// ProductOptionDto is the data transfer object for an option of a product
+type ProductOptionDto struct {
+	Name   string   `json:"name" validate:"required,max=50"`
+	Values []string `json:"values" validate:"gt=0,dive,required,max=50"`
+}
+
+// CreateVariantDto is the data transfer object to add a variant to a product
+type CreateVariantDto struct {
+	Sku string `json:"sku" validate:"required,max=64"`
+	// a value for every option of the product
+	Options map[string]string `json:"options" validate:"omitempty"`
+	// the product's price and discount are used when empty
+	Price    *int64 `json:"price" validate:"omitempty,is_amount"`
+	Discount *int64 `json:"discount" validate:"omitempty,min=0"`
+	Quantity int64  `json:"quantity" validate:"min=0"`
+	Position int64  `json:"position" validate:"min=0"`
+}
+
+// UpdateVariantDto is the data transfer object to update a variant
+type UpdateVariantDto struct {
+	Sku      *string            `json:"sku" validate:"omitempty,max=64"`
+	Options  *map[string]string `json:"options" validate:"omitempty"`
+	Price    *int64             `json:"price" validate:"omitempty,is_amount"`
+	Discount *int64             `json:"discount" validate:"omitempty,min=0"`
+	// drops the price and discount of the variant so it sells at the product's
+	ResetPrice bool   `json:"reset_price"`
+	Quantity   *int64 `json:"quantity" validate:"omitempty,min=0"`
+	// why the quantity changed, kept in the inventory ledger
+	QuantityReason string         `json:"quantity_reason" validate:"omitempty,max=255"`
+	Status         *ProductStatus `json:"status" validate:"omitempty,is_enum"`
+	Position       *int64         `json:"position" validate:"omitempty,min=0"`
+}
+
+// GetVariant gets a variant of the product by id
+func (p *Product) GetVariant(id uuid.UUID) *ProductVariant {
+	for _, variant := range p.Variants {
+		if variant.Id == id {
+			return variant
+		}
+	}
+	return nil
+}
+
+// HasVariants checks if the product is sold through its variants
+func (p *Product) HasVariants() bool {
+	return len(p.Variants) > 0
+}
+
+// ForVariant gets a copy of the product priced as one of its variants. A variant with its own
+// price or discount drops the product's prices in other currencies, its price is converted instead.
+func (p *Product) ForVariant(variant *ProductVariant) *Product {
+	if variant == nil || (variant.Price == nil && variant.Discount == nil) {
+		return p
+	}
+	product := *p
+	product.Prices = nil
+	if variant.Price != nil {
+		product.Price = *variant.Price
+	}
+	if variant.Discount != nil {
+		product.Discount = *variant.Discount
+	}
+	return &product
+}
+
+// FitsOptions checks that the variant has exactly one of the allowed values for every option
+func (o VariantOptions) FitsOptions(options []*ProductOption) bool {
+	if len(o) != len(options) {
+		return false
+	}
+	for _, option := range options {
+		value, ok := o[option.Name]
+		if !ok || !option.Values.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals checks if two variants have the same option values
+func (o VariantOptions) Equals(other VariantOptions) bool {
+	if len(o) != len(other) {
+		return false
+	}
+	for name, value := range o {
+		if other[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// AfterFind sets the currency of the variant amounts and whether it can be bought
+func (v *ProductVariant) AfterFind(tx *gorm.DB) error {
+	if v.Price != nil {
+		v.Price.Currency = Currency(v.Currency)
+	}
+	if v.Discount != nil {
+		v.Discount.Currency = Currency(v.Currency)
+	}
+	v.SellableQuantity = v.AvailableQuantity - v.ReservedQuantity
+	v.IsAvailable = v.Status == string(IN_STOCK) && v.SellableQuantity > 0
+	return nil
+}
+
+func (o VariantOptions) Value() (driver.Value, error) {
+	if o == nil {
+		o = VariantOptions{}
+	}
+	return json.Marshal(o)
+}
+
+func (o *VariantOptions) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to byte failed")
+	}
+
+	return json.Unmarshal(b, &o)
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WarehouseStatus string
+type AllocationStrategy string
+
+const (
+	WAREHOUSE_ACTIVE   WarehouseStatus = "active"
+	WAREHOUSE_INACTIVE WarehouseStatus = "inactive"
+
+	// ALLOCATE_NEAREST fulfils each order record from the warehouse closest to the shipping address that has all of it
+	ALLOCATE_NEAREST AllocationStrategy = "nearest"
+	// ALLOCATE_PRIORITY fulfils each order record from the first warehouse by priority that has all of it
+	ALLOCATE_PRIORITY AllocationStrategy = "priority"
+	// ALLOCATE_SPLIT fulfils each order record from as many warehouses as it takes, the closest first
+	ALLOCATE_SPLIT AllocationStrategy = "split"
+)
+
+// Warehouse is a location stock is held in and orders are fulfilled from. Stock that is not put
+// in a given warehouse goes to the default one.
+type Warehouse struct {
+	Id      uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	Name    string    `json:"name"`
+	Code    string    `json:"code"`
+	Country string    `json:"country"`
+	Region  string    `json:"region"`
+	// warehouses with a lower priority are allocated from first
+	Priority  int64     `json:"priority"`
+	Status    string    `json:"status"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WarehouseStock is the quantity of a product, or of one of its variants, held in a warehouse.
+// The available quantity of a product or variant is the sum of its stock in every warehouse.
+type WarehouseStock struct {
+	Id          uuid.UUID  `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	WarehouseId uuid.UUID  `json:"warehouse_id"`
+	ProductId   uuid.UUID  `json:"product_id"`
+	VariantId   *uuid.UUID `json:"variant_id"`
+	Quantity    int64      `json:"quantity"`
+	// quantity held for pending orders allocated to the warehouse
+	ReservedQuantity int64     `json:"reserved_quantity"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// OrderAllocation is the quantity of an order record a warehouse fulfils
+type OrderAllocation struct {
+	Id            uuid.UUID `json:"id" gorm:"column:id;PRIMARY_KEY;type:uuid;default:gen_random_uuid()"`
+	OrderRecordId uuid.UUID `json:"order_record_id"`
+	WarehouseId   uuid.UUID `json:"warehouse_id"`
+	Quantity      int64     `json:"quantity"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// CreateWarehouseDto is the data transfer object to create a warehouse
+type CreateWarehouseDto struct {
+	Name      string `json:"name" validate:"required,max=256"`
+	Code      string `json:"code" validate:"required,max=50"`
+	Country   string `json:"country" validate:"omitempty,len=2,alpha"`
+	Region    string `json:"region" validate:"omitempty,max=256"`
+	Priority  int64  `json:"priority" validate:"min=0"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// UpdateWarehouseDto is the data transfer object to update a warehouse. A warehouse stops being the
+// default when another one is made the default.
+type UpdateWarehouseDto struct {
+	Name      *string          `json:"name" validate:"omitempty,max=256"`
+	Country   *string          `json:"country" validate:"omitempty,len=2,alpha"`
+	Region    *string          `json:"region" validate:"omitempty,max=256"`
+	Priority  *int64           `json:"priority" validate:"omitempty,min=0"`
+	Status    *WarehouseStatus `json:"status" validate:"omitempty,is_enum"`
+	IsDefault bool             `json:"is_default"`
+}
+
+// WarehousesResponse is the warehouses data with pagination info
+type WarehousesResponse struct {
+	Warehouses []*Warehouse `json:"warehouses"`
+	PagingInfo *PagingInfo  `json:"paging_info"`
+}
+
+// IsValid checks if warehouse status is valid
+func (s WarehouseStatus) IsValid() bool {
+	switch s {
+	case WAREHOUSE_ACTIVE, WAREHOUSE_INACTIVE:
+		return true
+	}
+	return false
+}
+
+// IsValid checks if allocation strategy is valid
+func (s AllocationStrategy) IsValid() bool {
+	switch s {
+	case ALLOCATE_NEAREST, ALLOCATE_PRIORITY, ALLOCATE_SPLIT:
+		return true
+	}
+	return false
+}
+
+// Proximity is how close a warehouse is to an address, 2 in the same region, 1 in the same country and 0 elsewhere
+func (w *Warehouse) Proximity(country, region string) int {
+	if w.Country == "" || !strings.EqualFold(w.Country, country) {
+		return 0
+	}
+	if w.Region != "" && strings.EqualFold(w.Region, region) {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// Address repo object
+type Address struct {
+	repo *db.Database
+}
+
+// AddressRepo exposes address's methods to other packages
+type AddressRepo interface {
+	CreateAddress(ctx context.Context, address *models.Address) (*models.Address, error)
+	GetAddressByFields(ctx context.Context, fields map[string]interface{}) (*models.Address, error)
+	GetUserAddresses(ctx context.Context, userId uuid.UUID) ([]*models.Address, error)
+	UpdateAddressById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	UnsetDefaultAddress(ctx context.Context, userId uuid.UUID, field string) error
+	DeleteAddress(ctx context.Context, id uuid.UUID) error
+}
+
+// NewAddressRepo instantiates the Address Repo object
+func NewAddressRepo(db *db.Database) AddressRepo {
+	address := &Address{
+		repo: db,
+	}
+	return AddressRepo(address)
+}
+
+// CreateAddress stores a new address
+func (a *Address) CreateAddress(ctx context.Context, address *models.Address) (*models.Address, error) {
+	address.CreatedAt = time.Now().UTC()
+	address.UpdatedAt = time.Now().UTC()
+
+	db := a.repo.PostgresDb.WithContext(ctx).Create(address)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateAddress error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return address, nil
+}
+
+func (a *Address) GetAddressByFields(ctx context.Context, fields map[string]interface{}) (*models.Address, error) {
+	var address models.Address
+	db := a.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&address)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetAddressByFields error: %v, (%v)", "record not found", db.Error)
+		return &address, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if address.Id == uuid.Nil {
+		return nil, messages.ErrAddressNotFound
+	}
+	return &address, nil
+}
+
+// GetUserAddresses gets the address book of a user, default addresses first
+func (a *Address) GetUserAddresses(ctx context.Context, userId uuid.UUID) ([]*models.Address, error) {
+	addresses := []*models.Address{}
+	db := a.repo.PostgresDb.WithContext(ctx).Where("user_id = ?", userId).
+		Order("is_default_shipping desc").Order("is_default_billing desc").Order("created_at desc").
+		Find(&addresses)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetUserAddresses error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return addresses, nil
+}
+
+// UpdateAddressById updates an address with a map so empty and boolean values can be set
+func (a *Address) UpdateAddressById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := a.repo.PostgresDb.WithContext(ctx).Model(&models.Address{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateAddressById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// UnsetDefaultAddress clears a default flag, is_default_shipping or is_default_billing, on all of a user's addresses
+func (a *Address) UnsetDefaultAddress(ctx context.Context, userId uuid.UUID, field string) error {
+	db := a.repo.PostgresDb.WithContext(ctx).Model(&models.Address{}).
+		Where("user_id = ?", userId).Where(field+" = ?", true).
+		UpdateColumns(map[string]interface{}{field: false, "updated_at": time.Now().UTC()})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UnsetDefaultAddress error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	return nil
+}
+
+func (a *Address) DeleteAddress(ctx context.Context, id uuid.UUID) error {
+	db := a.repo.PostgresDb.WithContext(ctx).Delete(&models.Address{Id: id})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteAddress error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// CartItem repo object
+type CartItem struct {
+	repo *db.Database
+}
+
+// CartItemRepo exposes cart item's methods to other packages
+type CartItemRepo interface {
+	CreateCartItem(ctx context.Context, cartItem *models.CartItem) (*models.CartItem, error)
+	GetCartItemByFields(ctx context.Context, fields map[string]interface{}) (*models.CartItem, error)
+	UpdateCartItemById(ctx context.Context, id uuid.UUID, cartItem *models.CartItem) error
+	DeleteCartItem(ctx context.Context, cartItem *models.CartItem) error
+}
+
+// NewCartItemRepo instantiates the CartItem Repo object
+func NewCartItemRepo(db *db.Database) CartItemRepo {
+	cartItem := &CartItem{
+		repo: db,
+	}
+	return CartItemRepo(cartItem)
+}
+
+// CreateCartItem stores a new cart item
+func (c *CartItem) CreateCartItem(ctx context.Context, cartItem *models.CartItem) (*models.CartItem, error) {
+	cartItem.CreatedAt = time.Now().UTC()
+	cartItem.UpdatedAt = time.Now().UTC()
+
+	db := c.repo.PostgresDb.WithContext(ctx).Create(cartItem)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateCartItem error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, errors.New("an error occurred")
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return cartItem, nil
+}
+
+func (c *CartItem) GetCartItemByFields(ctx context.Context, fields map[string]interface{}) (*models.CartItem, error) {
+	var cartItem models.CartItem
+	db := c.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&cartItem)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCartItemByFields error: %v, (%v)", "record not found", db.Error)
+		return &cartItem, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if cartItem.Id == uuid.Nil {
+		return nil, messages.ErrCartItemNotFound
+	}
+	return &cartItem, nil
+}
+
+func (c *CartItem) UpdateCartItemById(ctx context.Context, id uuid.UUID, cartItem *models.CartItem) error {
+	cartItem.UpdatedAt = time.Now().UTC()
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.CartItem{
+		Id: id,
+	}).UpdateColumns(cartItem)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateCartItemById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+func (c *CartItem) DeleteCartItem(ctx context.Context, cartItem *models.CartItem) error {
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.CartItem{}).Delete(cartItem)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteCartItem error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
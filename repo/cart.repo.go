@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// Cart repo object
+type Cart struct {
+	repo *db.Database
+}
+
+// CartRepo exposes cart's methods to other packages
+type CartRepo interface {
+	CreateCart(ctx context.Context, cart *models.Cart) (*models.Cart, error)
+	GetCartByFields(ctx context.Context, fields map[string]interface{}) (*models.Cart, error)
+	UpdateCartById(ctx context.Context, id uuid.UUID, cart *models.Cart) error
+	MoveCart(ctx context.Context, id uuid.UUID, from string, to string) error
+}
+
+// NewCartRepo instantiates the Cart Repo object
+func NewCartRepo(db *db.Database) CartRepo {
+	cart := &Cart{
+		repo: db,
+	}
+	return CartRepo(cart)
+}
+
+// CreateCart stores a new cart
+func (c *Cart) CreateCart(ctx context.Context, cart *models.Cart) (*models.Cart, error) {
+	cart.CreatedAt = time.Now().UTC()
+	cart.UpdatedAt = time.Now().UTC()
+
+	db := c.repo.PostgresDb.WithContext(ctx).Create(cart)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateCart error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, errors.New("an error occurred")
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return cart, nil
+}
+
+func (c *Cart) GetCartByFields(ctx context.Context, fields map[string]interface{}) (*models.Cart, error) {
+	var cart models.Cart
+	db := c.repo.PostgresDb.WithContext(ctx).Where(fields).
+		Preload("CartItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("cart_items.created_at asc")
+		}).
+		Find(&cart)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCartByFields error: %v, (%v)", "record not found", db.Error)
+		return &cart, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if cart.Id == uuid.Nil {
+		return nil, messages.ErrCartNotFound
+	}
+	return &cart, nil
+}
+
+func (c *Cart) UpdateCartById(ctx context.Context, id uuid.UUID, cart *models.Cart) error {
+	cart.UpdatedAt = time.Now().UTC()
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.Cart{
+		Id: id,
+	}).UpdateColumns(cart)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateCartById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// MoveCart moves a cart that is still in the from status to another, so a cart cannot be checked out twice
+func (c *Cart) MoveCart(ctx context.Context, id uuid.UUID, from string, to string) error {
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.Cart{}).
+		Where("id = ? AND status = ?", id, from).
+		UpdateColumns(map[string]interface{}{"status": to, "updated_at": time.Now().UTC()})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::MoveCart error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if db.RowsAffected == 0 {
+		return messages.ErrCartNotFound
+	}
+	return nil
+}
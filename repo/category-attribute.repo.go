@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// CategoryAttribute repo object
+type CategoryAttribute struct {
+	repo *db.Database
+}
+
+// CategoryAttributeRepo exposes category attribute's methods to other packages
+type CategoryAttributeRepo interface {
+	CreateCategoryAttribute(ctx context.Context, attribute *models.CategoryAttribute) (*models.CategoryAttribute, error)
+	GetCategoryAttributeByFields(ctx context.Context, fields map[string]interface{}) (*models.CategoryAttribute, error)
+	GetCategoryAttributes(ctx context.Context, categoryIds []uuid.UUID) ([]*models.CategoryAttribute, error)
+	UpdateCategoryAttributeById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteCategoryAttribute(ctx context.Context, id uuid.UUID) error
+}
+
+// NewCategoryAttributeRepo instantiates the CategoryAttribute Repo object
+func NewCategoryAttributeRepo(db *db.Database) CategoryAttributeRepo {
+	attribute := &CategoryAttribute{
+		repo: db,
+	}
+	return CategoryAttributeRepo(attribute)
+}
+
+// CreateCategoryAttribute stores a new attribute of a category
+func (c *CategoryAttribute) CreateCategoryAttribute(ctx context.Context, attribute *models.CategoryAttribute) (*models.CategoryAttribute, error) {
+	attribute.CreatedAt = time.Now().UTC()
+	attribute.UpdatedAt = time.Now().UTC()
+
+	db := c.repo.PostgresDb.WithContext(ctx).Create(attribute)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateCategoryAttribute error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, messages.ErrAttributeWithKeyAlreadyExists
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return attribute, nil
+}
+
+func (c *CategoryAttribute) GetCategoryAttributeByFields(ctx context.Context, fields map[string]interface{}) (*models.CategoryAttribute, error) {
+	var attribute models.CategoryAttribute
+	db := c.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&attribute)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCategoryAttributeByFields error: %v, (%v)", "record not found", db.Error)
+		return &attribute, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if attribute.Id == uuid.Nil {
+		return nil, messages.ErrCategoryAttributeNotFound
+	}
+	return &attribute, nil
+}
+
+// GetCategoryAttributes gets the attributes products of the categories can have, those of the categories above them included
+func (c *CategoryAttribute) GetCategoryAttributes(ctx context.Context, categoryIds []uuid.UUID) ([]*models.CategoryAttribute, error) {
+	attributes := []*models.CategoryAttribute{}
+	if len(categoryIds) == 0 {
+		return attributes, nil
+	}
+	db := c.repo.PostgresDb.WithContext(ctx).Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id, parent_id FROM categories WHERE id IN ?
+			UNION
+			SELECT categories.id, categories.parent_id FROM categories JOIN tree ON categories.id = tree.parent_id
+		)
+		SELECT * FROM category_attributes
+		WHERE category_id IN (SELECT id FROM tree)
+		ORDER BY position ASC, key ASC`, categoryIds).Scan(&attributes)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCategoryAttributes error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return attributes, nil
+}
+
+// UpdateCategoryAttributeById updates an attribute with a map so it can stop being required
+func (c *CategoryAttribute) UpdateCategoryAttributeById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.CategoryAttribute{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateCategoryAttributeById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// DeleteCategoryAttribute deletes an attribute of a category
+func (c *CategoryAttribute) DeleteCategoryAttribute(ctx context.Context, id uuid.UUID) error {
+	db := c.repo.PostgresDb.WithContext(ctx).Delete(&models.CategoryAttribute{Id: id})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteCategoryAttribute error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
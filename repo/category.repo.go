@@ -0,0 +1,176 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// CATEGORY_TREE_LOCK is the key of the advisory lock taken while the category tree is changed
+const CATEGORY_TREE_LOCK int64 = 7_240_001
+
+// Category repo object
+type Category struct {
+	repo *db.Database
+}
+
+// CategoryRepo exposes category's methods to other packages
+type CategoryRepo interface {
+	CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error)
+	GetCategoryByFields(ctx context.Context, fields map[string]interface{}) (*models.Category, error)
+	GetCategoriesByIds(ctx context.Context, ids []uuid.UUID) ([]*models.Category, error)
+	GetAllCategories(ctx context.Context) ([]*models.Category, error)
+	GetCategoryDescendantIds(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	LockCategories(ctx context.Context, ids []uuid.UUID) ([]*models.Category, error)
+	LockCategoryTree(ctx context.Context) error
+	CountSubcategories(ctx context.Context, id uuid.UUID) (int64, error)
+	UpdateCategoryById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteCategory(ctx context.Context, id uuid.UUID) error
+}
+
+// NewCategoryRepo instantiates the Category Repo object
+func NewCategoryRepo(db *db.Database) CategoryRepo {
+	category := &Category{
+		repo: db,
+	}
+	return CategoryRepo(category)
+}
+
+// CreateCategory stores a new category
+func (c *Category) CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	category.CreatedAt = time.Now().UTC()
+	category.UpdatedAt = time.Now().UTC()
+
+	db := c.repo.PostgresDb.WithContext(ctx).Create(category)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateCategory error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return category, nil
+}
+
+func (c *Category) GetCategoryByFields(ctx context.Context, fields map[string]interface{}) (*models.Category, error) {
+	var category models.Category
+	db := c.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&category)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCategoryByFields error: %v, (%v)", "record not found", db.Error)
+		return &category, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if category.Id == uuid.Nil {
+		return nil, messages.ErrCategoryNotFound
+	}
+	return &category, nil
+}
+
+// GetCategoriesByIds gets the categories with the given ids, returning ErrCategoryNotFound when any is missing
+func (c *Category) GetCategoriesByIds(ctx context.Context, ids []uuid.UUID) ([]*models.Category, error) {
+	var categories []*models.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+	db := c.repo.PostgresDb.WithContext(ctx).Where("id IN ?", ids).Find(&categories)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCategoriesByIds error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	if len(categories) != len(ids) {
+		return nil, messages.ErrCategoryNotFound
+	}
+	return categories, nil
+}
+
+// GetAllCategories gets every category, unnested
+func (c *Category) GetAllCategories(ctx context.Context) ([]*models.Category, error) {
+	var categories []*models.Category
+	db := c.repo.PostgresDb.WithContext(ctx).Order("position asc").Order("name asc").Find(&categories)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetAllCategories error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return categories, nil
+}
+
+// GetCategoryDescendantIds gets the id of a category and of every category below it
+func (c *Category) GetCategoryDescendantIds(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	db := c.repo.PostgresDb.WithContext(ctx).Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION
+			SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id
+		)
+		SELECT id FROM tree`, id).Scan(&ids)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCategoryDescendantIds error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return ids, nil
+}
+
+// LockCategories locks categories for update in id order, so moves that lock the same categories queue up
+func (c *Category) LockCategories(ctx context.Context, ids []uuid.UUID) ([]*models.Category, error) {
+	var categories []*models.Category
+	db := c.repo.PostgresDb.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).Order("id").Find(&categories)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::LockCategories error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return categories, nil
+}
+
+// LockCategoryTree takes a lock on the whole category tree until the surrounding transaction ends, so moves
+// run one at a time and always see the tree other moves left
+func (c *Category) LockCategoryTree(ctx context.Context) error {
+	db := c.repo.PostgresDb.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(?)", CATEGORY_TREE_LOCK)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::LockCategoryTree error: %v, (%v)", "lock not taken", db.Error)
+		return errors.New("something went wrong")
+	}
+	return nil
+}
+
+// CountSubcategories counts the categories directly below a category
+func (c *Category) CountSubcategories(ctx context.Context, id uuid.UUID) (int64, error) {
+	var count int64
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.Category{}).Where("parent_id = ?", id).Count(&count)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CountSubcategories error: %v, (%v)", "record not found", db.Error)
+		return 0, errors.New("something went wrong")
+	}
+	return count, nil
+}
+
+// UpdateCategoryById updates a category with a map so it can be moved to the top level
+func (c *Category) UpdateCategoryById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.Category{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateCategoryById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// DeleteCategory deletes a category
+func (c *Category) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	db := c.repo.PostgresDb.WithContext(ctx).Delete(&models.Category{Id: id})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteCategory error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// CouponRedemption repo object
+type CouponRedemption struct {
+	repo *db.Database
+}
+
+// CouponRedemptionRepo exposes coupon redemption's methods to other packages
+type CouponRedemptionRepo interface {
+	CreateCouponRedemption(ctx context.Context, redemption *models.CouponRedemption) (*models.CouponRedemption, error)
+	CountCouponRedemptions(ctx context.Context, fields map[string]interface{}) (int64, error)
+	GetCouponRedemptionsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.CouponRedemption, error)
+	DeleteCouponRedemption(ctx context.Context, redemption *models.CouponRedemption) error
+}
+
+// NewCouponRedemptionRepo instantiates the CouponRedemption Repo object
+func NewCouponRedemptionRepo(db *db.Database) CouponRedemptionRepo {
+	redemption := &CouponRedemption{
+		repo: db,
+	}
+	return CouponRedemptionRepo(redemption)
+}
+
+// CreateCouponRedemption stores a new coupon redemption
+func (c *CouponRedemption) CreateCouponRedemption(ctx context.Context, redemption *models.CouponRedemption) (*models.CouponRedemption, error) {
+	redemption.CreatedAt = time.Now().UTC()
+	redemption.UpdatedAt = time.Now().UTC()
+
+	db := c.repo.PostgresDb.WithContext(ctx).Create(redemption)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateCouponRedemption error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return redemption, nil
+}
+
+func (c *CouponRedemption) CountCouponRedemptions(ctx context.Context, fields map[string]interface{}) (int64, error) {
+	var count int64
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.CouponRedemption{}).Where(fields).Count(&count)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CountCouponRedemptions error: %v, (%v)", "record not found", db.Error)
+		return 0, errors.New("something went wrong")
+	}
+	return count, nil
+}
+
+func (c *CouponRedemption) GetCouponRedemptionsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.CouponRedemption, error) {
+	var redemptions []*models.CouponRedemption
+	db := c.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&redemptions)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCouponRedemptionsByFields error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return redemptions, nil
+}
+
+func (c *CouponRedemption) DeleteCouponRedemption(ctx context.Context, redemption *models.CouponRedemption) error {
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.CouponRedemption{}).Delete(redemption)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteCouponRedemption error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// Coupon repo object
+type Coupon struct {
+	repo *db.Database
+}
+
+// CouponRepo exposes coupon's methods to other packages
+type CouponRepo interface {
+	CreateCoupon(ctx context.Context, coupon *models.Coupon) (*models.Coupon, error)
+	GetCouponByFields(ctx context.Context, fields map[string]interface{}) (*models.Coupon, error)
+	GetCouponForUpdate(ctx context.Context, fields map[string]interface{}) (*models.Coupon, error)
+	GetAllCoupons(ctx context.Context, query *models.APIPagingDto) (*models.CouponsResponse, error)
+	UpdateCouponById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	AddUsedCount(ctx context.Context, id uuid.UUID, count int64) error
+}
+
+// NewCouponRepo instantiates the Coupon Repo object
+func NewCouponRepo(db *db.Database) CouponRepo {
+	coupon := &Coupon{
+		repo: db,
+	}
+	return CouponRepo(coupon)
+}
+
+// CreateCoupon stores a new coupon
+func (c *Coupon) CreateCoupon(ctx context.Context, coupon *models.Coupon) (*models.Coupon, error) {
+	coupon.CreatedAt = time.Now().UTC()
+	coupon.UpdatedAt = time.Now().UTC()
+
+	db := c.repo.PostgresDb.WithContext(ctx).Create(coupon)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateCoupon error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, messages.ErrCouponWithCodeAlreadyExists
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return coupon, nil
+}
+
+func (c *Coupon) GetCouponByFields(ctx context.Context, fields map[string]interface{}) (*models.Coupon, error) {
+	var coupon models.Coupon
+	db := c.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&coupon)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCouponByFields error: %v, (%v)", "record not found", db.Error)
+		return &coupon, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if coupon.Id == uuid.Nil {
+		return nil, messages.ErrCouponNotFound
+	}
+	return &coupon, nil
+}
+
+// GetCouponForUpdate gets a coupon and locks its row until the surrounding transaction ends
+func (c *Coupon) GetCouponForUpdate(ctx context.Context, fields map[string]interface{}) (*models.Coupon, error) {
+	var coupon models.Coupon
+	db := c.repo.PostgresDb.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(fields).Find(&coupon)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetCouponForUpdate error: %v, (%v)", "record not found", db.Error)
+		return &coupon, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if coupon.Id == uuid.Nil {
+		return nil, messages.ErrCouponNotFound
+	}
+	return &coupon, nil
+}
+
+// couponFilterFields are the coupon columns a query filter can use
+var couponFilterFields = []string{"code", "type", "currency", "stackable", "status", "starts_at", "ends_at", "created_at"}
+
+func (c *Coupon) GetAllCoupons(ctx context.Context, query *models.APIPagingDto) (*models.CouponsResponse, error) {
+	var coupons []*models.Coupon
+	var count int64
+	queryInfo, offset := getPaginationInfo(query)
+
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.Coupon{})
+	filters, err := getFilterFromQuery(query.Filter, couponFilterFields...)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range filters {
+		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
+	}
+	// then do counting of all
+	db.Count(&count)
+
+	db = db.Offset(offset).Limit(queryInfo.Limit).
+		Order(fmt.Sprintf("coupons.%s %s", queryInfo.Sort, queryInfo.Direction)).
+		Find(&coupons)
+
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetAll error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("record not found")
+	}
+
+	pagingInfo := getPagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(coupons)
+	return &models.CouponsResponse{
+		Coupons:    coupons,
+		PagingInfo: &pagingInfo,
+	}, nil
+}
+
+// UpdateCouponById updates a coupon with a map so boolean and zero values can be set
+func (c *Coupon) UpdateCouponById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.Coupon{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateCouponById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// AddUsedCount moves the number of times a coupon has been used by count
+func (c *Coupon) AddUsedCount(ctx context.Context, id uuid.UUID, count int64) error {
+	db := c.repo.PostgresDb.WithContext(ctx).Model(&models.Coupon{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"used_count": gorm.Expr("used_count + ?", count),
+			"updated_at": time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddUsedCount error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	return nil
+}
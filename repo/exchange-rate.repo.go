@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ExchangeRate repo object
+type ExchangeRate struct {
+	repo *db.Database
+}
+
+// ExchangeRateRepo exposes exchange rate's methods to other packages
+type ExchangeRateRepo interface {
+	SetExchangeRate(ctx context.Context, rate *models.ExchangeRate) (*models.ExchangeRate, error)
+	GetExchangeRateByFields(ctx context.Context, fields map[string]interface{}) (*models.ExchangeRate, error)
+	GetAllExchangeRates(ctx context.Context, query *models.APIPagingDto) (*models.ExchangeRatesResponse, error)
+}
+
+// NewExchangeRateRepo instantiates the ExchangeRate Repo object
+func NewExchangeRateRepo(db *db.Database) ExchangeRateRepo {
+	rate := &ExchangeRate{
+		repo: db,
+	}
+	return ExchangeRateRepo(rate)
+}
+
+// SetExchangeRate stores the rate between two currencies, replacing the current one
+func (e *ExchangeRate) SetExchangeRate(ctx context.Context, rate *models.ExchangeRate) (*models.ExchangeRate, error) {
+	rate.CreatedAt = time.Now().UTC()
+	rate.UpdatedAt = time.Now().UTC()
+
+	db := e.repo.PostgresDb.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "base_currency"}, {Name: "quote_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(rate)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::SetExchangeRate error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return rate, nil
+}
+
+func (e *ExchangeRate) GetExchangeRateByFields(ctx context.Context, fields map[string]interface{}) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	db := e.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&rate)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetExchangeRateByFields error: %v, (%v)", "record not found", db.Error)
+		return &rate, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if rate.Id == uuid.Nil {
+		return nil, messages.ErrExchangeRateNotFound
+	}
+	return &rate, nil
+}
+
+// exchangeRateFilterFields are the exchange rate columns a query filter can use
+var exchangeRateFilterFields = []string{"base_currency", "quote_currency", "created_at", "updated_at"}
+
+func (e *ExchangeRate) GetAllExchangeRates(ctx context.Context, query *models.APIPagingDto) (*models.ExchangeRatesResponse, error) {
+	var rates []*models.ExchangeRate
+	var count int64
+	queryInfo, offset := getPaginationInfo(query)
+
+	db := e.repo.PostgresDb.WithContext(ctx).Model(&models.ExchangeRate{})
+	filters, err := getFilterFromQuery(query.Filter, exchangeRateFilterFields...)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range filters {
+		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
+	}
+	// then do counting of all
+	db.Count(&count)
+
+	db = db.Offset(offset).Limit(queryInfo.Limit).
+		Order(fmt.Sprintf("exchange_rates.%s %s", queryInfo.Sort, queryInfo.Direction)).
+		Find(&rates)
+
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetAll error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("record not found")
+	}
+
+	pagingInfo := getPagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(rates)
+	return &models.ExchangeRatesResponse{
+		ExchangeRates: rates,
+		PagingInfo:    &pagingInfo,
+	}, nil
+}
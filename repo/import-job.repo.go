@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ImportJob repo object
+type ImportJob struct {
+	repo *db.Database
+}
+
+// ImportJobRepo exposes import job's methods to other packages
+type ImportJobRepo interface {
+	CreateImportJob(ctx context.Context, job *models.ImportJob) (*models.ImportJob, error)
+	GetImportJobByFields(ctx context.Context, fields map[string]interface{}) (*models.ImportJob, error)
+	UpdateImportJobById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+}
+
+// NewImportJobRepo instantiates the ImportJob Repo object
+func NewImportJobRepo(db *db.Database) ImportJobRepo {
+	job := &ImportJob{
+		repo: db,
+	}
+	return ImportJobRepo(job)
+}
+
+// CreateImportJob stores a new import job
+func (i *ImportJob) CreateImportJob(ctx context.Context, job *models.ImportJob) (*models.ImportJob, error) {
+	job.CreatedAt = time.Now().UTC()
+	job.UpdatedAt = time.Now().UTC()
+
+	db := i.repo.PostgresDb.WithContext(ctx).Create(job)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateImportJob error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return job, nil
+}
+
+func (i *ImportJob) GetImportJobByFields(ctx context.Context, fields map[string]interface{}) (*models.ImportJob, error) {
+	var job models.ImportJob
+	db := i.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&job)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetImportJobByFields error: %v, (%v)", "record not found", db.Error)
+		return &job, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if job.Id == uuid.Nil {
+		return nil, messages.ErrImportJobNotFound
+	}
+	return &job, nil
+}
+
+// UpdateImportJobById updates an import job with a map so its counts can be set to zero
+func (i *ImportJob) UpdateImportJobById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := i.repo.PostgresDb.WithContext(ctx).Model(&models.ImportJob{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateImportJobById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// InventoryMovement repo object
+type InventoryMovement struct {
+	repo *db.Database
+}
+
+// InventoryMovementRepo exposes the inventory ledger to other packages, movements are only ever added
+type InventoryMovementRepo interface {
+	CreateInventoryMovement(ctx context.Context, movement *models.InventoryMovement) (*models.InventoryMovement, error)
+	GetInventoryMovements(ctx context.Context, query *models.APIPagingDto, fields map[string]interface{}) (*models.InventoryMovementsResponse, error)
+	GetLedgerQuantities(ctx context.Context, productId uuid.UUID) (map[uuid.UUID]int64, error)
+}
+
+// NewInventoryMovementRepo instantiates the InventoryMovement Repo object
+func NewInventoryMovementRepo(db *db.Database) InventoryMovementRepo {
+	movement := &InventoryMovement{
+		repo: db,
+	}
+	return InventoryMovementRepo(movement)
+}
+
+// CreateInventoryMovement adds a movement to the inventory ledger
+func (i *InventoryMovement) CreateInventoryMovement(ctx context.Context, movement *models.InventoryMovement) (*models.InventoryMovement, error) {
+	movement.CreatedAt = time.Now().UTC()
+
+	db := i.repo.PostgresDb.WithContext(ctx).Create(movement)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateInventoryMovement error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return movement, nil
+}
+
+// inventoryMovementFilterFields are the inventory movement columns a query filter can use
+var inventoryMovementFilterFields = []string{"type", "warehouse_id", "variant_id", "reference_id", "actor_id", "created_at"}
+
+// GetInventoryMovements gets a page of the inventory ledger, latest first unless sorted otherwise
+func (i *InventoryMovement) GetInventoryMovements(ctx context.Context, query *models.APIPagingDto, fields map[string]interface{}) (*models.InventoryMovementsResponse, error) {
+	var movements []*models.InventoryMovement
+	var count int64
+	queryInfo, offset := getPaginationInfo(query)
+
+	db := i.repo.PostgresDb.WithContext(ctx).Model(&models.InventoryMovement{}).Where(fields)
+	filters, err := getFilterFromQuery(query.Filter, inventoryMovementFilterFields...)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range filters {
+		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
+	}
+	// then do counting of all
+	db.Count(&count)
+
+	db = db.Offset(offset).Limit(queryInfo.Limit).
+		Order(fmt.Sprintf("inventory_movements.%s %s", queryInfo.Sort, queryInfo.Direction)).
+		Find(&movements)
+
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetInventoryMovements error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("record not found")
+	}
+
+	pagingInfo := getPagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(movements)
+	return &models.InventoryMovementsResponse{
+		Movements:  movements,
+		PagingInfo: &pagingInfo,
+	}, nil
+}
+
+// GetLedgerQuantities adds up the ledger of a product, by variant. The stock of the product itself is under uuid.Nil.
+func (i *InventoryMovement) GetLedgerQuantities(ctx context.Context, productId uuid.UUID) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		VariantId *uuid.UUID
+		Quantity  int64
+	}
+	db := i.repo.PostgresDb.WithContext(ctx).Model(&models.InventoryMovement{}).
+		Select("variant_id, sum(quantity) as quantity").
+		Where("product_id = ?", productId).
+		Group("variant_id").
+		Scan(&rows)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetLedgerQuantities error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+
+	quantities := map[uuid.UUID]int64{}
+	for _, row := range rows {
+		if row.VariantId == nil {
+			quantities[uuid.Nil] = row.Quantity
+			continue
+		}
+		quantities[*row.VariantId] = row.Quantity
+	}
+	return quantities, nil
+}
@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// OrderAllocation repo object
+type OrderAllocation struct {
+	repo *db.Database
+}
+
+// OrderAllocationRepo exposes order allocation's methods to other packages
+type OrderAllocationRepo interface {
+	CreateOrderAllocations(ctx context.Context, allocations []*models.OrderAllocation) error
+	GetOrderAllocationsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.OrderAllocation, error)
+}
+
+// NewOrderAllocationRepo instantiates the OrderAllocation Repo object
+func NewOrderAllocationRepo(db *db.Database) OrderAllocationRepo {
+	allocation := &OrderAllocation{
+		repo: db,
+	}
+	return OrderAllocationRepo(allocation)
+}
+
+// CreateOrderAllocations stores the warehouses order records are fulfilled from
+func (o *OrderAllocation) CreateOrderAllocations(ctx context.Context, allocations []*models.OrderAllocation) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+	for _, allocation := range allocations {
+		allocation.CreatedAt = time.Now().UTC()
+	}
+
+	db := o.repo.PostgresDb.WithContext(ctx).Create(allocations)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateOrderAllocations error: %v, (%v)", "", db.Error)
+		return errors.New("an error occurred")
+	}
+	return nil
+}
+
+// GetOrderAllocationsByFields gets allocations in the order they were made
+func (o *OrderAllocation) GetOrderAllocationsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.OrderAllocation, error) {
+	var allocations []*models.OrderAllocation
+	db := o.repo.PostgresDb.WithContext(ctx).Where(fields).Order("created_at asc").Find(&allocations)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetOrderAllocationsByFields error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return allocations, nil
+}
@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// OrderDiscount repo object
+type OrderDiscount struct {
+	repo *db.Database
+}
+
+// OrderDiscountRepo exposes order discount's methods to other packages
+type OrderDiscountRepo interface {
+	CreateOrderDiscount(ctx context.Context, discount *models.OrderDiscount) (*models.OrderDiscount, error)
+}
+
+// NewOrderDiscountRepo instantiates the OrderDiscount Repo object
+func NewOrderDiscountRepo(db *db.Database) OrderDiscountRepo {
+	discount := &OrderDiscount{
+		repo: db,
+	}
+	return OrderDiscountRepo(discount)
+}
+
+// CreateOrderDiscount stores a new order discount line
+func (o *OrderDiscount) CreateOrderDiscount(ctx context.Context, discount *models.OrderDiscount) (*models.OrderDiscount, error) {
+	discount.CreatedAt = time.Now().UTC()
+	discount.UpdatedAt = time.Now().UTC()
+
+	db := o.repo.PostgresDb.WithContext(ctx).Create(discount)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateOrderDiscount error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return discount, nil
+}
@@ -6,8 +6,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 
+	"e-commerce/common/messages"
 	"e-commerce/db"
 	"e-commerce/models"
 )
@@ -20,6 +23,11 @@ type OrderRecord struct {
 // OrderRepo exposes order's methods to other packages
 type OrderRecordRepo interface {
 	CreateOrderRecord(ctx context.Context, order *models.OrderRecord) (*models.OrderRecord, error)
+	AddRefundedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error
+	AddShippedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error
+	AddReturnedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error
+	AddRestockedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error
+	CountOrderRecords(ctx context.Context, fields map[string]interface{}) (int64, error)
 }
 
 // NewOrderRecordRepo instantiates the Order Repo object
@@ -46,3 +54,90 @@ func (o *OrderRecord) CreateOrderRecord(ctx context.Context, orderRecord *models
 	}
 	return orderRecord, nil
 }
+
+// AddRefundedQuantity moves the refunded quantity of an order record by quantity,
+// refusing to refund more than was ordered
+func (o *OrderRecord) AddRefundedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error {
+	db := o.repo.PostgresDb.WithContext(ctx).Model(&models.OrderRecord{}).
+		Where("id = ? AND refunded_quantity + ? BETWEEN 0 AND quantity", id, quantity).
+		UpdateColumns(map[string]interface{}{
+			"refunded_quantity": gorm.Expr("refunded_quantity + ?", quantity),
+			"updated_at":        time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddRefundedQuantity error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if db.RowsAffected == 0 {
+		return messages.ErrRefundQuantityExceeded
+	}
+	return nil
+}
+
+// AddShippedQuantity moves the shipped quantity of an order record by quantity,
+// refusing to ship more than is left after refunds
+func (o *OrderRecord) AddShippedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error {
+	db := o.repo.PostgresDb.WithContext(ctx).Model(&models.OrderRecord{}).
+		Where("id = ? AND shipped_quantity + ? BETWEEN 0 AND quantity - refunded_quantity", id, quantity).
+		UpdateColumns(map[string]interface{}{
+			"shipped_quantity": gorm.Expr("shipped_quantity + ?", quantity),
+			"updated_at":       time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddShippedQuantity error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if db.RowsAffected == 0 {
+		return messages.ErrShipmentQuantityExceeded
+	}
+	return nil
+}
+
+// AddReturnedQuantity moves the quantity of an order record asked to be returned by quantity,
+// refusing to return more than is left after refunds
+func (o *OrderRecord) AddReturnedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error {
+	db := o.repo.PostgresDb.WithContext(ctx).Model(&models.OrderRecord{}).
+		Where("id = ? AND returned_quantity + ? BETWEEN 0 AND quantity - refunded_quantity", id, quantity).
+		UpdateColumns(map[string]interface{}{
+			"returned_quantity": gorm.Expr("returned_quantity + ?", quantity),
+			"updated_at":        time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddReturnedQuantity error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if db.RowsAffected == 0 {
+		return messages.ErrReturnQuantityExceeded
+	}
+	return nil
+}
+
+// AddRestockedQuantity moves the quantity of an order record put back in stock by quantity,
+// refusing to restock more than was left unshipped
+func (o *OrderRecord) AddRestockedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error {
+	db := o.repo.PostgresDb.WithContext(ctx).Model(&models.OrderRecord{}).
+		Where("id = ? AND restocked_quantity + ? BETWEEN 0 AND quantity - shipped_quantity", id, quantity).
+		UpdateColumns(map[string]interface{}{
+			"restocked_quantity": gorm.Expr("restocked_quantity + ?", quantity),
+			"updated_at":         time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddRestockedQuantity error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if db.RowsAffected == 0 {
+		return messages.ErrRestockQuantityExceeded
+	}
+	return nil
+}
+
+// CountOrderRecords counts the order records with fields, e.g. those of a product
+func (o *OrderRecord) CountOrderRecords(ctx context.Context, fields map[string]interface{}) (int64, error) {
+	var count int64
+	db := o.repo.PostgresDb.WithContext(ctx).Model(&models.OrderRecord{}).Where(fields).Count(&count)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CountOrderRecords error: %v, (%v)", "record not found", db.Error)
+		return 0, errors.New("something went wrong")
+	}
+	return count, nil
+}
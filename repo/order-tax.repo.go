@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// OrderTax repo object
+type OrderTax struct {
+	repo *db.Database
+}
+
+// OrderTaxRepo exposes order tax's methods to other packages
+type OrderTaxRepo interface {
+	CreateOrderTax(ctx context.Context, tax *models.OrderTax) (*models.OrderTax, error)
+	GetTaxReport(ctx context.Context, query *models.TaxReportDto) ([]*models.TaxReportRow, error)
+}
+
+// NewOrderTaxRepo instantiates the OrderTax Repo object
+func NewOrderTaxRepo(db *db.Database) OrderTaxRepo {
+	tax := &OrderTax{
+		repo: db,
+	}
+	return OrderTaxRepo(tax)
+}
+
+// CreateOrderTax stores a new order tax line
+func (o *OrderTax) CreateOrderTax(ctx context.Context, tax *models.OrderTax) (*models.OrderTax, error) {
+	tax.CreatedAt = time.Now().UTC()
+	tax.UpdatedAt = time.Now().UTC()
+
+	db := o.repo.PostgresDb.WithContext(ctx).Create(tax)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateOrderTax error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return tax, nil
+}
+
+// GetTaxReport sums the tax lines of paid orders placed between two dates, by period, rate and currency
+func (o *OrderTax) GetTaxReport(ctx context.Context, query *models.TaxReportDto) ([]*models.TaxReportRow, error) {
+	var rows []struct {
+		Period        time.Time
+		TaxRateId     uuid.UUID
+		Name          string
+		Rate          string
+		Inclusive     bool
+		Currency      string
+		Orders        int64
+		TaxableAmount int64
+		Amount        int64
+	}
+
+	db := o.repo.PostgresDb.WithContext(ctx).Table("order_taxes").
+		Select(`date_trunc(?, orders.created_at) AS period, order_taxes.tax_rate_id, order_taxes.name,
+			order_taxes.rate, order_taxes.inclusive, order_taxes.currency,
+			count(DISTINCT order_taxes.order_id) AS orders,
+			sum(order_taxes.taxable_amount) AS taxable_amount, sum(order_taxes.amount) AS amount`, string(query.Period)).
+		Joins("JOIN orders ON orders.id = order_taxes.order_id").
+		Where("orders.status IN ?", models.PAID_ORDER_STATUSES).
+		Where("orders.created_at >= ? AND orders.created_at < ?", query.From, query.To.AddDate(0, 0, 1))
+	if query.Currency != "" {
+		db = db.Where("order_taxes.currency = ?", string(query.Currency))
+	}
+	db = db.Group("1, order_taxes.tax_rate_id, order_taxes.name, order_taxes.rate, order_taxes.inclusive, order_taxes.currency").
+		Order("period asc").Order("order_taxes.name asc").
+		Scan(&rows)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetTaxReport error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+
+	report := []*models.TaxReportRow{}
+	for _, row := range rows {
+		currency := models.Currency(row.Currency)
+		report = append(report, &models.TaxReportRow{
+			Period:        row.Period,
+			TaxRateId:     row.TaxRateId,
+			Name:          row.Name,
+			Rate:          row.Rate,
+			Inclusive:     row.Inclusive,
+			Orders:        row.Orders,
+			TaxableAmount: models.NewMoney(row.TaxableAmount, currency),
+			Amount:        models.NewMoney(row.Amount, currency),
+		})
+	}
+	return report, nil
+}
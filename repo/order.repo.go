@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
 
 	"e-commerce/common/messages"
 	"e-commerce/db"
@@ -24,6 +25,7 @@ type Order struct {
 type OrderRepo interface {
 	CreateOrder(ctx context.Context, order *models.Order) (*models.Order, error)
 	GetOrderByFields(ctx context.Context, fields map[string]interface{}) (*models.Order, error)
+	GetOrderForUpdate(ctx context.Context, fields map[string]interface{}) (*models.Order, error)
 	UpdateOrderById(ctx context.Context, id uuid.UUID, order *models.Order) error
 	GetAllOrders(ctx context.Context, query *models.APIPagingDto, fields map[string]interface{}) (*models.OrdersResponse, error)
 }
@@ -54,7 +56,7 @@ func (o *Order) CreateOrder(ctx context.Context, order *models.Order) (*models.O
 
 func (o *Order) GetOrderByFields(ctx context.Context, fields map[string]interface{}) (*models.Order, error) {
 	var order models.Order
-	db := o.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("OrderRecords").Find(&order)
+	db := o.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("OrderRecords.TaxLines").Preload("OrderRecords.Allocations").Preload("Discounts").Find(&order)
 	if db.Error != nil {
 		log.Err(db.Error).Msgf("Basic::GetOrderByFields error: %v, (%v)", "record not found", db.Error)
 		return &order, errors.New("something went wrong")
@@ -67,6 +69,22 @@ func (o *Order) GetOrderByFields(ctx context.Context, fields map[string]interfac
 	return &order, nil
 }
 
+// GetOrderForUpdate gets an order with its records and locks its row until the surrounding transaction ends
+func (o *Order) GetOrderForUpdate(ctx context.Context, fields map[string]interface{}) (*models.Order, error) {
+	var order models.Order
+	db := o.repo.PostgresDb.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(fields).Preload("OrderRecords.Allocations").Find(&order)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetOrderForUpdate error: %v, (%v)", "record not found", db.Error)
+		return &order, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if order.Id == uuid.Nil {
+		return nil, messages.ErrOrderNotFound
+	}
+	return &order, nil
+}
+
 func (o *Order) UpdateOrderById(ctx context.Context, id uuid.UUID, order *models.Order) error {
 	order.UpdatedAt = time.Now().UTC()
 	db := o.repo.PostgresDb.WithContext(ctx).Model(&models.Order{
@@ -80,13 +98,19 @@ func (o *Order) UpdateOrderById(ctx context.Context, id uuid.UUID, order *models
 	return nil
 }
 
+// orderFilterFields are the order columns a query filter can use
+var orderFilterFields = []string{"tracking_code", "status", "refund_status", "currency", "coupon_code", "country", "region", "created_at"}
+
 func (o *Order) GetAllOrders(ctx context.Context, query *models.APIPagingDto, fields map[string]interface{}) (*models.OrdersResponse, error) {
 	var orders []*models.Order
 	var count, queryCount int64
 	queryInfo, offset := getPaginationInfo(query)
 
-	db := o.repo.PostgresDb.WithContext(ctx).Model(&models.Order{}).Preload("OrderRecords").Where(fields)
-	filters := getFilterFromQuery(query.Filter)
+	db := o.repo.PostgresDb.WithContext(ctx).Model(&models.Order{}).Preload("OrderRecords.TaxLines").Preload("OrderRecords.Allocations").Preload("Discounts").Where(fields)
+	filters, err := getFilterFromQuery(query.Filter, orderFilterFields...)
+	if err != nil {
+		return nil, err
+	}
 	for _, filter := range filters {
 		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
 	}
@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// PaymentEvent repo object
+type PaymentEvent struct {
+	repo *db.Database
+}
+
+// PaymentEventRepo exposes payment event's methods to other packages
+type PaymentEventRepo interface {
+	CreatePaymentEvent(ctx context.Context, event *models.PaymentEvent) (bool, error)
+	UpdatePaymentEventById(ctx context.Context, id uuid.UUID, event *models.PaymentEvent) error
+}
+
+// NewPaymentEventRepo instantiates the PaymentEvent Repo object
+func NewPaymentEventRepo(db *db.Database) PaymentEventRepo {
+	event := &PaymentEvent{
+		repo: db,
+	}
+	return PaymentEventRepo(event)
+}
+
+// CreatePaymentEvent stores a new payment event, returning false if the provider already sent it
+func (p *PaymentEvent) CreatePaymentEvent(ctx context.Context, event *models.PaymentEvent) (bool, error) {
+	event.CreatedAt = time.Now().UTC()
+	event.UpdatedAt = time.Now().UTC()
+
+	db := p.repo.PostgresDb.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "provider"}, {Name: "event_id"}},
+		DoNothing: true,
+	}).Create(event)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreatePaymentEvent error: %v, (%v)", "", db.Error)
+		return false, errors.New("an error occurred")
+	}
+	return db.RowsAffected > 0, nil
+}
+
+func (p *PaymentEvent) UpdatePaymentEventById(ctx context.Context, id uuid.UUID, event *models.PaymentEvent) error {
+	event.UpdatedAt = time.Now().UTC()
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.PaymentEvent{
+		Id: id,
+	}).UpdateColumns(event)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdatePaymentEventById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
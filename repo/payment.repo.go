@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// Payment repo object
+type Payment struct {
+	repo *db.Database
+}
+
+// PaymentRepo exposes payment's methods to other packages
+type PaymentRepo interface {
+	CreatePayment(ctx context.Context, payment *models.Payment) (*models.Payment, error)
+	GetPaymentByFields(ctx context.Context, fields map[string]interface{}) (*models.Payment, error)
+	GetPaymentForUpdate(ctx context.Context, fields map[string]interface{}) (*models.Payment, error)
+	UpdatePaymentById(ctx context.Context, id uuid.UUID, payment *models.Payment) error
+	AddRefundedAmount(ctx context.Context, id uuid.UUID, amount int64) error
+}
+
+// NewPaymentRepo instantiates the Payment Repo object
+func NewPaymentRepo(db *db.Database) PaymentRepo {
+	payment := &Payment{
+		repo: db,
+	}
+	return PaymentRepo(payment)
+}
+
+// CreatePayment stores a new payment
+func (p *Payment) CreatePayment(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	payment.CreatedAt = time.Now().UTC()
+	payment.UpdatedAt = time.Now().UTC()
+
+	db := p.repo.PostgresDb.WithContext(ctx).Create(payment)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreatePayment error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, errors.New("an error occurred")
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return payment, nil
+}
+
+func (p *Payment) GetPaymentByFields(ctx context.Context, fields map[string]interface{}) (*models.Payment, error) {
+	var payment models.Payment
+	db := p.repo.PostgresDb.WithContext(ctx).Where(fields).Order("created_at desc").Find(&payment)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetPaymentByFields error: %v, (%v)", "record not found", db.Error)
+		return &payment, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if payment.Id == uuid.Nil {
+		return nil, messages.ErrPaymentNotFound
+	}
+	return &payment, nil
+}
+
+// GetPaymentForUpdate gets a payment and locks its row until the surrounding transaction ends
+func (p *Payment) GetPaymentForUpdate(ctx context.Context, fields map[string]interface{}) (*models.Payment, error) {
+	var payment models.Payment
+	db := p.repo.PostgresDb.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(fields).Find(&payment)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetPaymentForUpdate error: %v, (%v)", "record not found", db.Error)
+		return &payment, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if payment.Id == uuid.Nil {
+		return nil, messages.ErrPaymentNotFound
+	}
+	return &payment, nil
+}
+
+func (p *Payment) UpdatePaymentById(ctx context.Context, id uuid.UUID, payment *models.Payment) error {
+	payment.UpdatedAt = time.Now().UTC()
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Payment{
+		Id: id,
+	}).UpdateColumns(payment)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdatePaymentById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// AddRefundedAmount moves the refunded amount of a payment by amount,
+// refusing to refund more than was paid
+func (p *Payment) AddRefundedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Payment{}).
+		Where("id = ? AND refunded_amount + ? BETWEEN 0 AND amount", id, amount).
+		UpdateColumns(map[string]interface{}{
+			"refunded_amount": gorm.Expr("refunded_amount + ?", amount),
+			"updated_at":      time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddRefundedAmount error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if db.RowsAffected == 0 {
+		return messages.ErrRefundExceedsPayment
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ProductCategory repo object
+type ProductCategory struct {
+	repo *db.Database
+}
+
+// ProductCategoryRepo exposes product category's methods to other packages
+type ProductCategoryRepo interface {
+	ReplaceProductCategories(ctx context.Context, productId uuid.UUID, categoryIds []uuid.UUID) error
+	CountCategoryProducts(ctx context.Context, categoryId uuid.UUID) (int64, error)
+}
+
+// NewProductCategoryRepo instantiates the ProductCategory Repo object
+func NewProductCategoryRepo(db *db.Database) ProductCategoryRepo {
+	productCategory := &ProductCategory{
+		repo: db,
+	}
+	return ProductCategoryRepo(productCategory)
+}
+
+// ReplaceProductCategories swaps all the categories of a product for the given ones
+func (p *ProductCategory) ReplaceProductCategories(ctx context.Context, productId uuid.UUID, categoryIds []uuid.UUID) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Where("product_id = ?", productId).Delete(&models.ProductCategory{})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::ReplaceProductCategories error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if len(categoryIds) == 0 {
+		return nil
+	}
+
+	productCategories := []*models.ProductCategory{}
+	for _, categoryId := range categoryIds {
+		productCategories = append(productCategories, &models.ProductCategory{
+			ProductId:  productId,
+			CategoryId: categoryId,
+			CreatedAt:  time.Now().UTC(),
+		})
+	}
+	db = p.repo.PostgresDb.WithContext(ctx).Create(productCategories)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::ReplaceProductCategories error: %v, (%v)", "", db.Error)
+		return errors.New("update not successful")
+	}
+	return nil
+}
+
+// CountCategoryProducts counts the products assigned directly to a category
+func (p *ProductCategory) CountCategoryProducts(ctx context.Context, categoryId uuid.UUID) (int64, error) {
+	var count int64
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.ProductCategory{}).Where("category_id = ?", categoryId).Count(&count)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CountCategoryProducts error: %v, (%v)", "record not found", db.Error)
+		return 0, errors.New("something went wrong")
+	}
+	return count, nil
+}
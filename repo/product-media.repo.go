@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ProductMedia repo object
+type ProductMedia struct {
+	repo *db.Database
+}
+
+// ProductMediaRepo exposes product media's methods to other packages
+type ProductMediaRepo interface {
+	CreateProductMedia(ctx context.Context, media *models.ProductMedia) (*models.ProductMedia, error)
+	GetProductMediaByFields(ctx context.Context, fields map[string]interface{}) (*models.ProductMedia, error)
+	GetMediaOfProduct(ctx context.Context, productId uuid.UUID) ([]*models.ProductMedia, error)
+	UpdateProductMediaById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	UnsetPrimaryMedia(ctx context.Context, productId uuid.UUID) error
+	DeleteProductMedia(ctx context.Context, id uuid.UUID) error
+}
+
+// NewProductMediaRepo instantiates the ProductMedia Repo object
+func NewProductMediaRepo(db *db.Database) ProductMediaRepo {
+	media := &ProductMedia{
+		repo: db,
+	}
+	return ProductMediaRepo(media)
+}
+
+// CreateProductMedia stores a new product image
+func (p *ProductMedia) CreateProductMedia(ctx context.Context, media *models.ProductMedia) (*models.ProductMedia, error) {
+	media.CreatedAt = time.Now().UTC()
+	media.UpdatedAt = time.Now().UTC()
+
+	db := p.repo.PostgresDb.WithContext(ctx).Create(media)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateProductMedia error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return media, nil
+}
+
+func (p *ProductMedia) GetProductMediaByFields(ctx context.Context, fields map[string]interface{}) (*models.ProductMedia, error) {
+	var media models.ProductMedia
+	db := p.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&media)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetProductMediaByFields error: %v, (%v)", "record not found", db.Error)
+		return &media, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if media.Id == uuid.Nil {
+		return nil, messages.ErrMediaNotFound
+	}
+	return &media, nil
+}
+
+// GetMediaOfProduct gets the images of a product in order
+func (p *ProductMedia) GetMediaOfProduct(ctx context.Context, productId uuid.UUID) ([]*models.ProductMedia, error) {
+	var media []*models.ProductMedia
+	db := p.repo.PostgresDb.WithContext(ctx).Where("product_id = ?", productId).Scopes(orderByPosition).Find(&media)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetMediaOfProduct error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return media, nil
+}
+
+// UpdateProductMediaById updates a product image with a map so it can stop being the primary one
+func (p *ProductMedia) UpdateProductMediaById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.ProductMedia{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateProductMediaById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// UnsetPrimaryMedia makes none of the images of a product the primary one
+func (p *ProductMedia) UnsetPrimaryMedia(ctx context.Context, productId uuid.UUID) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.ProductMedia{}).
+		Where("product_id = ? AND is_primary", productId).
+		UpdateColumns(map[string]interface{}{"is_primary": false, "updated_at": time.Now().UTC()})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UnsetPrimaryMedia error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	return nil
+}
+
+// DeleteProductMedia deletes a product image, its files are left to the caller
+func (p *ProductMedia) DeleteProductMedia(ctx context.Context, id uuid.UUID) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Delete(&models.ProductMedia{Id: id})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteProductMedia error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ProductOption repo object
+type ProductOption struct {
+	repo *db.Database
+}
+
+// ProductOptionRepo exposes product option's methods to other packages
+type ProductOptionRepo interface {
+	ReplaceProductOptions(ctx context.Context, productId uuid.UUID, options []*models.ProductOption) error
+}
+
+// NewProductOptionRepo instantiates the ProductOption Repo object
+func NewProductOptionRepo(db *db.Database) ProductOptionRepo {
+	option := &ProductOption{
+		repo: db,
+	}
+	return ProductOptionRepo(option)
+}
+
+// ReplaceProductOptions swaps all the options of a product for the given ones
+func (p *ProductOption) ReplaceProductOptions(ctx context.Context, productId uuid.UUID, options []*models.ProductOption) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Where("product_id = ?", productId).Delete(&models.ProductOption{})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::ReplaceProductOptions error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if len(options) == 0 {
+		return nil
+	}
+
+	for _, option := range options {
+		option.ProductId = productId
+		option.CreatedAt = time.Now().UTC()
+		option.UpdatedAt = time.Now().UTC()
+	}
+	db = p.repo.PostgresDb.WithContext(ctx).Create(options)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::ReplaceProductOptions error: %v, (%v)", "", db.Error)
+		return errors.New("update not successful")
+	}
+	return nil
+}
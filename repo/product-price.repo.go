@@ -0,0 +1,55 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ProductPrice repo object
+type ProductPrice struct {
+	repo *db.Database
+}
+
+// ProductPriceRepo exposes product price's methods to other packages
+type ProductPriceRepo interface {
+	ReplaceProductPrices(ctx context.Context, productId uuid.UUID, prices []*models.ProductPrice) error
+}
+
+// NewProductPriceRepo instantiates the ProductPrice Repo object
+func NewProductPriceRepo(db *db.Database) ProductPriceRepo {
+	price := &ProductPrice{
+		repo: db,
+	}
+	return ProductPriceRepo(price)
+}
+
+// ReplaceProductPrices swaps all the prices of a product in other currencies for the given ones
+func (p *ProductPrice) ReplaceProductPrices(ctx context.Context, productId uuid.UUID, prices []*models.ProductPrice) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Where("product_id = ?", productId).Delete(&models.ProductPrice{})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::ReplaceProductPrices error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if len(prices) == 0 {
+		return nil
+	}
+
+	for _, price := range prices {
+		price.ProductId = productId
+		price.CreatedAt = time.Now().UTC()
+		price.UpdatedAt = time.Now().UTC()
+	}
+	db = p.repo.PostgresDb.WithContext(ctx).Create(prices)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::ReplaceProductPrices error: %v, (%v)", "", db.Error)
+		return errors.New("update not successful")
+	}
+	return nil
+}
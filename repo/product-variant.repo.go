@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ProductVariant repo object
+type ProductVariant struct {
+	repo *db.Database
+}
+
+// ProductVariantRepo exposes product variant's methods to other packages
+type ProductVariantRepo interface {
+	CreateProductVariants(ctx context.Context, variants []*models.ProductVariant) error
+	GetProductVariantByFields(ctx context.Context, fields map[string]interface{}) (*models.ProductVariant, error)
+	GetProductVariantForUpdate(ctx context.Context, id uuid.UUID) (*models.ProductVariant, error)
+	UpdateProductVariantById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteProductVariant(ctx context.Context, id uuid.UUID) error
+	AddVariantQuantity(ctx context.Context, id uuid.UUID, quantity int64) (int64, error)
+	AddVariantReservedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error
+}
+
+// NewProductVariantRepo instantiates the ProductVariant Repo object
+func NewProductVariantRepo(db *db.Database) ProductVariantRepo {
+	variant := &ProductVariant{
+		repo: db,
+	}
+	return ProductVariantRepo(variant)
+}
+
+// CreateProductVariants stores new variants
+func (p *ProductVariant) CreateProductVariants(ctx context.Context, variants []*models.ProductVariant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+	for _, variant := range variants {
+		variant.CreatedAt = time.Now().UTC()
+		variant.UpdatedAt = time.Now().UTC()
+	}
+
+	db := p.repo.PostgresDb.WithContext(ctx).Create(variants)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateProductVariants error: %v, (%v)", "", db.Error)
+		return errors.New("an error occurred")
+	}
+	return nil
+}
+
+func (p *ProductVariant) GetProductVariantByFields(ctx context.Context, fields map[string]interface{}) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+	db := p.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&variant)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetProductVariantByFields error: %v, (%v)", "record not found", db.Error)
+		return &variant, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if variant.Id == uuid.Nil {
+		return nil, messages.ErrProductVariantNotFound
+	}
+	return &variant, nil
+}
+
+// GetProductVariantForUpdate gets a variant and locks it until the end of the transaction
+func (p *ProductVariant) GetProductVariantForUpdate(ctx context.Context, id uuid.UUID) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+	db := p.repo.PostgresDb.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).Find(&variant)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetProductVariantForUpdate error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if variant.Id == uuid.Nil {
+		return nil, messages.ErrProductVariantNotFound
+	}
+	return &variant, nil
+}
+
+// UpdateProductVariantById updates a variant with a map so its price can go back to the product's
+func (p *ProductVariant) UpdateProductVariantById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.ProductVariant{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateProductVariantById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// DeleteProductVariant deletes a variant, order records keep its SKU
+func (p *ProductVariant) DeleteProductVariant(ctx context.Context, id uuid.UUID) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Delete(&models.ProductVariant{Id: id})
+	// orders keep the variants they were for, the inventory ledger keeps their ids
+	if db.Error != nil && strings.Contains(db.Error.Error(), "violates foreign key constraint") {
+		return messages.ErrProductVariantInUse
+	}
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteProductVariant error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
+
+// AddVariantQuantity moves the available quantity of a variant by quantity and returns the quantity it ends up with
+func (p *ProductVariant) AddVariantQuantity(ctx context.Context, id uuid.UUID, quantity int64) (int64, error) {
+	var variant models.ProductVariant
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&variant).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "available_quantity"}}}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"available_quantity": gorm.Expr("available_quantity + ?", quantity),
+			"updated_at":         time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddVariantQuantity error: %v, (%v)", "update not successful", db.Error)
+		return 0, errors.New("update not successful")
+	}
+	// means no record was found
+	if db.RowsAffected == 0 {
+		return 0, messages.ErrProductVariantNotFound
+	}
+	return variant.AvailableQuantity, nil
+}
+
+// AddVariantReservedQuantity moves the quantity of a variant held for pending orders by quantity
+func (p *ProductVariant) AddVariantReservedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.ProductVariant{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"reserved_quantity": gorm.Expr("reserved_quantity + ?", quantity),
+			"updated_at":        time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddVariantReservedQuantity error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	// means no record was found
+	if db.RowsAffected == 0 {
+		return messages.ErrProductVariantNotFound
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +15,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Product repo object
@@ -24,9 +28,18 @@ type Product struct {
 type ProductRepo interface {
 	CreateProduct(ctx context.Context, product *models.Product) (*models.Product, error)
 	GetProductByFields(ctx context.Context, fields map[string]interface{}) (*models.Product, error)
-	GetAllProducts(ctx context.Context, query *models.APIPagingDto) (*models.ProductsResponse, error)
+	GetAllProducts(ctx context.Context, query *models.APIPagingDto, filter *models.ProductFilter) (*models.ProductsResponse, error)
 	UpdateProductById(ctx context.Context, id uuid.UUID, product *models.Product) error
+	UpdateProductColumns(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 	DeleteProduct(ctx context.Context, product *models.Product) error
+	AddProductQuantity(ctx context.Context, id uuid.UUID, quantity int64) (int64, error)
+	AddProductReservedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error
+	GetProductForUpdate(ctx context.Context, id uuid.UUID) (*models.Product, error)
+	SearchProducts(ctx context.Context, query *models.APIPagingDto, search string, filter *models.ProductFilter) (*models.ProductSearchResponse, error)
+	AutocompleteProducts(ctx context.Context, search string, limit int) ([]*models.ProductSuggestion, error)
+	GetProductFacets(ctx context.Context, query *models.APIPagingDto, filter *models.ProductFilter) (*models.ProductFacets, error)
+	RemoveProductAttribute(ctx context.Context, categoryIds []uuid.UUID, key string) error
+	GetProductsAfter(ctx context.Context, afterId uuid.UUID, limit int) ([]*models.Product, error)
 }
 
 // NewProductsRepo instantiates the User Repo object
@@ -56,7 +69,8 @@ func (p *Product) CreateProduct(ctx context.Context, product *models.Product) (*
 
 func (p *Product) GetProductByFields(ctx context.Context, fields map[string]interface{}) (*models.Product, error) {
 	var product models.Product
-	db := p.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&product)
+	db := p.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("Prices").Preload("Categories").
+		Preload("Options", orderByPosition).Preload("Variants", orderByPosition).Preload("Media", orderByPosition).Preload("Stock").Find(&product)
 	if db.Error != nil {
 		log.Err(db.Error).Msgf("Basic::GetProductByFields error: %v, (%v)", "record not found", db.Error)
 		return &product, errors.New("something went wrong")
@@ -82,8 +96,114 @@ func (p *Product) UpdateProductById(ctx context.Context, id uuid.UUID, product *
 	return nil
 }
 
+// UpdateProductColumns updates a product with a map so zero values can be set
+func (p *Product) UpdateProductColumns(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateProductColumns error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// GetProductForUpdate gets a product and locks it until the end of the transaction
+func (p *Product) GetProductForUpdate(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	var product models.Product
+	db := p.repo.PostgresDb.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).Find(&product)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetProductForUpdate error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if product.Id == uuid.Nil {
+		return nil, messages.ErrProductNotFound
+	}
+	return &product, nil
+}
+
+// RemoveProductAttribute drops an attribute from the products of categories
+func (p *Product) RemoveProductAttribute(ctx context.Context, categoryIds []uuid.UUID, key string) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).
+		Where("id IN (?)", p.repo.PostgresDb.Model(&models.ProductCategory{}).Select("product_id").Where("category_id IN ?", categoryIds)).
+		Where("jsonb_exists(attributes, ?)", key).
+		UpdateColumns(map[string]interface{}{"attributes": gorm.Expr("attributes - ?", key), "updated_at": time.Now().UTC()})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::RemoveProductAttribute error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	return nil
+}
+
+// GetProductsAfter gets the products with an id after afterId in id order, so the whole catalog can be
+// read in batches while it changes
+func (p *Product) GetProductsAfter(ctx context.Context, afterId uuid.UUID, limit int) ([]*models.Product, error) {
+	var products []*models.Product
+	db := p.repo.PostgresDb.WithContext(ctx).Preload("Categories").Preload("Variants", orderByPosition).
+		Where("id > ?", afterId).Order("id ASC").Limit(limit).Find(&products)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetProductsAfter error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return products, nil
+}
+
+// orderByPosition orders preloaded options, variants and media the way they are listed on the product
+func orderByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position asc").Order("created_at asc")
+}
+
+// AddProductQuantity moves the available quantity of a product by quantity and returns the quantity it ends up with
+func (p *Product) AddProductQuantity(ctx context.Context, id uuid.UUID, quantity int64) (int64, error) {
+	var product models.Product
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&product).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "available_quantity"}}}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"available_quantity": gorm.Expr("available_quantity + ?", quantity),
+			"updated_at":         time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddProductQuantity error: %v, (%v)", "update not successful", db.Error)
+		return 0, errors.New("update not successful")
+	}
+	// means no record was found
+	if db.RowsAffected == 0 {
+		return 0, messages.ErrProductNotFound
+	}
+	return product.AvailableQuantity, nil
+}
+
+// AddProductReservedQuantity moves the quantity of a product held for pending orders by quantity
+func (p *Product) AddProductReservedQuantity(ctx context.Context, id uuid.UUID, quantity int64) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"reserved_quantity": gorm.Expr("reserved_quantity + ?", quantity),
+			"updated_at":        time.Now().UTC(),
+		})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddProductReservedQuantity error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	// means no record was found
+	if db.RowsAffected == 0 {
+		return messages.ErrProductNotFound
+	}
+	return nil
+}
+
 func (p *Product) DeleteProduct(ctx context.Context, product *models.Product) error {
 	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).Delete(product)
+	// orders keep the products they were for, the inventory ledger keeps their ids
+	if db.Error != nil && strings.Contains(db.Error.Error(), "violates foreign key constraint") {
+		return messages.ErrProductInUse
+	}
 	if db.Error != nil {
 		log.Err(db.Error).Msgf("Basic::UpdateSavingsGoalByID error: %v, (%v)", "delete not successful", db.Error)
 		return errors.New("delete not successful")
@@ -91,16 +211,17 @@ func (p *Product) DeleteProduct(ctx context.Context, product *models.Product) er
 	return nil
 }
 
-func (p *Product) GetAllProducts(ctx context.Context, query *models.APIPagingDto) (*models.ProductsResponse, error) {
+func (p *Product) GetAllProducts(ctx context.Context, query *models.APIPagingDto, filter *models.ProductFilter) (*models.ProductsResponse, error) {
 	var products []*models.Product
 	var count, queryCount int64
 	queryInfo, offset := getPaginationInfo(query)
-
-	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{})
-	filters := getFilterFromQuery(query.Filter)
-	for _, filter := range filters {
-		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
+	if _, err := getFilterFromQuery(query.Filter, productFilterFields...); err != nil {
+		return nil, err
 	}
+
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).Preload("Prices").Preload("Categories").
+		Preload("Options", orderByPosition).Preload("Variants", orderByPosition).Preload("Media", orderByPosition).Preload("Stock")
+	db = db.Scopes(p.filterProducts(query, filter, ""))
 	// then do counting of all
 	db.Count(&count)
 
@@ -122,3 +243,412 @@ func (p *Product) GetAllProducts(ctx context.Context, query *models.APIPagingDto
 	}, nil
 
 }
+
+const (
+	// SEARCH_CONFIG is the text search configuration product names and descriptions are indexed with
+	SEARCH_CONFIG                 = "english"
+	NAME_HIGHLIGHT_OPTIONS        = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	DESCRIPTION_HIGHLIGHT_OPTIONS = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15"
+)
+
+// SearchProducts finds products whose name or description has the words searched, or whose name is close
+// to them, best matches first. Words in the name weigh more than words in the description.
+func (p *Product) SearchProducts(ctx context.Context, query *models.APIPagingDto, search string, filter *models.ProductFilter) (*models.ProductSearchResponse, error) {
+	var rows []struct {
+		Id                   uuid.UUID
+		Rank                 float64
+		NameHighlight        string
+		DescriptionHighlight string
+	}
+	var count int64
+	queryInfo, offset := getPaginationInfo(query)
+
+	matches := func(db *gorm.DB) *gorm.DB {
+		return db.Where(`(products.search_vector @@ websearch_to_tsquery(?::regconfig, ?) OR ? <% products.name)`,
+			SEARCH_CONFIG, search, search).Scopes(p.filterProducts(nil, filter, ""))
+	}
+
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).Scopes(matches).Count(&count)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::SearchProducts error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+
+	db = p.repo.PostgresDb.WithContext(ctx).Table("products").
+		Select(`products.id,
+			ts_rank_cd(products.search_vector, websearch_to_tsquery(?::regconfig, ?)) + word_similarity(?, products.name) AS rank,
+			ts_headline(?::regconfig, products.name, websearch_to_tsquery(?::regconfig, ?), ?) AS name_highlight,
+			ts_headline(?::regconfig, products.description, websearch_to_tsquery(?::regconfig, ?), ?) AS description_highlight`,
+			SEARCH_CONFIG, search, search,
+			SEARCH_CONFIG, SEARCH_CONFIG, search, NAME_HIGHLIGHT_OPTIONS,
+			SEARCH_CONFIG, SEARCH_CONFIG, search, DESCRIPTION_HIGHLIGHT_OPTIONS).
+		Scopes(matches).
+		Order("rank desc").Order("products.created_at desc").
+		Offset(offset).Limit(queryInfo.Limit).
+		Scan(&rows)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::SearchProducts error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+
+	ids := []uuid.UUID{}
+	for _, row := range rows {
+		ids = append(ids, row.Id)
+	}
+	var products []*models.Product
+	db = p.repo.PostgresDb.WithContext(ctx).Preload("Prices").Preload("Categories").
+		Preload("Options", orderByPosition).Preload("Variants", orderByPosition).Preload("Media", orderByPosition).Preload("Stock").
+		Where("id IN ?", ids).Find(&products)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::SearchProducts error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	productsById := map[uuid.UUID]*models.Product{}
+	for _, product := range products {
+		productsById[product.Id] = product
+	}
+
+	// keep the order of the ranking, a product deleted in between is left out
+	results := []*models.ProductSearchResult{}
+	for _, row := range rows {
+		product, ok := productsById[row.Id]
+		if !ok {
+			continue
+		}
+		results = append(results, &models.ProductSearchResult{
+			Product:              product,
+			Rank:                 row.Rank,
+			NameHighlight:        row.NameHighlight,
+			DescriptionHighlight: row.DescriptionHighlight,
+		})
+	}
+
+	pagingInfo := getPagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(results)
+	return &models.ProductSearchResponse{
+		Results:    results,
+		PagingInfo: &pagingInfo,
+	}, nil
+}
+
+// AutocompleteProducts suggests products for what has been typed so far, names starting with it first,
+// then names with a word close to it
+func (p *Product) AutocompleteProducts(ctx context.Context, search string, limit int) ([]*models.ProductSuggestion, error) {
+	suggestions := []*models.ProductSuggestion{}
+	prefix := escapeLike(search) + "%"
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).
+		Select("id, slug, name").
+		Where("name ILIKE ? OR ? <% name", prefix, search).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "name ILIKE ? DESC, word_similarity(?, name) DESC, name ASC",
+			Vars: []interface{}{prefix, search},
+		}}).
+		Limit(limit).
+		Scan(&suggestions)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AutocompleteProducts error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return suggestions, nil
+}
+
+const (
+	FACET_CATEGORY = "category"
+	FACET_STATUS   = "status"
+	FACET_BRAND    = "brand"
+	FACET_PRICE    = "price"
+	FACET_OPTION   = "option:"
+	// FACET_ATTRIBUTE is also the prefix of attribute fields in the listing filter, as in attributes.fabric|eq|wool
+	FACET_ATTRIBUTE         = "attributes."
+	ATTRIBUTE_FILTER_PREFIX = FACET_ATTRIBUTE
+
+	// PRICE_FACET_BUCKETS is about how many price ranges products of a currency are split into
+	PRICE_FACET_BUCKETS = 5
+)
+
+// productFilterFields are the product columns a query filter can use, and attributes by key
+var productFilterFields = []string{"name", "slug", "brand", "price", "discount", "currency", "tax_class", "status", "created_at", ATTRIBUTE_FILTER_PREFIX}
+
+// filterProducts narrows products down to the query filter and the product filter, all but the except facet
+func (p *Product) filterProducts(query *models.APIPagingDto, filter *models.ProductFilter, except string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if query != nil {
+			filters, err := getFilterFromQuery(query.Filter, productFilterFields...)
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+			for _, where := range filters {
+				if key, ok := strings.CutPrefix(where.field, ATTRIBUTE_FILTER_PREFIX); ok {
+					if except != FACET_ATTRIBUTE+key {
+						db = whereAttribute(db, key, where)
+					}
+					continue
+				}
+				db = db.Where(fmt.Sprintf("products.%s %s ?", where.field, where.condition), where.value)
+			}
+		}
+		if filter == nil {
+			return db
+		}
+		if filter.CategoryIds != nil && except != FACET_CATEGORY {
+			db = db.Where("products.id IN (?)", p.repo.PostgresDb.Model(&models.ProductCategory{}).
+				Select("product_id").Where("category_id IN ?", filter.CategoryIds))
+		}
+		if len(filter.Statuses) > 0 && except != FACET_STATUS {
+			db = db.Where("products.status IN ?", filter.Statuses)
+		}
+		if len(filter.Brands) > 0 && except != FACET_BRAND {
+			db = db.Where("products.brand IN ?", filter.Brands)
+		}
+		if filter.Currency != "" {
+			db = db.Where("products.currency = ?", filter.Currency)
+		}
+		if except != FACET_PRICE {
+			if filter.MinPrice != nil {
+				db = db.Where("products.price - products.discount >= ?", *filter.MinPrice)
+			}
+			if filter.MaxPrice != nil {
+				db = db.Where("products.price - products.discount <= ?", *filter.MaxPrice)
+			}
+		}
+		for _, name := range sortedKeys(filter.Options) {
+			if except == FACET_OPTION+name {
+				continue
+			}
+			db = db.Where("products.id IN (?)", p.repo.PostgresDb.Model(&models.ProductVariant{}).
+				Select("product_id").Where("options->>? IN ?", name, filter.Options[name]))
+		}
+		return db
+	}
+}
+
+// filteredProductIds is a subquery of the ids of the products found by a filter, all but the except facet
+func (p *Product) filteredProductIds(query *models.APIPagingDto, filter *models.ProductFilter, except string) *gorm.DB {
+	return p.repo.PostgresDb.Model(&models.Product{}).Select("products.id").Scopes(p.filterProducts(query, filter, except))
+}
+
+// GetProductFacets counts the products found by a filter for each category, status, brand, price range and option value
+func (p *Product) GetProductFacets(ctx context.Context, query *models.APIPagingDto, filter *models.ProductFilter) (*models.ProductFacets, error) {
+	if _, err := getFilterFromQuery(query.Filter, productFilterFields...); err != nil {
+		return nil, err
+	}
+	facets := &models.ProductFacets{
+		Categories: []*models.CategoryFacet{},
+		Statuses:   []*models.FacetValue{},
+		Brands:     []*models.FacetValue{},
+		Prices:     []*models.PriceFacet{},
+		Options:    []*models.OptionFacet{},
+		Attributes: []*models.OptionFacet{},
+	}
+
+	// products count towards their categories and every category above them
+	db := p.repo.PostgresDb.WithContext(ctx).Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id AS category_id, id AS ancestor_id FROM categories
+			UNION ALL
+			SELECT ancestors.category_id, categories.parent_id FROM ancestors
+			JOIN categories ON categories.id = ancestors.ancestor_id
+			WHERE categories.parent_id IS NOT NULL
+		)
+		SELECT categories.id, categories.parent_id, categories.name, categories.slug,
+			count(DISTINCT product_categories.product_id) AS count
+		FROM product_categories
+		JOIN ancestors ON ancestors.category_id = product_categories.category_id
+		JOIN categories ON categories.id = ancestors.ancestor_id
+		WHERE product_categories.product_id IN (?)
+		GROUP BY categories.id
+		ORDER BY categories.position ASC, categories.name ASC`, p.filteredProductIds(query, filter, FACET_CATEGORY)).
+		Scan(&facets.Categories)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetProductFacets error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+
+	for column, values := range map[string]*[]*models.FacetValue{FACET_STATUS: &facets.Statuses, FACET_BRAND: &facets.Brands} {
+		db = p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).
+			Select(fmt.Sprintf("products.%s AS value, count(*) AS count", column)).
+			Scopes(p.filterProducts(query, filter, column)).
+			Where(fmt.Sprintf("products.%s <> ''", column)).
+			Group(fmt.Sprintf("products.%s", column)).Order("count DESC").Order("value ASC").
+			Scan(values)
+		if db.Error != nil {
+			log.Err(db.Error).Msgf("Basic::GetProductFacets error: %v, (%v)", "record not found", db.Error)
+			return nil, errors.New("something went wrong")
+		}
+	}
+
+	var err error
+	if facets.Prices, err = p.getPriceFacets(ctx, query, filter); err != nil {
+		return nil, err
+	}
+	if facets.Options, err = p.getOptionFacets(ctx, query, filter); err != nil {
+		return nil, err
+	}
+	if facets.Attributes, err = p.getAttributeFacets(ctx, query, filter); err != nil {
+		return nil, err
+	}
+	return facets, nil
+}
+
+// getPriceFacets splits the products of each currency into ranges of a round width by what they sell for
+func (p *Product) getPriceFacets(ctx context.Context, query *models.APIPagingDto, filter *models.ProductFilter) ([]*models.PriceFacet, error) {
+	var bounds []struct {
+		Currency string
+		Min      int64
+		Max      int64
+	}
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).
+		Select("products.currency, min(products.price - products.discount) AS min, max(products.price - products.discount) AS max").
+		Scopes(p.filterProducts(query, filter, FACET_PRICE)).
+		Group("products.currency").Order("products.currency ASC").
+		Scan(&bounds)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetProductFacets error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+
+	facets := []*models.PriceFacet{}
+	for _, bound := range bounds {
+		step := priceStep((bound.Max - bound.Min + 1) / PRICE_FACET_BUCKETS)
+		var buckets []struct {
+			Bucket int64
+			Count  int64
+		}
+		db = p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).
+			Select("(products.price - products.discount) / ? AS bucket, count(*) AS count", step).
+			Scopes(p.filterProducts(query, filter, FACET_PRICE)).
+			Where("products.currency = ?", bound.Currency).
+			Group("bucket").Order("bucket ASC").
+			Scan(&buckets)
+		if db.Error != nil {
+			log.Err(db.Error).Msgf("Basic::GetProductFacets error: %v, (%v)", "record not found", db.Error)
+			return nil, errors.New("something went wrong")
+		}
+		for _, bucket := range buckets {
+			facets = append(facets, &models.PriceFacet{
+				Currency: bound.Currency,
+				From:     bucket.Bucket * step,
+				To:       (bucket.Bucket+1)*step - 1,
+				Count:    bucket.Count,
+			})
+		}
+	}
+	return facets, nil
+}
+
+// getOptionFacets counts the products with a variant of each option value
+func (p *Product) getOptionFacets(ctx context.Context, query *models.APIPagingDto, filter *models.ProductFilter) ([]*models.OptionFacet, error) {
+	filtered := []string{}
+	if filter != nil {
+		filtered = sortedKeys(filter.Options)
+	}
+	return p.getValueFacets(ctx, FACET_OPTION, filtered, "options.key", func(except string) *gorm.DB {
+		return p.repo.PostgresDb.WithContext(ctx).Table("product_variants").
+			Select("options.key AS name, options.value AS value, count(DISTINCT product_variants.product_id) AS count").
+			Joins("CROSS JOIN LATERAL jsonb_each_text(product_variants.options) AS options").
+			Where("product_variants.product_id IN (?)", p.filteredProductIds(query, filter, except)).
+			Group("options.key, options.value")
+	})
+}
+
+// getAttributeFacets counts the products with each value of their text, enum and boolean attributes
+func (p *Product) getAttributeFacets(ctx context.Context, query *models.APIPagingDto, filter *models.ProductFilter) ([]*models.OptionFacet, error) {
+	filters, err := getFilterFromQuery(query.Filter, productFilterFields...)
+	if err != nil {
+		return nil, err
+	}
+	filtered := []string{}
+	for _, where := range filters {
+		if key, ok := strings.CutPrefix(where.field, ATTRIBUTE_FILTER_PREFIX); ok {
+			filtered = append(filtered, key)
+		}
+	}
+	return p.getValueFacets(ctx, FACET_ATTRIBUTE, filtered, "attributes.key", func(except string) *gorm.DB {
+		return p.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).
+			Select("attributes.key AS name, attributes.value #>> '{}' AS value, count(*) AS count").
+			Joins("CROSS JOIN LATERAL jsonb_each(products.attributes) AS attributes").
+			Where("jsonb_typeof(attributes.value) IN ('string', 'boolean')").
+			Scopes(p.filterProducts(query, filter, except)).
+			Group("attributes.key, attributes.value")
+	})
+}
+
+// getValueFacets counts products by the name and value pairs selected by values, names being in nameColumn. The
+// values of a name being filtered on are counted without that name's filter, prefix+name being the facet left out.
+func (p *Product) getValueFacets(ctx context.Context, prefix string, filtered []string, nameColumn string, values func(except string) *gorm.DB) ([]*models.OptionFacet, error) {
+	var rows []struct {
+		Name  string
+		Value string
+		Count int64
+	}
+	db := values("")
+	if len(filtered) > 0 {
+		db = db.Where(fmt.Sprintf("%s NOT IN ?", nameColumn), filtered)
+	}
+	db = db.Scan(&rows)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetProductFacets error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	for _, name := range filtered {
+		var nameRows []struct {
+			Name  string
+			Value string
+			Count int64
+		}
+		db = values(prefix+name).Where(fmt.Sprintf("%s = ?", nameColumn), name).Scan(&nameRows)
+		if db.Error != nil {
+			log.Err(db.Error).Msgf("Basic::GetProductFacets error: %v, (%v)", "record not found", db.Error)
+			return nil, errors.New("something went wrong")
+		}
+		rows = append(rows, nameRows...)
+	}
+
+	facets := []*models.OptionFacet{}
+	byName := map[string]*models.OptionFacet{}
+	for _, row := range rows {
+		facet, ok := byName[row.Name]
+		if !ok {
+			facet = &models.OptionFacet{Name: row.Name, Values: []*models.FacetValue{}}
+			byName[row.Name] = facet
+			facets = append(facets, facet)
+		}
+		facet.Values = append(facet.Values, &models.FacetValue{Value: row.Value, Count: row.Count})
+	}
+	sort.Slice(facets, func(i, j int) bool { return facets[i].Name < facets[j].Name })
+	for _, facet := range facets {
+		sort.Slice(facet.Values, func(i, j int) bool { return facet.Values[i].Value < facet.Values[j].Value })
+	}
+	return facets, nil
+}
+
+// whereAttribute narrows products down by the value of an attribute. Numbers are compared as numbers by gt
+// and lt, every other condition compares the value as text.
+func whereAttribute(db *gorm.DB, key string, where *WhereObj) *gorm.DB {
+	switch where.condition {
+	case "=", "<>", "like", "in":
+		return db.Where(fmt.Sprintf("products.attributes->>? %s ?", where.condition), key, where.value)
+	case ">", "<":
+		number, err := strconv.ParseFloat(fmt.Sprint(where.value), 64)
+		if err != nil {
+			return db.Where("false")
+		}
+		return db.Where(fmt.Sprintf(`CASE WHEN jsonb_typeof(products.attributes->?) = 'number'
+			THEN (products.attributes->>?)::numeric END %s ?`, where.condition), key, key, number)
+	}
+	return db.Where("false")
+}
+
+// priceStep rounds the width of a price range up to 1, 2 or 5 times a power of ten
+func priceStep(width int64) int64 {
+	step := int64(1)
+	for {
+		for _, multiple := range []int64{1, 2, 5} {
+			if step*multiple >= width {
+				return step * multiple
+			}
+		}
+		step *= 10
+	}
+}
@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// Promotion repo object
+type Promotion struct {
+	repo *db.Database
+}
+
+// PromotionRepo exposes promotion's methods to other packages
+type PromotionRepo interface {
+	CreatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error)
+	GetPromotionByFields(ctx context.Context, fields map[string]interface{}) (*models.Promotion, error)
+	GetAllPromotions(ctx context.Context, query *models.APIPagingDto) (*models.PromotionsResponse, error)
+	GetActivePromotions(ctx context.Context, at time.Time) ([]*models.Promotion, error)
+	UpdatePromotionById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeletePromotion(ctx context.Context, id uuid.UUID) error
+}
+
+// NewPromotionRepo instantiates the Promotion Repo object
+func NewPromotionRepo(db *db.Database) PromotionRepo {
+	promotion := &Promotion{
+		repo: db,
+	}
+	return PromotionRepo(promotion)
+}
+
+// CreatePromotion stores a new promotion
+func (p *Promotion) CreatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	promotion.CreatedAt = time.Now().UTC()
+	promotion.UpdatedAt = time.Now().UTC()
+
+	db := p.repo.PostgresDb.WithContext(ctx).Create(promotion)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreatePromotion error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return promotion, nil
+}
+
+func (p *Promotion) GetPromotionByFields(ctx context.Context, fields map[string]interface{}) (*models.Promotion, error) {
+	var promotion models.Promotion
+	db := p.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&promotion)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetPromotionByFields error: %v, (%v)", "record not found", db.Error)
+		return &promotion, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if promotion.Id == uuid.Nil {
+		return nil, messages.ErrPromotionNotFound
+	}
+	return &promotion, nil
+}
+
+// promotionFilterFields are the promotion columns a query filter can use
+var promotionFilterFields = []string{"name", "type", "currency", "priority", "exclusive", "status", "starts_at", "ends_at", "created_at"}
+
+func (p *Promotion) GetAllPromotions(ctx context.Context, query *models.APIPagingDto) (*models.PromotionsResponse, error) {
+	var promotions []*models.Promotion
+	var count int64
+	queryInfo, offset := getPaginationInfo(query)
+
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Promotion{})
+	filters, err := getFilterFromQuery(query.Filter, promotionFilterFields...)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range filters {
+		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
+	}
+	// then do counting of all
+	db.Count(&count)
+
+	db = db.Offset(offset).Limit(queryInfo.Limit).
+		Order(fmt.Sprintf("promotions.%s %s", queryInfo.Sort, queryInfo.Direction)).
+		Find(&promotions)
+
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetAll error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("record not found")
+	}
+
+	pagingInfo := getPagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(promotions)
+	return &models.PromotionsResponse{
+		Promotions: promotions,
+		PagingInfo: &pagingInfo,
+	}, nil
+}
+
+// GetActivePromotions gets the promotions running at a given time, highest priority first
+func (p *Promotion) GetActivePromotions(ctx context.Context, at time.Time) ([]*models.Promotion, error) {
+	var promotions []*models.Promotion
+	db := p.repo.PostgresDb.WithContext(ctx).
+		Where("status = ?", string(models.PROMOTION_ACTIVE)).
+		Where("starts_at IS NULL OR starts_at <= ?", at).
+		Where("ends_at IS NULL OR ends_at >= ?", at).
+		Order("priority desc").Order("created_at asc").
+		Find(&promotions)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetActivePromotions error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return promotions, nil
+}
+
+// UpdatePromotionById updates a promotion with a map so boolean and zero values can be set
+func (p *Promotion) UpdatePromotionById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := p.repo.PostgresDb.WithContext(ctx).Model(&models.Promotion{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdatePromotionById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+func (p *Promotion) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	db := p.repo.PostgresDb.WithContext(ctx).Delete(&models.Promotion{Id: id})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeletePromotion error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// Refund repo object
+type Refund struct {
+	repo *db.Database
+}
+
+// RefundRepo exposes refund's methods to other packages
+type RefundRepo interface {
+	CreateRefund(ctx context.Context, refund *models.Refund) (*models.Refund, error)
+	GetRefundByFields(ctx context.Context, fields map[string]interface{}) (*models.Refund, error)
+	GetRefundsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.Refund, error)
+	GetRefundForUpdate(ctx context.Context, fields map[string]interface{}) (*models.Refund, error)
+	UpdateRefundById(ctx context.Context, id uuid.UUID, refund *models.Refund) error
+}
+
+// NewRefundRepo instantiates the Refund Repo object
+func NewRefundRepo(db *db.Database) RefundRepo {
+	refund := &Refund{
+		repo: db,
+	}
+	return RefundRepo(refund)
+}
+
+// CreateRefund stores a new refund along with its items
+func (r *Refund) CreateRefund(ctx context.Context, refund *models.Refund) (*models.Refund, error) {
+	refund.CreatedAt = time.Now().UTC()
+	refund.UpdatedAt = time.Now().UTC()
+	for _, item := range refund.RefundItems {
+		item.CreatedAt = refund.CreatedAt
+		item.UpdatedAt = refund.UpdatedAt
+	}
+
+	db := r.repo.PostgresDb.WithContext(ctx).Create(refund)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateRefund error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, errors.New("an error occurred")
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return refund, nil
+}
+
+func (r *Refund) GetRefundByFields(ctx context.Context, fields map[string]interface{}) (*models.Refund, error) {
+	var refund models.Refund
+	db := r.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("RefundItems").Find(&refund)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetRefundByFields error: %v, (%v)", "record not found", db.Error)
+		return &refund, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if refund.Id == uuid.Nil {
+		return nil, messages.ErrRefundNotFound
+	}
+	return &refund, nil
+}
+
+func (r *Refund) GetRefundsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.Refund, error) {
+	var refunds []*models.Refund
+	db := r.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("RefundItems").Order("created_at desc").Find(&refunds)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetRefundsByFields error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return refunds, nil
+}
+
+// GetRefundForUpdate gets a refund with its items and locks its row until the surrounding transaction ends
+func (r *Refund) GetRefundForUpdate(ctx context.Context, fields map[string]interface{}) (*models.Refund, error) {
+	var refund models.Refund
+	db := r.repo.PostgresDb.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(fields).Preload("RefundItems").Find(&refund)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetRefundForUpdate error: %v, (%v)", "record not found", db.Error)
+		return &refund, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if refund.Id == uuid.Nil {
+		return nil, messages.ErrRefundNotFound
+	}
+	return &refund, nil
+}
+
+func (r *Refund) UpdateRefundById(ctx context.Context, id uuid.UUID, refund *models.Refund) error {
+	refund.UpdatedAt = time.Now().UTC()
+	db := r.repo.PostgresDb.WithContext(ctx).Model(&models.Refund{
+		Id: id,
+	}).UpdateColumns(refund)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateRefundById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
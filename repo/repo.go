@@ -1,8 +1,11 @@
 package repo
 
 import (
+	"slices"
+	"sort"
 	"strings"
 
+	"e-commerce/common/messages"
 	"e-commerce/db"
 	"e-commerce/models"
 )
@@ -88,33 +91,59 @@ func genWhere(filtered WhereObj) *WhereObj {
 	return &filtered
 }
 
-func getFilterFromQuery(filterValue string) []*WhereObj {
-	filtered := parseFilterEntries(filterValue)
+// filterConditions are the conditions a query filter can use
+var filterConditions = []string{"eq", "like", "in", "ne", "gt", "lt"}
+
+// getFilterFromQuery parses a query filter of space separated field|condition|value entries. Only the
+// given fields can be filtered on; a field ending with a dot allows any key after it.
+func getFilterFromQuery(filterValue string, fields ...string) ([]*WhereObj, error) {
+	filtered, err := parseFilterEntries(filterValue, fields)
+	if err != nil {
+		return nil, err
+	}
 	for i := 0; i < len(filtered); i++ {
 		present := filtered[i]
 		filtered[i] = genWhere(*present)
 	}
-	return filtered
+	return filtered, nil
 }
 
-func parseFilterEntries(filter string) []*WhereObj {
-
-	if filter == "" {
-		return nil
-	}
-	splitFilter := strings.Split(filter, " ")
+func parseFilterEntries(filter string, fields []string) ([]*WhereObj, error) {
 	var allWhereObj []*WhereObj
-	for i := 0; i < len(splitFilter); i++ {
-		data := strings.Split(splitFilter[i], "|")
-		var obj WhereObj
-		if len(data) < 2 {
-			return nil
+	for _, entry := range strings.Fields(filter) {
+		data := strings.SplitN(entry, "|", 3)
+		if len(data) < 3 || !slices.Contains(filterConditions, data[1]) || !isFilterField(data[0], fields) {
+			return nil, messages.ErrInvalidFilter
 		}
-		obj.field = data[0]
-		obj.condition = data[1]
-		obj.value = data[2]
-		allWhereObj = append(allWhereObj, &obj)
+		allWhereObj = append(allWhereObj, &WhereObj{field: data[0], condition: data[1], value: data[2]})
 	}
+	return allWhereObj, nil
+}
 
-	return allWhereObj
+// isFilterField checks that a field is one of fields, or a key under one of them
+func isFilterField(field string, fields []string) bool {
+	for _, allowed := range fields {
+		if !strings.HasSuffix(allowed, ".") && field == allowed {
+			return true
+		}
+		if key, ok := strings.CutPrefix(field, allowed); ok && strings.HasSuffix(allowed, ".") && key != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeLike escapes the wildcards of a value matched with like so they are taken literally
+func escapeLike(value string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(value)
+}
+
+// sortedKeys gets the keys of a map in order, so queries built from it are always the same
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,52 @@
+package repo
+
+import (
+	"reflect"
+	"testing"
+
+	"e-commerce/common/messages"
+)
+
+func TestGetFilterFromQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   []WhereObj
+		err    error
+	}{
+		{name: "empty", filter: ""},
+		{name: "eq", filter: "status|eq|active", want: []WhereObj{{field: "status", condition: "=", value: "active"}}},
+		{name: "like", filter: "name|like|shoe", want: []WhereObj{{field: "name", condition: "like", value: "%shoe%"}}},
+		{name: "in", filter: "brand|in|acme,globex", want: []WhereObj{{field: "brand", condition: "in", value: []string{"acme", "globex"}}}},
+		{
+			name:   "several entries",
+			filter: "price|gt|100  price|lt|500",
+			want:   []WhereObj{{field: "price", condition: ">", value: "100"}, {field: "price", condition: "<", value: "500"}},
+		},
+		{name: "keys under a prefix", filter: "attributes.color|ne|red", want: []WhereObj{{field: "attributes.color", condition: "<>", value: "red"}}},
+		{name: "value with a pipe", filter: "name|eq|a|b", want: []WhereObj{{field: "name", condition: "=", value: "a|b"}}},
+		{name: "missing value", filter: "a|b", err: messages.ErrInvalidFilter},
+		{name: "missing condition", filter: "status", err: messages.ErrInvalidFilter},
+		{name: "unknown condition", filter: "status|= 1 OR 1 =|1", err: messages.ErrInvalidFilter},
+		{name: "unknown field", filter: "password|eq|x", err: messages.ErrInvalidFilter},
+		{name: "sql in the field", filter: "status=status--|eq|x", err: messages.ErrInvalidFilter},
+		{name: "prefix without a key", filter: "attributes.|eq|x", err: messages.ErrInvalidFilter},
+		{name: "one bad entry", filter: "status|eq|active reserved_quantity|gt|0", err: messages.ErrInvalidFilter},
+	}
+	fields := []string{"name", "brand", "price", "status", "attributes."}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := getFilterFromQuery(tt.filter, fields...)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			got := []WhereObj{}
+			for _, filter := range filters {
+				got = append(got, *filter)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("filters = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ReturnRequest repo object
+type ReturnRequest struct {
+	repo *db.Database
+}
+
+// ReturnRequestRepo exposes return request's methods to other packages
+type ReturnRequestRepo interface {
+	CreateReturnRequest(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error)
+	GetReturnRequestByFields(ctx context.Context, fields map[string]interface{}) (*models.ReturnRequest, error)
+	GetReturnRequestsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.ReturnRequest, error)
+	GetAllReturnRequests(ctx context.Context, query *models.APIPagingDto) (*models.ReturnRequestsResponse, error)
+	UpdateReturnRequestById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	MoveReturnRequest(ctx context.Context, id uuid.UUID, from string, updates map[string]interface{}) error
+	UpdateReturnItemById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+}
+
+// NewReturnRequestRepo instantiates the ReturnRequest Repo object
+func NewReturnRequestRepo(db *db.Database) ReturnRequestRepo {
+	returnRequest := &ReturnRequest{
+		repo: db,
+	}
+	return ReturnRequestRepo(returnRequest)
+}
+
+// CreateReturnRequest stores a new return request along with its items
+func (r *ReturnRequest) CreateReturnRequest(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	returnRequest.CreatedAt = time.Now().UTC()
+	returnRequest.UpdatedAt = time.Now().UTC()
+	for _, item := range returnRequest.Items {
+		item.CreatedAt = returnRequest.CreatedAt
+		item.UpdatedAt = returnRequest.UpdatedAt
+	}
+
+	db := r.repo.PostgresDb.WithContext(ctx).Create(returnRequest)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateReturnRequest error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, errors.New("an error occurred")
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return returnRequest, nil
+}
+
+func (r *ReturnRequest) GetReturnRequestByFields(ctx context.Context, fields map[string]interface{}) (*models.ReturnRequest, error) {
+	var returnRequest models.ReturnRequest
+	db := r.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("Items").Find(&returnRequest)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetReturnRequestByFields error: %v, (%v)", "record not found", db.Error)
+		return &returnRequest, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if returnRequest.Id == uuid.Nil {
+		return nil, messages.ErrReturnNotFound
+	}
+	return &returnRequest, nil
+}
+
+func (r *ReturnRequest) GetReturnRequestsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.ReturnRequest, error) {
+	var returnRequests []*models.ReturnRequest
+	db := r.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("Items").Order("created_at desc").Find(&returnRequests)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetReturnRequestsByFields error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return returnRequests, nil
+}
+
+// returnRequestFilterFields are the return request columns a query filter can use
+var returnRequestFilterFields = []string{"order_id", "user_id", "status", "restocked", "created_at"}
+
+func (r *ReturnRequest) GetAllReturnRequests(ctx context.Context, query *models.APIPagingDto) (*models.ReturnRequestsResponse, error) {
+	var returnRequests []*models.ReturnRequest
+	var count int64
+	queryInfo, offset := getPaginationInfo(query)
+
+	db := r.repo.PostgresDb.WithContext(ctx).Model(&models.ReturnRequest{}).Preload("Items")
+	filters, err := getFilterFromQuery(query.Filter, returnRequestFilterFields...)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range filters {
+		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
+	}
+	// then do counting of all
+	db.Count(&count)
+
+	db = db.Offset(offset).Limit(queryInfo.Limit).
+		Order(fmt.Sprintf("return_requests.%s %s", queryInfo.Sort, queryInfo.Direction)).
+		Find(&returnRequests)
+
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetAll error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("record not found")
+	}
+
+	pagingInfo := getPagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(returnRequests)
+	return &models.ReturnRequestsResponse{
+		ReturnRequests: returnRequests,
+		PagingInfo:     &pagingInfo,
+	}, nil
+}
+
+// UpdateReturnRequestById updates a return request with a map so boolean and zero values can be set
+func (r *ReturnRequest) UpdateReturnRequestById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := r.repo.PostgresDb.WithContext(ctx).Model(&models.ReturnRequest{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateReturnRequestById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// MoveReturnRequest updates a return request that is still in the from status, so two reviews
+// of the same return cannot both go through
+func (r *ReturnRequest) MoveReturnRequest(ctx context.Context, id uuid.UUID, from string, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := r.repo.PostgresDb.WithContext(ctx).Model(&models.ReturnRequest{}).
+		Where("id = ? AND status = ?", id, from).
+		UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::MoveReturnRequest error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if db.RowsAffected == 0 {
+		return messages.ErrInvalidReturnStatus
+	}
+	return nil
+}
+
+// UpdateReturnItemById updates an item of a return request with a map
+func (r *ReturnRequest) UpdateReturnItemById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := r.repo.PostgresDb.WithContext(ctx).Model(&models.ReturnItem{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateReturnItemById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
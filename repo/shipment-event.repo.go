@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm/clause"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ShipmentEvent repo object
+type ShipmentEvent struct {
+	repo *db.Database
+}
+
+// ShipmentEventRepo exposes shipment event's methods to other packages
+type ShipmentEventRepo interface {
+	CreateShipmentEvent(ctx context.Context, event *models.ShipmentEvent) (bool, error)
+}
+
+// NewShipmentEventRepo instantiates the ShipmentEvent Repo object
+func NewShipmentEventRepo(db *db.Database) ShipmentEventRepo {
+	event := &ShipmentEvent{
+		repo: db,
+	}
+	return ShipmentEventRepo(event)
+}
+
+// CreateShipmentEvent stores a new tracking event, returning false if it was already recorded
+func (s *ShipmentEvent) CreateShipmentEvent(ctx context.Context, event *models.ShipmentEvent) (bool, error) {
+	event.CreatedAt = time.Now().UTC()
+
+	db := s.repo.PostgresDb.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "shipment_id"}, {Name: "status"}, {Name: "occurred_at"}},
+		DoNothing: true,
+	}).Create(event)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateShipmentEvent error: %v, (%v)", "", db.Error)
+		return false, errors.New("an error occurred")
+	}
+	return db.RowsAffected > 0, nil
+}
@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// Shipment repo object
+type Shipment struct {
+	repo *db.Database
+}
+
+// ShipmentRepo exposes shipment's methods to other packages
+type ShipmentRepo interface {
+	CreateShipment(ctx context.Context, shipment *models.Shipment) (*models.Shipment, error)
+	GetShipmentByFields(ctx context.Context, fields map[string]interface{}) (*models.Shipment, error)
+	GetShipmentsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.Shipment, error)
+	UpdateShipmentById(ctx context.Context, id uuid.UUID, shipment *models.Shipment) error
+}
+
+// NewShipmentRepo instantiates the Shipment Repo object
+func NewShipmentRepo(db *db.Database) ShipmentRepo {
+	shipment := &Shipment{
+		repo: db,
+	}
+	return ShipmentRepo(shipment)
+}
+
+// CreateShipment stores a new shipment along with its items
+func (s *Shipment) CreateShipment(ctx context.Context, shipment *models.Shipment) (*models.Shipment, error) {
+	shipment.CreatedAt = time.Now().UTC()
+	shipment.UpdatedAt = time.Now().UTC()
+	for _, item := range shipment.Items {
+		item.CreatedAt = shipment.CreatedAt
+		item.UpdatedAt = shipment.UpdatedAt
+	}
+
+	db := s.repo.PostgresDb.WithContext(ctx).Create(shipment)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateShipment error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, errors.New("an error occurred")
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return shipment, nil
+}
+
+func (s *Shipment) GetShipmentByFields(ctx context.Context, fields map[string]interface{}) (*models.Shipment, error) {
+	var shipment models.Shipment
+	db := s.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("Items").Preload("Events", orderEvents).Find(&shipment)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetShipmentByFields error: %v, (%v)", "record not found", db.Error)
+		return &shipment, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if shipment.Id == uuid.Nil {
+		return nil, messages.ErrShipmentNotFound
+	}
+	return &shipment, nil
+}
+
+func (s *Shipment) GetShipmentsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.Shipment, error) {
+	var shipments []*models.Shipment
+	db := s.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("Items").Preload("Events", orderEvents).Order("created_at asc").Find(&shipments)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetShipmentsByFields error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return shipments, nil
+}
+
+func (s *Shipment) UpdateShipmentById(ctx context.Context, id uuid.UUID, shipment *models.Shipment) error {
+	shipment.UpdatedAt = time.Now().UTC()
+	db := s.repo.PostgresDb.WithContext(ctx).Model(&models.Shipment{
+		Id: id,
+	}).UpdateColumns(shipment)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateShipmentById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// orderEvents loads the tracking events of a shipment oldest first
+func orderEvents(db *gorm.DB) *gorm.DB {
+	return db.Order("occurred_at asc")
+}
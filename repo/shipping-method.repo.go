@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ShippingMethod repo object
+type ShippingMethod struct {
+	repo *db.Database
+}
+
+// ShippingMethodRepo exposes shipping method's methods to other packages
+type ShippingMethodRepo interface {
+	CreateShippingMethod(ctx context.Context, method *models.ShippingMethod) (*models.ShippingMethod, error)
+	GetShippingMethodByFields(ctx context.Context, fields map[string]interface{}) (*models.ShippingMethod, error)
+	UpdateShippingMethodById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteShippingMethod(ctx context.Context, id uuid.UUID) error
+}
+
+// NewShippingMethodRepo instantiates the ShippingMethod Repo object
+func NewShippingMethodRepo(db *db.Database) ShippingMethodRepo {
+	method := &ShippingMethod{
+		repo: db,
+	}
+	return ShippingMethodRepo(method)
+}
+
+// CreateShippingMethod stores a new shipping method
+func (s *ShippingMethod) CreateShippingMethod(ctx context.Context, method *models.ShippingMethod) (*models.ShippingMethod, error) {
+	method.CreatedAt = time.Now().UTC()
+	method.UpdatedAt = time.Now().UTC()
+
+	db := s.repo.PostgresDb.WithContext(ctx).Create(method)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateShippingMethod error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return method, nil
+}
+
+func (s *ShippingMethod) GetShippingMethodByFields(ctx context.Context, fields map[string]interface{}) (*models.ShippingMethod, error) {
+	var method models.ShippingMethod
+	db := s.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&method)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetShippingMethodByFields error: %v, (%v)", "record not found", db.Error)
+		return &method, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if method.Id == uuid.Nil {
+		return nil, messages.ErrShippingMethodNotFound
+	}
+	return &method, nil
+}
+
+// UpdateShippingMethodById updates a shipping method with a map so zero values can be set
+func (s *ShippingMethod) UpdateShippingMethodById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := s.repo.PostgresDb.WithContext(ctx).Model(&models.ShippingMethod{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateShippingMethodById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+func (s *ShippingMethod) DeleteShippingMethod(ctx context.Context, id uuid.UUID) error {
+	db := s.repo.PostgresDb.WithContext(ctx).Delete(&models.ShippingMethod{Id: id})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteShippingMethod error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// ShippingZone repo object
+type ShippingZone struct {
+	repo *db.Database
+}
+
+// ShippingZoneRepo exposes shipping zone's methods to other packages
+type ShippingZoneRepo interface {
+	CreateShippingZone(ctx context.Context, zone *models.ShippingZone) (*models.ShippingZone, error)
+	GetShippingZoneByFields(ctx context.Context, fields map[string]interface{}) (*models.ShippingZone, error)
+	GetAllShippingZones(ctx context.Context, query *models.APIPagingDto) (*models.ShippingZonesResponse, error)
+	GetActiveShippingZones(ctx context.Context, country string) ([]*models.ShippingZone, error)
+	UpdateShippingZoneById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteShippingZone(ctx context.Context, id uuid.UUID) error
+}
+
+// NewShippingZoneRepo instantiates the ShippingZone Repo object
+func NewShippingZoneRepo(db *db.Database) ShippingZoneRepo {
+	zone := &ShippingZone{
+		repo: db,
+	}
+	return ShippingZoneRepo(zone)
+}
+
+// CreateShippingZone stores a new shipping zone
+func (s *ShippingZone) CreateShippingZone(ctx context.Context, zone *models.ShippingZone) (*models.ShippingZone, error) {
+	zone.CreatedAt = time.Now().UTC()
+	zone.UpdatedAt = time.Now().UTC()
+
+	db := s.repo.PostgresDb.WithContext(ctx).Create(zone)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateShippingZone error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return zone, nil
+}
+
+func (s *ShippingZone) GetShippingZoneByFields(ctx context.Context, fields map[string]interface{}) (*models.ShippingZone, error) {
+	var zone models.ShippingZone
+	db := s.repo.PostgresDb.WithContext(ctx).Where(fields).Preload("Methods").Find(&zone)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetShippingZoneByFields error: %v, (%v)", "record not found", db.Error)
+		return &zone, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if zone.Id == uuid.Nil {
+		return nil, messages.ErrShippingZoneNotFound
+	}
+	return &zone, nil
+}
+
+// shippingZoneFilterFields are the shipping zone columns a query filter can use
+var shippingZoneFilterFields = []string{"name", "status", "created_at"}
+
+func (s *ShippingZone) GetAllShippingZones(ctx context.Context, query *models.APIPagingDto) (*models.ShippingZonesResponse, error) {
+	var zones []*models.ShippingZone
+	var count int64
+	queryInfo, offset := getPaginationInfo(query)
+
+	db := s.repo.PostgresDb.WithContext(ctx).Model(&models.ShippingZone{}).Preload("Methods")
+	filters, err := getFilterFromQuery(query.Filter, shippingZoneFilterFields...)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range filters {
+		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
+	}
+	// then do counting of all
+	db.Count(&count)
+
+	db = db.Offset(offset).Limit(queryInfo.Limit).
+		Order(fmt.Sprintf("shipping_zones.%s %s", queryInfo.Sort, queryInfo.Direction)).
+		Find(&zones)
+
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetAll error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("record not found")
+	}
+
+	pagingInfo := getPagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(zones)
+	return &models.ShippingZonesResponse{
+		ShippingZones: zones,
+		PagingInfo:    &pagingInfo,
+	}, nil
+}
+
+// GetActiveShippingZones gets the active zones that include a country with their active methods
+func (s *ShippingZone) GetActiveShippingZones(ctx context.Context, country string) ([]*models.ShippingZone, error) {
+	var zones []*models.ShippingZone
+	db := s.repo.PostgresDb.WithContext(ctx).
+		Where("status = ? AND countries @> ?", string(models.SHIPPING_ACTIVE), fmt.Sprintf("[%q]", country)).
+		Preload("Methods", func(db *gorm.DB) *gorm.DB {
+			return db.Where("status = ?", string(models.SHIPPING_ACTIVE))
+		}).
+		Find(&zones)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetActiveShippingZones error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return zones, nil
+}
+
+// UpdateShippingZoneById updates a shipping zone with a map so lists can be emptied
+func (s *ShippingZone) UpdateShippingZoneById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := s.repo.PostgresDb.WithContext(ctx).Model(&models.ShippingZone{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateShippingZoneById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// DeleteShippingZone deletes a shipping zone, its methods go with it
+func (s *ShippingZone) DeleteShippingZone(ctx context.Context, id uuid.UUID) error {
+	db := s.repo.PostgresDb.WithContext(ctx).Delete(&models.ShippingZone{Id: id})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteShippingZone error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// StockAlert repo object
+type StockAlert struct {
+	repo *db.Database
+}
+
+// StockAlertRepo exposes the products and variants that ran low on stock to other packages.
+// Each is alerted on once until its stock goes back above the reorder threshold.
+type StockAlertRepo interface {
+	GetLowStockItems(ctx context.Context) ([]*models.LowStockItem, error)
+	MarkLowStockAlerted(ctx context.Context, items []*models.LowStockItem) error
+	ResetLowStockAlerts(ctx context.Context) error
+}
+
+// NewStockAlertRepo instantiates the StockAlert Repo object
+func NewStockAlertRepo(db *db.Database) StockAlertRepo {
+	alert := &StockAlert{
+		repo: db,
+	}
+	return StockAlertRepo(alert)
+}
+
+// GetLowStockItems gets the products and variants at or below their reorder threshold that admins were not
+// alerted on yet, the lowest first. Products with variants are stocked through them and only their variants count.
+func (s *StockAlert) GetLowStockItems(ctx context.Context) ([]*models.LowStockItem, error) {
+	var items []*models.LowStockItem
+	db := s.repo.PostgresDb.WithContext(ctx).Raw(`
+		SELECT p.id AS product_id, NULL::uuid AS variant_id, p.name, '' AS sku, p.available_quantity, p.reorder_threshold
+		FROM products p
+		WHERE p.deleted_at IS NULL AND p.reorder_threshold > 0 AND p.low_stock_alerted_at IS NULL
+			AND p.available_quantity <= p.reorder_threshold
+			AND NOT EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = p.id)
+		UNION ALL
+		SELECT p.id, v.id, p.name, v.sku, v.available_quantity, p.reorder_threshold
+		FROM product_variants v JOIN products p ON p.id = v.product_id
+		WHERE p.deleted_at IS NULL AND p.reorder_threshold > 0 AND v.low_stock_alerted_at IS NULL
+			AND v.available_quantity <= p.reorder_threshold
+		ORDER BY available_quantity ASC`).Scan(&items)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetLowStockItems error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return items, nil
+}
+
+// MarkLowStockAlerted records that admins were alerted on products and variants
+func (s *StockAlert) MarkLowStockAlerted(ctx context.Context, items []*models.LowStockItem) error {
+	productIds, variantIds := []uuid.UUID{}, []uuid.UUID{}
+	for _, item := range items {
+		if item.VariantId != nil {
+			variantIds = append(variantIds, *item.VariantId)
+			continue
+		}
+		productIds = append(productIds, item.ProductId)
+	}
+	now := time.Now().UTC()
+	if len(productIds) > 0 {
+		db := s.repo.PostgresDb.WithContext(ctx).Model(&models.Product{}).
+			Where("id IN ?", productIds).
+			UpdateColumn("low_stock_alerted_at", now)
+		if db.Error != nil {
+			log.Err(db.Error).Msgf("Basic::MarkLowStockAlerted error: %v, (%v)", "update not successful", db.Error)
+			return errors.New("update not successful")
+		}
+	}
+	if len(variantIds) > 0 {
+		db := s.repo.PostgresDb.WithContext(ctx).Model(&models.ProductVariant{}).
+			Where("id IN ?", variantIds).
+			UpdateColumn("low_stock_alerted_at", now)
+		if db.Error != nil {
+			log.Err(db.Error).Msgf("Basic::MarkLowStockAlerted error: %v, (%v)", "update not successful", db.Error)
+			return errors.New("update not successful")
+		}
+	}
+	return nil
+}
+
+// ResetLowStockAlerts clears the alerts of products and variants that were restocked above their reorder
+// threshold, or whose threshold was turned off, so they are alerted on again the next time they run low
+func (s *StockAlert) ResetLowStockAlerts(ctx context.Context) error {
+	db := s.repo.PostgresDb.WithContext(ctx).Exec(`
+		UPDATE products SET low_stock_alerted_at = NULL
+		WHERE low_stock_alerted_at IS NOT NULL AND (available_quantity > reorder_threshold OR reorder_threshold = 0)`)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::ResetLowStockAlerts error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	db = s.repo.PostgresDb.WithContext(ctx).Exec(`
+		UPDATE product_variants v SET low_stock_alerted_at = NULL
+		FROM products p
+		WHERE p.id = v.product_id AND v.low_stock_alerted_at IS NOT NULL
+			AND (v.available_quantity > p.reorder_threshold OR p.reorder_threshold = 0)`)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::ResetLowStockAlerts error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	return nil
+}
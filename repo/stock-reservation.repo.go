@@ -0,0 +1,93 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// StockReservation repo object
+type StockReservation struct {
+	repo *db.Database
+}
+
+// StockReservationRepo exposes stock reservation's methods to other packages
+type StockReservationRepo interface {
+	CreateStockReservations(ctx context.Context, reservations []*models.StockReservation) error
+	GetStockReservationsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.StockReservation, error)
+	GetExpiredStockReservations(ctx context.Context, now time.Time, limit int) ([]*models.StockReservation, error)
+	MoveStockReservation(ctx context.Context, id uuid.UUID, from string, to string) error
+}
+
+// NewStockReservationRepo instantiates the StockReservation Repo object
+func NewStockReservationRepo(db *db.Database) StockReservationRepo {
+	reservation := &StockReservation{
+		repo: db,
+	}
+	return StockReservationRepo(reservation)
+}
+
+// CreateStockReservations stores the stock held for a pending order
+func (s *StockReservation) CreateStockReservations(ctx context.Context, reservations []*models.StockReservation) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+	for _, reservation := range reservations {
+		reservation.CreatedAt = time.Now().UTC()
+		reservation.UpdatedAt = time.Now().UTC()
+	}
+
+	db := s.repo.PostgresDb.WithContext(ctx).Create(reservations)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateStockReservations error: %v, (%v)", "", db.Error)
+		return errors.New("an error occurred")
+	}
+	return nil
+}
+
+// GetStockReservationsByFields gets reservations in the order they were made
+func (s *StockReservation) GetStockReservationsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.StockReservation, error) {
+	var reservations []*models.StockReservation
+	db := s.repo.PostgresDb.WithContext(ctx).Where(fields).Order("created_at asc").Find(&reservations)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetStockReservationsByFields error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return reservations, nil
+}
+
+// GetExpiredStockReservations gets active reservations that expired before now, the oldest first
+func (s *StockReservation) GetExpiredStockReservations(ctx context.Context, now time.Time, limit int) ([]*models.StockReservation, error) {
+	var reservations []*models.StockReservation
+	db := s.repo.PostgresDb.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", string(models.RESERVATION_ACTIVE), now).
+		Order("expires_at asc").Limit(limit).Find(&reservations)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetExpiredStockReservations error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return reservations, nil
+}
+
+// MoveStockReservation changes the status of a reservation only if it is still in the from status, so a
+// reservation is released or converted once even when the sweeper and an order update race
+func (s *StockReservation) MoveStockReservation(ctx context.Context, id uuid.UUID, from string, to string) error {
+	db := s.repo.PostgresDb.WithContext(ctx).Model(&models.StockReservation{}).
+		Where("id = ? AND status = ?", id, from).
+		UpdateColumns(map[string]interface{}{"status": to, "updated_at": time.Now().UTC()})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::MoveStockReservation error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if db.RowsAffected == 0 {
+		return messages.ErrReservationNotActive
+	}
+	return nil
+}
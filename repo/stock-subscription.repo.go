@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// StockSubscription repo object
+type StockSubscription struct {
+	repo *db.Database
+}
+
+// StockSubscriptionRepo exposes stock subscription's methods to other packages
+type StockSubscriptionRepo interface {
+	CreateStockSubscription(ctx context.Context, subscription *models.StockSubscription) (*models.StockSubscription, error)
+	GetStockSubscriptionByFields(ctx context.Context, fields map[string]interface{}) (*models.StockSubscription, error)
+	GetStockSubscriptionsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.StockSubscription, error)
+	UpdateStockSubscriptionById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	DeleteStockSubscription(ctx context.Context, id uuid.UUID) error
+}
+
+// NewStockSubscriptionRepo instantiates the StockSubscription Repo object
+func NewStockSubscriptionRepo(db *db.Database) StockSubscriptionRepo {
+	subscription := &StockSubscription{
+		repo: db,
+	}
+	return StockSubscriptionRepo(subscription)
+}
+
+func (s *StockSubscription) CreateStockSubscription(ctx context.Context, subscription *models.StockSubscription) (*models.StockSubscription, error) {
+	subscription.CreatedAt = time.Now().UTC()
+	subscription.UpdatedAt = time.Now().UTC()
+
+	db := s.repo.PostgresDb.WithContext(ctx).Create(subscription)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateStockSubscription error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, messages.ErrAlreadySubscribedToProduct
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return subscription, nil
+}
+
+func (s *StockSubscription) GetStockSubscriptionByFields(ctx context.Context, fields map[string]interface{}) (*models.StockSubscription, error) {
+	var subscription models.StockSubscription
+	db := s.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&subscription)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetStockSubscriptionByFields error: %v, (%v)", "record not found", db.Error)
+		return &subscription, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if subscription.Id == uuid.Nil {
+		return nil, messages.ErrStockSubscriptionNotFound
+	}
+	return &subscription, nil
+}
+
+// GetStockSubscriptionsByFields gets subscriptions in the order customers subscribed
+func (s *StockSubscription) GetStockSubscriptionsByFields(ctx context.Context, fields map[string]interface{}) ([]*models.StockSubscription, error) {
+	var subscriptions []*models.StockSubscription
+	db := s.repo.PostgresDb.WithContext(ctx).Where(fields).Order("created_at asc").Find(&subscriptions)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetStockSubscriptionsByFields error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return subscriptions, nil
+}
+
+func (s *StockSubscription) UpdateStockSubscriptionById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := s.repo.PostgresDb.WithContext(ctx).Model(&models.StockSubscription{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateStockSubscriptionById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+func (s *StockSubscription) DeleteStockSubscription(ctx context.Context, id uuid.UUID) error {
+	db := s.repo.PostgresDb.WithContext(ctx).Delete(&models.StockSubscription{}, "id = ?", id)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::DeleteStockSubscription error: %v, (%v)", "delete not successful", db.Error)
+		return errors.New("delete not successful")
+	}
+	return nil
+}
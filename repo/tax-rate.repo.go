@@ -0,0 +1,129 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// TaxRate repo object
+type TaxRate struct {
+	repo *db.Database
+}
+
+// TaxRateRepo exposes tax rate's methods to other packages
+type TaxRateRepo interface {
+	CreateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error)
+	GetTaxRateByFields(ctx context.Context, fields map[string]interface{}) (*models.TaxRate, error)
+	GetAllTaxRates(ctx context.Context, query *models.APIPagingDto) (*models.TaxRatesResponse, error)
+	GetActiveTaxRates(ctx context.Context, country string) ([]*models.TaxRate, error)
+	UpdateTaxRateById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+}
+
+// NewTaxRateRepo instantiates the TaxRate Repo object
+func NewTaxRateRepo(db *db.Database) TaxRateRepo {
+	rate := &TaxRate{
+		repo: db,
+	}
+	return TaxRateRepo(rate)
+}
+
+// CreateTaxRate stores a new tax rate
+func (t *TaxRate) CreateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	rate.CreatedAt = time.Now().UTC()
+	rate.UpdatedAt = time.Now().UTC()
+
+	db := t.repo.PostgresDb.WithContext(ctx).Create(rate)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateTaxRate error: %v, (%v)", "", db.Error)
+		return nil, errors.New("an error occurred")
+	}
+	return rate, nil
+}
+
+func (t *TaxRate) GetTaxRateByFields(ctx context.Context, fields map[string]interface{}) (*models.TaxRate, error) {
+	var rate models.TaxRate
+	db := t.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&rate)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetTaxRateByFields error: %v, (%v)", "record not found", db.Error)
+		return &rate, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if rate.Id == uuid.Nil {
+		return nil, messages.ErrTaxRateNotFound
+	}
+	return &rate, nil
+}
+
+// taxRateFilterFields are the tax rate columns a query filter can use
+var taxRateFilterFields = []string{"name", "country", "region", "tax_class", "inclusive", "status", "created_at"}
+
+func (t *TaxRate) GetAllTaxRates(ctx context.Context, query *models.APIPagingDto) (*models.TaxRatesResponse, error) {
+	var rates []*models.TaxRate
+	var count int64
+	queryInfo, offset := getPaginationInfo(query)
+
+	db := t.repo.PostgresDb.WithContext(ctx).Model(&models.TaxRate{})
+	filters, err := getFilterFromQuery(query.Filter, taxRateFilterFields...)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range filters {
+		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
+	}
+	// then do counting of all
+	db.Count(&count)
+
+	db = db.Offset(offset).Limit(queryInfo.Limit).
+		Order(fmt.Sprintf("tax_rates.%s %s", queryInfo.Sort, queryInfo.Direction)).
+		Find(&rates)
+
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetAll error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("record not found")
+	}
+
+	pagingInfo := getPagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(rates)
+	return &models.TaxRatesResponse{
+		TaxRates:   rates,
+		PagingInfo: &pagingInfo,
+	}, nil
+}
+
+// GetActiveTaxRates gets the active tax rates of a country, region specific rates first
+func (t *TaxRate) GetActiveTaxRates(ctx context.Context, country string) ([]*models.TaxRate, error) {
+	var rates []*models.TaxRate
+	db := t.repo.PostgresDb.WithContext(ctx).
+		Where("country = ? AND status = ?", country, string(models.TAX_RATE_ACTIVE)).
+		Order("region desc").
+		Find(&rates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetActiveTaxRates error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return rates, nil
+}
+
+// UpdateTaxRateById updates a tax rate with a map so boolean values can be set
+func (t *TaxRate) UpdateTaxRateById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := t.repo.PostgresDb.WithContext(ctx).Model(&models.TaxRate{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateTaxRateById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
@@ -23,6 +23,8 @@ type User struct {
 type UserRepo interface {
 	CreateUser(ctx context.Context, user *models.User) (*models.User, error)
 	GetUserByFields(ctx context.Context, fields map[string]interface{}) (*models.User, error)
+	GetUsersByFields(ctx context.Context, fields map[string]interface{}) ([]*models.User, error)
+	UpdateUserById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
 }
 
 // NewUserRepo instantiates the User Repo object
@@ -64,3 +66,28 @@ func (u *User) GetUserByFields(ctx context.Context, fields map[string]interface{
 	}
 	return &user, nil
 }
+
+// GetUsersByFields gets every user matching fields
+func (u *User) GetUsersByFields(ctx context.Context, fields map[string]interface{}) ([]*models.User, error) {
+	var users []*models.User
+	db := u.repo.PostgresDb.WithContext(ctx).Where(fields).Order("created_at asc").Find(&users)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetUsersByFields error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return users, nil
+}
+
+// UpdateUserById updates a user with a map so boolean values can be set
+func (u *User) UpdateUserById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := u.repo.PostgresDb.WithContext(ctx).Model(&models.User{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateUserById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// WarehouseStock repo object
+type WarehouseStock struct {
+	repo *db.Database
+}
+
+// WarehouseStockRepo exposes the stock held in warehouses to other packages
+type WarehouseStockRepo interface {
+	AddWarehouseStock(ctx context.Context, warehouseId uuid.UUID, productId uuid.UUID, variantId *uuid.UUID, quantity int64) (int64, error)
+	GetWarehouseStocks(ctx context.Context, productIds []uuid.UUID) ([]*models.WarehouseStock, error)
+	ReserveWarehouseStock(ctx context.Context, warehouseId uuid.UUID, productId uuid.UUID, variantId *uuid.UUID, quantity int64) error
+}
+
+// NewWarehouseStockRepo instantiates the WarehouseStock Repo object
+func NewWarehouseStockRepo(db *db.Database) WarehouseStockRepo {
+	stock := &WarehouseStock{
+		repo: db,
+	}
+	return WarehouseStockRepo(stock)
+}
+
+// AddWarehouseStock moves the stock of a product or variant in a warehouse by quantity, starting it
+// when the warehouse had none, and returns the quantity it ends up with
+func (w *WarehouseStock) AddWarehouseStock(ctx context.Context, warehouseId uuid.UUID, productId uuid.UUID, variantId *uuid.UUID, quantity int64) (int64, error) {
+	var balance int64
+	now := time.Now().UTC()
+	db := w.repo.PostgresDb.WithContext(ctx).Raw(`
+		INSERT INTO warehouse_stocks (id, warehouse_id, product_id, variant_id, quantity, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (warehouse_id, product_id, (COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid)))
+		DO UPDATE SET quantity = warehouse_stocks.quantity + EXCLUDED.quantity, updated_at = EXCLUDED.updated_at
+		RETURNING quantity`, uuid.New(), warehouseId, productId, variantId, quantity, now, now).Scan(&balance)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::AddWarehouseStock error: %v, (%v)", "update not successful", db.Error)
+		return 0, errors.New("update not successful")
+	}
+	return balance, nil
+}
+
+// GetWarehouseStocks gets the stock of products and their variants in every warehouse
+func (w *WarehouseStock) GetWarehouseStocks(ctx context.Context, productIds []uuid.UUID) ([]*models.WarehouseStock, error) {
+	var stocks []*models.WarehouseStock
+	db := w.repo.PostgresDb.WithContext(ctx).Where("product_id IN ?", productIds).Find(&stocks)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetWarehouseStocks error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return stocks, nil
+}
+
+// ReserveWarehouseStock holds quantity of a product or variant in a warehouse for a pending order, or
+// releases it when quantity is negative. Stock that is not there to hold is not reserved.
+func (w *WarehouseStock) ReserveWarehouseStock(ctx context.Context, warehouseId uuid.UUID, productId uuid.UUID, variantId *uuid.UUID, quantity int64) error {
+	db := w.repo.PostgresDb.WithContext(ctx).Model(&models.WarehouseStock{}).
+		Where("warehouse_id = ? AND product_id = ?", warehouseId, productId)
+	if variantId != nil {
+		db = db.Where("variant_id = ?", *variantId)
+	} else {
+		db = db.Where("variant_id IS NULL")
+	}
+	if quantity > 0 {
+		db = db.Where("quantity - reserved_quantity >= ?", quantity)
+	}
+	db = db.UpdateColumns(map[string]interface{}{
+		"reserved_quantity": gorm.Expr("GREATEST(reserved_quantity + ?, 0)", quantity),
+		"updated_at":        time.Now().UTC(),
+	})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::ReserveWarehouseStock error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	if db.RowsAffected == 0 && quantity > 0 {
+		return messages.ErrInsufficientStock
+	}
+	return nil
+}
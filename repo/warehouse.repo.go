@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/common/messages"
+	"e-commerce/db"
+	"e-commerce/models"
+)
+
+// Warehouse repo object
+type Warehouse struct {
+	repo *db.Database
+}
+
+// WarehouseRepo exposes warehouse's methods to other packages
+type WarehouseRepo interface {
+	CreateWarehouse(ctx context.Context, warehouse *models.Warehouse) (*models.Warehouse, error)
+	GetWarehouseByFields(ctx context.Context, fields map[string]interface{}) (*models.Warehouse, error)
+	GetAllWarehouses(ctx context.Context, query *models.APIPagingDto) (*models.WarehousesResponse, error)
+	GetActiveWarehouses(ctx context.Context) ([]*models.Warehouse, error)
+	UpdateWarehouseById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error
+	UnsetDefaultWarehouse(ctx context.Context) error
+}
+
+// NewWarehouseRepo instantiates the Warehouse Repo object
+func NewWarehouseRepo(db *db.Database) WarehouseRepo {
+	warehouse := &Warehouse{
+		repo: db,
+	}
+	return WarehouseRepo(warehouse)
+}
+
+func (w *Warehouse) CreateWarehouse(ctx context.Context, warehouse *models.Warehouse) (*models.Warehouse, error) {
+	warehouse.CreatedAt = time.Now().UTC()
+	warehouse.UpdatedAt = time.Now().UTC()
+
+	db := w.repo.PostgresDb.WithContext(ctx).Create(warehouse)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::CreateWarehouse error: %v, (%v)", "", db.Error)
+		if strings.Contains(db.Error.Error(), "duplicate key value") {
+			return nil, messages.ErrWarehouseWithCodeAlreadyExists
+		}
+		return nil, errors.New("an error occurred")
+	}
+	return warehouse, nil
+}
+
+func (w *Warehouse) GetWarehouseByFields(ctx context.Context, fields map[string]interface{}) (*models.Warehouse, error) {
+	var warehouse models.Warehouse
+	db := w.repo.PostgresDb.WithContext(ctx).Where(fields).Find(&warehouse)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetWarehouseByFields error: %v, (%v)", "record not found", db.Error)
+		return &warehouse, errors.New("something went wrong")
+	}
+
+	// means no record was found
+	if warehouse.Id == uuid.Nil {
+		return nil, messages.ErrWarehouseNotFound
+	}
+	return &warehouse, nil
+}
+
+// warehouseFilterFields are the warehouse columns a query filter can use
+var warehouseFilterFields = []string{"name", "code", "country", "region", "priority", "status", "is_default", "created_at"}
+
+func (w *Warehouse) GetAllWarehouses(ctx context.Context, query *models.APIPagingDto) (*models.WarehousesResponse, error) {
+	var warehouses []*models.Warehouse
+	var count int64
+	queryInfo, offset := getPaginationInfo(query)
+
+	db := w.repo.PostgresDb.WithContext(ctx).Model(&models.Warehouse{})
+	filters, err := getFilterFromQuery(query.Filter, warehouseFilterFields...)
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range filters {
+		db = db.Where(fmt.Sprintf("%s %s ?", filter.field, filter.condition), filter.value)
+	}
+	// then do counting of all
+	db.Count(&count)
+
+	db = db.Offset(offset).Limit(queryInfo.Limit).
+		Order(fmt.Sprintf("warehouses.%s %s", queryInfo.Sort, queryInfo.Direction)).
+		Find(&warehouses)
+
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetAll error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("record not found")
+	}
+
+	pagingInfo := getPagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(warehouses)
+	return &models.WarehousesResponse{
+		Warehouses: warehouses,
+		PagingInfo: &pagingInfo,
+	}, nil
+}
+
+// GetActiveWarehouses gets the warehouses orders can be fulfilled from, by priority
+func (w *Warehouse) GetActiveWarehouses(ctx context.Context) ([]*models.Warehouse, error) {
+	var warehouses []*models.Warehouse
+	db := w.repo.PostgresDb.WithContext(ctx).Where("status = ?", string(models.WAREHOUSE_ACTIVE)).
+		Order("priority asc").Order("created_at asc").Find(&warehouses)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::GetActiveWarehouses error: %v, (%v)", "record not found", db.Error)
+		return nil, errors.New("something went wrong")
+	}
+	return warehouses, nil
+}
+
+// UpdateWarehouseById updates a warehouse with a map so its priority can be set to zero
+func (w *Warehouse) UpdateWarehouseById(ctx context.Context, id uuid.UUID, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().UTC()
+	db := w.repo.PostgresDb.WithContext(ctx).Model(&models.Warehouse{
+		Id: id,
+	}).UpdateColumns(updates)
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UpdateWarehouseById error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+
+	return nil
+}
+
+// UnsetDefaultWarehouse makes no warehouse the default, so another one can be
+func (w *Warehouse) UnsetDefaultWarehouse(ctx context.Context) error {
+	db := w.repo.PostgresDb.WithContext(ctx).Model(&models.Warehouse{}).
+		Where("is_default = ?", true).
+		UpdateColumns(map[string]interface{}{"is_default": false, "updated_at": time.Now().UTC()})
+	if db.Error != nil {
+		log.Err(db.Error).Msgf("Basic::UnsetDefaultWarehouse error: %v, (%v)", "update not successful", db.Error)
+		return errors.New("update not successful")
+	}
+	return nil
+}
@@ -5,15 +5,17 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"e-commerce/config"
 	"e-commerce/handlers"
 )
 
 type Routes struct {
 	handler handlers.Operations
+	config  *config.ConfigType
 }
 
-func NewRoutes(h handlers.Operations) Routes {
-	return Routes{handler: h}
+func NewRoutes(h handlers.Operations, config *config.ConfigType) Routes {
+	return Routes{handler: h, config: config}
 }
 
 func (ro Routes) RegisterRoutes(r *gin.Engine, handler handlers.Operations) {
@@ -24,18 +26,184 @@ func (ro Routes) RegisterRoutes(r *gin.Engine, handler handlers.Operations) {
 	{
 		products.POST("", handler.AdminPermissionMiddleware(), handler.CreateProduct)
 		products.GET("", handler.GetAllProducts)
+		products.GET("/search", handler.SearchProducts)
+		products.GET("/autocomplete", handler.AutocompleteProducts)
+		products.POST("/import", handler.AdminPermissionMiddleware(), handler.ImportProducts)
+		products.GET("/imports/:id", handler.AdminPermissionMiddleware(), handler.GetProductImport)
+		products.GET("/export", handler.AdminPermissionMiddleware(), handler.ExportProducts)
 		products.GET("/:id", handler.GetSingleProduct)
 		products.PUT("/:id", handler.AdminPermissionMiddleware(), handler.UpdateProduct)
 		products.DELETE("/:id", handler.AdminPermissionMiddleware(), handler.DeleteProduct)
+		products.POST("/:id/variants", handler.AdminPermissionMiddleware(), handler.CreateProductVariant)
+		products.PUT("/:id/variants/:variant_id", handler.AdminPermissionMiddleware(), handler.UpdateProductVariant)
+		products.DELETE("/:id/variants/:variant_id", handler.AdminPermissionMiddleware(), handler.DeleteProductVariant)
+		products.POST("/:id/media", handler.AdminPermissionMiddleware(), handler.UploadProductMedia)
+		products.PUT("/:id/media/:media_id", handler.AdminPermissionMiddleware(), handler.UpdateProductMedia)
+		products.DELETE("/:id/media/:media_id", handler.AdminPermissionMiddleware(), handler.DeleteProductMedia)
+		products.GET("/:id/inventory", handler.AdminPermissionMiddleware(), handler.GetProductInventory)
+		products.POST("/:id/inventory", handler.AdminPermissionMiddleware(), handler.AdjustProductInventory)
+		products.POST("/:id/notify-me", handler.SubscribeToProduct)
+		products.DELETE("/:id/notify-me", handler.UnsubscribeFromProduct)
+	}
+
+	// categories
+	categories := r.Group("categories")
+	{
+		categories.GET("", handler.GetCategoryTree)
+		categories.GET("/:id", handler.GetSingleCategory)
+		categories.POST("", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.CreateCategory)
+		categories.PUT("/:id", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.UpdateCategory)
+		categories.PUT("/:id/move", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.MoveCategory)
+		categories.DELETE("/:id", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.DeleteCategory)
+		categories.GET("/:id/attributes", handler.GetCategoryAttributes)
+		categories.POST("/:id/attributes", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.CreateCategoryAttribute)
+		categories.PUT("/:id/attributes/:attribute_id", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.UpdateCategoryAttribute)
+		categories.DELETE("/:id/attributes/:attribute_id", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.DeleteCategoryAttribute)
 	}
 	// orders
 	orders := r.Group("orders", handler.AuthenticatedUserMiddleware())
 	{
 		orders.POST("", handler.UserPermissionMiddleware(), handler.PlaceOrder)
+		orders.POST("/price", handler.UserPermissionMiddleware(), handler.PriceOrder)
 		orders.GET("", handler.UserPermissionMiddleware(), handler.GetAllOrders)
-		orders.GET("/:id", handler.UserPermissionMiddleware(), handler.GetSingleOrder)
+		orders.GET("/:id", handler.GetSingleOrder)
 		orders.PUT("/:id/status", handler.AdminPermissionMiddleware(), handler.UpdateOrderStatus)
 		orders.PUT("/:id/cancel", handler.UserPermissionMiddleware(), handler.CancelOrder)
+		orders.POST("/:id/payments", handler.UserPermissionMiddleware(), handler.InitializePayment)
+		orders.POST("/:id/refunds", handler.AdminPermissionMiddleware(), handler.CreateRefund)
+		orders.GET("/:id/refunds", handler.AdminPermissionMiddleware(), handler.GetOrderRefunds)
+		orders.POST("/:id/shipments", handler.AdminPermissionMiddleware(), handler.CreateShipment)
+		orders.GET("/:id/shipments", handler.GetOrderShipments)
+		orders.POST("/:id/returns", handler.UserPermissionMiddleware(), handler.CreateReturn)
+		orders.GET("/:id/returns", handler.GetOrderReturns)
+	}
+
+	// order tracking
+	track := r.Group("track", handler.TrackingRateLimitMiddleware())
+	{
+		track.GET("/:code", handler.TrackOrder)
+	}
+
+	// address book
+	me := r.Group("me", handler.AuthenticatedUserMiddleware())
+	{
+		me.POST("/addresses", handler.CreateAddress)
+		me.GET("/addresses", handler.GetAddresses)
+		me.GET("/addresses/:id", handler.GetSingleAddress)
+		me.PUT("/addresses/:id", handler.UpdateAddress)
+		me.DELETE("/addresses/:id", handler.DeleteAddress)
+	}
+
+	// coupons
+	coupons := r.Group("coupons", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware())
+	{
+		coupons.POST("", handler.CreateCoupon)
+		coupons.GET("", handler.GetAllCoupons)
+		coupons.GET("/:id", handler.GetSingleCoupon)
+		coupons.PUT("/:id", handler.UpdateCoupon)
+	}
+
+	// promotions
+	promotions := r.Group("promotions", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware())
+	{
+		promotions.POST("", handler.CreatePromotion)
+		promotions.GET("", handler.GetAllPromotions)
+		promotions.GET("/:id", handler.GetSinglePromotion)
+		promotions.PUT("/:id", handler.UpdatePromotion)
+		promotions.DELETE("/:id", handler.DeletePromotion)
+	}
+
+	// exchange rates
+	exchangeRates := r.Group("exchange-rates", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware())
+	{
+		exchangeRates.PUT("", handler.SetExchangeRate)
+		exchangeRates.GET("", handler.GetAllExchangeRates)
+		exchangeRates.POST("/import", handler.ImportExchangeRates)
+	}
+
+	// taxes
+	taxes := r.Group("taxes", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware())
+	{
+		taxes.POST("/rates", handler.CreateTaxRate)
+		taxes.GET("/rates", handler.GetAllTaxRates)
+		taxes.GET("/rates/:id", handler.GetSingleTaxRate)
+		taxes.PUT("/rates/:id", handler.UpdateTaxRate)
+		taxes.GET("/report", handler.GetTaxReport)
+	}
+
+	// warehouses
+	warehouses := r.Group("warehouses", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware())
+	{
+		warehouses.POST("", handler.CreateWarehouse)
+		warehouses.GET("", handler.GetAllWarehouses)
+		warehouses.GET("/:id", handler.GetSingleWarehouse)
+		warehouses.PUT("/:id", handler.UpdateWarehouse)
+	}
+
+	// returns
+	returns := r.Group("returns", handler.AuthenticatedUserMiddleware())
+	{
+		returns.GET("", handler.AdminPermissionMiddleware(), handler.GetAllReturns)
+		returns.GET("/:id", handler.GetSingleReturn)
+		returns.PUT("/:id/approve", handler.AdminPermissionMiddleware(), handler.ApproveReturn)
+		returns.PUT("/:id/reject", handler.AdminPermissionMiddleware(), handler.RejectReturn)
+		returns.PUT("/:id/receive", handler.AdminPermissionMiddleware(), handler.ReceiveReturn)
+	}
+
+	// shipments
+	shipments := r.Group("shipments")
+	{
+		shipments.POST("/:id/track", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.RefreshShipmentTracking)
+		// stands in for the fake carrier moving a parcel, it is only used in development
+		if ro.config.IsDevelopment() {
+			shipments.POST("/fake/:tracking_number", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.ScanFakeShipment)
+		}
+	}
+
+	// shipping
+	shipping := r.Group("shipping", handler.AuthenticatedUserMiddleware())
+	{
+		shipping.POST("/quote", handler.QuoteShipping)
+		shipping.POST("/zones", handler.AdminPermissionMiddleware(), handler.CreateShippingZone)
+		shipping.GET("/zones", handler.AdminPermissionMiddleware(), handler.GetAllShippingZones)
+		shipping.GET("/zones/:id", handler.AdminPermissionMiddleware(), handler.GetSingleShippingZone)
+		shipping.PUT("/zones/:id", handler.AdminPermissionMiddleware(), handler.UpdateShippingZone)
+		shipping.DELETE("/zones/:id", handler.AdminPermissionMiddleware(), handler.DeleteShippingZone)
+		shipping.POST("/zones/:id/methods", handler.AdminPermissionMiddleware(), handler.CreateShippingMethod)
+		shipping.PUT("/methods/:id", handler.AdminPermissionMiddleware(), handler.UpdateShippingMethod)
+		shipping.DELETE("/methods/:id", handler.AdminPermissionMiddleware(), handler.DeleteShippingMethod)
+	}
+
+	// users
+	users := r.Group("users", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware())
+	{
+		users.PUT("/:id/tax-exemption", handler.SetTaxExemption)
+	}
+
+	// payments
+	payments := r.Group("payments")
+	{
+		payments.GET("/:reference/verify", handler.AuthenticatedUserMiddleware(), handler.VerifyPayment)
+		// stands in for the payment page of the fake provider, which is only used in development
+		if ro.config.IsDevelopment() {
+			payments.POST("/fake/:reference", handler.AuthenticatedUserMiddleware(), handler.AdminPermissionMiddleware(), handler.CompleteFakePayment)
+		}
+	}
+
+	// cart
+	cart := r.Group("cart", handler.OptionalAuthenticatedUserMiddleware())
+	{
+		cart.GET("", handler.GetCart)
+		cart.POST("/items", handler.AddCartItem)
+		cart.PUT("/items/:id", handler.UpdateCartItem)
+		cart.DELETE("/items/:id", handler.RemoveCartItem)
+		cart.POST("/checkout", handler.AuthenticatedUserMiddleware(), handler.UserPermissionMiddleware(), handler.CheckoutCart)
+	}
+
+	// webhooks
+	webhooks := r.Group("webhooks")
+	{
+		webhooks.POST("/payments/:provider", handler.PaymentWebhook)
 	}
 
 	// auth
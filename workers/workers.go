@@ -0,0 +1,46 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"e-commerce/config"
+	"e-commerce/controllers"
+)
+
+// Start runs the background jobs of the application until the context is done
+func Start(ctx context.Context, controller controllers.Operations, config *config.ConfigType) {
+	go every(ctx, "tracking poller", interval(config.TrackingPollInterval, 15*time.Minute), controller.PollShipmentTracking)
+	go every(ctx, "reservation sweeper", interval(config.ReservationSweepInterval, time.Minute), controller.ReleaseExpiredReservations)
+	go every(ctx, "low stock alerts", interval(config.StockAlertInterval, 15*time.Minute), controller.AlertLowStock)
+}
+
+// every runs a job on an interval, logging its errors so a failed run does not stop the next
+func every(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				log.Err(err).Msgf("%s error: %v", name, err)
+			}
+		}
+	}
+}
+
+// interval parses a configured duration, falling back when it is missing or not positive
+func interval(value string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		if value != "" {
+			log.Warn().Msgf("invalid interval %q, using %s", value, fallback)
+		}
+		return fallback
+	}
+	return duration
+}